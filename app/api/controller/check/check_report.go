@@ -25,6 +25,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -182,6 +183,15 @@ func (c *Controller) Report(
 		return nil, fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
 	}
 
+	if statusCheckReport.Status.IsCompleted() {
+		c.eventReporter.CheckReported(ctx, &pullreqevents.CheckReportedPayload{
+			RepoID:     repo.ID,
+			SHA:        commitSHA,
+			Identifier: statusCheckReport.Identifier,
+			Status:     statusCheckReport.Status,
+		})
+	}
+
 	return statusCheckReport, nil
 }
 
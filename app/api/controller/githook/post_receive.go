@@ -38,6 +38,9 @@ const (
 
 	// gitReferenceNamePrefixTag is the prefix of pull req references.
 	gitReferenceNamePullReq = "refs/pullreq/"
+
+	// gitReferenceNameMergeQueue is the prefix of the speculative merge commits of the merge queue.
+	gitReferenceNameMergeQueue = "refs/merge-queue/"
 )
 
 // PostReceive executes the post-receive hook for a git repository.
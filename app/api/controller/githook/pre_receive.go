@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -53,6 +54,12 @@ func (c *Controller) PreReceive(
 			limiter.ErrMaxRepoSizeReached)
 	}
 
+	// Mirror repositories are only updated by the mirror synchronization (which doesn't trigger git hooks).
+	if repo.IsMirror {
+		output.Error = ptr.String(usererror.ErrMirrorRepoCantBeModified.Error())
+		return output, nil
+	}
+
 	refUpdates := groupRefsByAction(in.RefUpdates)
 
 	if slices.Contains(refUpdates.branches.deleted, repo.DefaultBranch) {
@@ -63,6 +70,7 @@ func (c *Controller) PreReceive(
 
 	if in.Internal {
 		// It's an internal call, so no need to verify protection rules.
+		// The rules (including signed commits) are verified by the operation before the refs are updated.
 		return output, nil
 	}
 
@@ -82,7 +90,11 @@ func (c *Controller) PreReceive(
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+	commits := protection.NewCommitLister(func(ctx context.Context, branchName string) ([]git.CommitSignedData, error) {
+		return c.listPushedCommitSignatures(ctx, repo, in, branchName)
+	})
+
+	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, commits, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 	}
@@ -92,7 +104,7 @@ func (c *Controller) PreReceive(
 
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 	fn := func(ref string) bool {
-		return strings.HasPrefix(ref, gitReferenceNamePullReq)
+		return strings.HasPrefix(ref, gitReferenceNamePullReq) || strings.HasPrefix(ref, gitReferenceNameMergeQueue)
 	}
 
 	return slices.ContainsFunc(refUpdates.other.created, fn) ||
@@ -105,6 +117,7 @@ func (c *Controller) checkProtectionRules(
 	session *auth.Session,
 	repo *types.Repository,
 	refUpdates changedRefs,
+	commits protection.CommitLister,
 	output *hook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -133,6 +146,7 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+			Commits:     commits,
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -172,6 +186,45 @@ func (c *Controller) checkProtectionRules(
 	return nil
 }
 
+// listPushedCommitSignatures returns the signatures of the commits pushed to the branch.
+// For new branches only commits that aren't reachable from any existing reference are returned.
+func (c *Controller) listPushedCommitSignatures(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	branchName string,
+) ([]git.CommitSignedData, error) {
+	ref := gitReferenceNamePrefixBranch + branchName
+	idx := slices.IndexFunc(in.RefUpdates, func(refUpdate hook.ReferenceUpdate) bool {
+		return refUpdate.Ref == ref
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("no reference update found for branch %q", branchName)
+	}
+
+	refUpdate := in.RefUpdates[idx]
+
+	params := git.ListCommitSignaturesParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		},
+		GitREF: refUpdate.New,
+	}
+	if refUpdate.Old == types.NilSHA {
+		params.ExcludeAllRefs = true
+	} else {
+		params.Exclude = []string{refUpdate.Old}
+	}
+
+	out, err := c.git.ListCommitSignatures(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit signatures: %w", err)
+	}
+
+	return out.Commits, nil
+}
+
 type changes struct {
 	created []string
 	deleted []string
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Batch returns the actions the client has to take to download or upload the requested lfs objects.
+func (c *Controller) Batch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+) (*BatchResponse, error) {
+	var (
+		reqPermission enum.Permission
+		orPublic      bool
+	)
+	switch in.Operation {
+	case OperationDownload:
+		reqPermission, orPublic = enum.PermissionRepoView, true
+	case OperationUpload:
+		reqPermission, orPublic = enum.PermissionRepoPush, false
+	default:
+		return nil, usererror.UnprocessableEntityf("Unsupported operation %q.", in.Operation)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != HashAlgoSHA256 {
+		return nil, usererror.Conflict(fmt.Sprintf("Unsupported hash algorithm %q.", in.HashAlgo))
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission, orPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		oids = append(oids, obj.OID)
+	}
+
+	existingObjects, err := c.lfsObjectStore.FindMany(ctx, repo.ID, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs objects: %w", err)
+	}
+
+	existing := make(map[string]types.LFSObject, len(existingObjects))
+	for _, obj := range existingObjects {
+		existing[obj.OID] = obj
+	}
+
+	out := &BatchResponse{
+		Transfer: TransferBasic,
+		Objects:  make([]ObjectResponse, len(in.Objects)),
+		HashAlgo: HashAlgoSHA256,
+	}
+
+	for i, pointer := range in.Objects {
+		out.Objects[i], err = c.batchObject(ctx, repo, in.Operation, pointer, existing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Controller) batchObject(
+	ctx context.Context,
+	repo *types.Repository,
+	operation string,
+	pointer Pointer,
+	existing map[string]types.LFSObject,
+) (ObjectResponse, error) {
+	res := ObjectResponse{Pointer: pointer}
+
+	if err := validateOID(pointer.OID); err != nil || pointer.Size < 0 {
+		res.Error = &ObjectError{Code: http.StatusUnprocessableEntity, Message: "Invalid object"}
+		return res, nil
+	}
+
+	obj, exists := existing[pointer.OID]
+
+	// objects that already exist don't have to be uploaded again.
+	if operation == OperationUpload {
+		if !exists {
+			res.Actions = map[string]Action{
+				OperationUpload: {Href: c.objectURL(repo, pointer.OID)},
+			}
+		}
+
+		return res, nil
+	}
+
+	if !exists {
+		res.Error = &ObjectError{Code: http.StatusNotFound, Message: "Object does not exist"}
+		return res, nil
+	}
+
+	res.Size = obj.Size
+
+	// prefer direct downloads from the blob store if supported.
+	href, err := c.blobStore.GetSignedURL(ctx, getObjectBucketPath(repo.ID, pointer.OID))
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return res, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if href == "" {
+		href = c.objectURL(repo, pointer.OID)
+	} else {
+		res.Authenticated = true
+	}
+
+	res.Actions = map[string]Action{
+		OperationDownload: {Href: href},
+	}
+
+	return res, nil
+}
+
+func (c *Controller) objectURL(repo *types.Repository, oid string) string {
+	return c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/objects/" + oid
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+)
+
+const (
+	objectBucketPathFmt = "lfs/%d/%s"
+	uploadBucketPathFmt = "lfs/%d/tmp/%s"
+	maxLocksPageSize    = 100
+)
+
+var oidRegex = regexp.MustCompile("^[0-9a-f]{64}$")
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	urlProvider        url.Provider
+	repoStore          store.RepoStore
+	principalInfoCache store.PrincipalInfoCache
+	lfsObjectStore     store.LFSObjectStore
+	lfsLockStore       store.LFSLockStore
+	blobStore          blob.Store
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	urlProvider url.Provider,
+	repoStore store.RepoStore,
+	principalInfoCache store.PrincipalInfoCache,
+	lfsObjectStore store.LFSObjectStore,
+	lfsLockStore store.LFSLockStore,
+	blobStore blob.Store,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		urlProvider:        urlProvider,
+		repoStore:          repoStore,
+		principalInfoCache: principalInfoCache,
+		lfsObjectStore:     lfsObjectStore,
+		lfsLockStore:       lfsLockStore,
+		blobStore:          blobStore,
+	}
+}
+
+// getRepoCheckAccess fetches an active repo (not one that is currently being imported)
+// and checks if the current user has permission to access it.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	orPublic bool,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("Repository import is in progress.")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, orPublic); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+func validateOID(oid string) error {
+	if !oidRegex.MatchString(oid) {
+		return usererror.UnprocessableEntityf("Invalid object id %q, expected a sha256 hash.", oid)
+	}
+
+	return nil
+}
+
+func getObjectBucketPath(repoID int64, oid string) string {
+	return fmt.Sprintf(objectBucketPathFmt, repoID, oid)
+}
+
+func getUploadBucketPath(repoID int64) string {
+	return fmt.Sprintf(uploadBucketPathFmt, repoID, uuid.NewString())
+}
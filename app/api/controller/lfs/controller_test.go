@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOID(t *testing.T) {
+	tests := []struct {
+		name    string
+		oid     string
+		wantErr bool
+	}{
+		{
+			name: "sha256",
+			oid:  strings.Repeat("0123456789abcdef", 4),
+		},
+		{
+			name:    "empty",
+			oid:     "",
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			oid:     strings.Repeat("a", 63),
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			oid:     strings.Repeat("a", 65),
+			wantErr: true,
+		},
+		{
+			name:    "upper case",
+			oid:     strings.Repeat("A", 64),
+			wantErr: true,
+		},
+		{
+			name:    "path traversal",
+			oid:     "../" + strings.Repeat("a", 61),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOID(test.oid)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   string
+		wantPage int
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			cursor:   "",
+			wantPage: 1,
+		},
+		{
+			name:     "page",
+			cursor:   "3",
+			wantPage: 3,
+		},
+		{
+			name:    "zero",
+			cursor:  "0",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			cursor:  "-1",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			cursor:  "next",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, err := parseCursor(test.cursor)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantPage, page)
+		})
+	}
+}
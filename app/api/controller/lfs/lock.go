@@ -0,0 +1,260 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CreateLock locks a path of a repository for the current principal.
+func (c *Controller) CreateLock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateLockInput,
+) (*Lock, error) {
+	in.Path = strings.TrimSpace(in.Path)
+	if in.Path == "" {
+		return nil, usererror.BadRequest("The path to lock is required.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:    repo.ID,
+		Path:      in.Path,
+		Ref:       refName(in.Ref),
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("The path %q is already locked.", in.Path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lfs lock: %w", err)
+	}
+
+	locks, err := c.mapLocks(ctx, []types.LFSLock{*lock})
+	if err != nil {
+		return nil, err
+	}
+
+	return &locks[0], nil
+}
+
+// ListLocks lists the lfs locks of a repository.
+// The returned cursor is empty if there are no further locks.
+func (c *Controller) ListLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.LFSLockFilter,
+) ([]Lock, string, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, "", err
+	}
+
+	list, nextCursor, err := c.listLocks(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	locks, err := c.mapLocks(ctx, list)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return locks, nextCursor, nil
+}
+
+// VerifyLocks lists the lfs locks of a repository split by whether they're owned by the current principal.
+func (c *Controller) VerifyLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *VerifyLocksInput,
+) (*VerifyLocksResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := parseCursor(in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	list, nextCursor, err := c.listLocks(ctx, repo.ID, &types.LFSLockFilter{
+		Pagination: types.Pagination{Page: page, Size: in.Limit},
+		Ref:        refName(in.Ref),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := c.mapLocks(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &VerifyLocksResponse{
+		Ours:       []Lock{},
+		Theirs:     []Lock{},
+		NextCursor: nextCursor,
+	}
+	for i := range list {
+		if list[i].CreatedBy == session.Principal.ID {
+			out.Ours = append(out.Ours, locks[i])
+		} else {
+			out.Theirs = append(out.Theirs, locks[i])
+		}
+	}
+
+	return out, nil
+}
+
+// Unlock removes an lfs lock of a repository.
+// Locks of other principals can only be removed with force and repo edit permission.
+func (c *Controller) Unlock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	lockID int64,
+	in *UnlockInput,
+) (*Lock, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, repo.ID, lockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs lock: %w", err)
+	}
+
+	if lock.CreatedBy != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("The lock is owned by another user.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+			return nil, err
+		}
+	}
+
+	locks, err := c.mapLocks(ctx, []types.LFSLock{*lock})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.lfsLockStore.Delete(ctx, repo.ID, lock.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete lfs lock: %w", err)
+	}
+
+	return &locks[0], nil
+}
+
+// listLocks lists the locks of a repository and returns the cursor of the next page.
+func (c *Controller) listLocks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]types.LFSLock, string, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 || filter.Size > maxLocksPageSize {
+		filter.Size = maxLocksPageSize
+	}
+
+	list, err := c.lfsLockStore.List(ctx, repoID, filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	var nextCursor string
+	if len(list) == filter.Size {
+		nextCursor = strconv.Itoa(filter.Page + 1)
+	}
+
+	return list, nextCursor, nil
+}
+
+func (c *Controller) mapLocks(ctx context.Context, list []types.LFSLock) ([]Lock, error) {
+	principalIDs := make([]int64, len(list))
+	for i := range list {
+		principalIDs[i] = list[i].CreatedBy
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal infos: %w", err)
+	}
+
+	locks := make([]Lock, len(list))
+	for i, lock := range list {
+		locks[i] = Lock{
+			ID:       strconv.FormatInt(lock.ID, 10),
+			Path:     lock.Path,
+			LockedAt: time.UnixMilli(lock.Created).UTC().Format(time.RFC3339),
+		}
+
+		if principalInfo, ok := principalInfos[lock.CreatedBy]; ok {
+			locks[i].Owner = &LockOwner{Name: principalInfo.DisplayName}
+		}
+	}
+
+	return locks, nil
+}
+
+// parseCursor parses the cursor of a lock list request (the cursor is the page number).
+func parseCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 1, nil
+	}
+
+	page, err := strconv.Atoi(cursor)
+	if err != nil || page < 1 {
+		return 0, usererror.BadRequestf("Invalid cursor %q.", cursor)
+	}
+
+	return page, nil
+}
+
+func refName(ref *Reference) string {
+	if ref == nil {
+		return ""
+	}
+
+	return ref.Name
+}
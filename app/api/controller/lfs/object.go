@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an lfs object in the blob store.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	content io.Reader,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return err
+	}
+
+	if err = validateOID(oid); err != nil {
+		return err
+	}
+
+	_, err = c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err == nil {
+		// object already exists - nothing to do.
+		return nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	reader := io.TeeReader(content, io.MultiWriter(hash, counter))
+
+	// the content is uploaded to a temporary path and only moved into place once it matches the oid.
+	uploadPath := getUploadBucketPath(repo.ID)
+	if err = c.blobStore.Upload(ctx, reader, uploadPath); err != nil {
+		return fmt.Errorf("failed to upload lfs object: %w", err)
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != oid {
+		if dErr := c.blobStore.Delete(ctx, uploadPath); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msgf("failed to delete invalid lfs object upload %q", uploadPath)
+		}
+		return usererror.UnprocessableEntityf("The object content doesn't match the object id %q.", oid)
+	}
+
+	if err = c.blobStore.Move(ctx, uploadPath, getObjectBucketPath(repo.ID, oid)); err != nil {
+		return fmt.Errorf("failed to move lfs object into place: %w", err)
+	}
+
+	obj := &types.LFSObject{
+		RepoID:    repo.ID,
+		OID:       oid,
+		Size:      counter.n,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	}
+
+	err = c.lfsObjectStore.Create(ctx, obj)
+	if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+		return fmt.Errorf("failed to create lfs object: %w", err)
+	}
+
+	return nil
+}
+
+// Download returns a reader for the content of an lfs object.
+func (c *Controller) Download(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+) (io.ReadCloser, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = validateOID(oid); err != nil {
+		return nil, 0, err
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	file, err := c.blobStore.Download(ctx, getObjectBucketPath(repo.ID, oid))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download lfs object from blob store: %w", err)
+	}
+
+	return file, obj.Size, nil
+}
+
+// countingWriter counts the number of bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -42,6 +42,9 @@ func (c *Controller) ActivityList(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	// the pending review comments are visible only to their author.
+	filter.PendingAuthorID = session.Principal.ID
+
 	list, err := c.activityStore.List(ctx, pr.ID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests activities: %w", err)
@@ -49,6 +52,15 @@ func (c *Controller) ActivityList(
 
 	list = removeDeletedComments(list)
 
+	reactions, err := c.reactionStore.SummaryPerActivity(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions of pull request activities: %w", err)
+	}
+
+	for _, act := range list {
+		act.Reactions = reactions[act.ID]
+	}
+
 	return list, nil
 }
 
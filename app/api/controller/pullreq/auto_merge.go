@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeInput struct {
+	Method enum.MergeMethod `json:"method"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	return nil
+}
+
+// AutoMergeEnable marks the pull request to be merged with the provided method
+// as soon as all merge requirements are satisfied. The merge is performed on behalf of the current principal.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.PullReq, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.autoMergeUpdate(ctx, targetRepo, pullreqNum, &session.Principal, &in.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	// The requirements might already be satisfied, so try to merge the pull request right away.
+	// Merge acquires the pull request lock on its own, so it must be called after autoMergeUpdate has released it.
+	_, violations, err := c.Merge(ctx, session, repoRef, pullreqNum, &MergeInput{
+		Method:    in.Method,
+		SourceSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to auto-merge pull request %d right away", pr.Number)
+		return pr, nil
+	}
+	if violations != nil {
+		return pr, nil
+	}
+
+	pr, err = c.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// AutoMergeDisable clears the auto-merge intent of the pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	return c.autoMergeUpdate(ctx, targetRepo, pullreqNum, &session.Principal, nil)
+}
+
+// autoMergeUpdate sets (or clears, if method is nil) the auto-merge intent of the pull request
+// and writes the pull request activity.
+func (c *Controller) autoMergeUpdate(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pullreqNum int64,
+	principal *types.Principal,
+	method *enum.MergeMethod,
+) (*types.PullReq, error) {
+	const timeout = 30 * time.Second
+
+	unlock, err := c.lockPR(ctx, targetRepo.ID, 0, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{}
+
+	if method != nil {
+		if pr.IsDraft {
+			return nil, usererror.BadRequest(
+				"Auto-merge can't be enabled for draft pull requests. Clear the draft flag first.",
+			)
+		}
+
+		payload.Action = enum.AutoMergeActionEnabled
+		payload.Method = *method
+	} else {
+		if pr.AutoMergeMethod == nil {
+			return nil, usererror.BadRequest("Auto-merge is not enabled for the pull request")
+		}
+
+		payload.Action = enum.AutoMergeActionDisabled
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if method != nil {
+			pr.AutoMergeMethod = method
+			pr.AutoMergeBy = &principal.ID
+		} else {
+			pr.AutoMergeMethod = nil
+			pr.AutoMergeBy = nil
+		}
+
+		pr.ActivitySeq++ // because we need to add the activity entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request auto-merge activity")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
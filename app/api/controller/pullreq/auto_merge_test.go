@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAutoMergeInputSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  enum.MergeMethod
+		want    enum.MergeMethod
+		wantErr bool
+	}{
+		{
+			name:   "squash",
+			method: enum.MergeMethodSquash,
+			want:   enum.MergeMethodSquash,
+		},
+		{
+			name:   "fast-forward",
+			method: enum.MergeMethodFastForward,
+			want:   enum.MergeMethodFastForward,
+		},
+		{
+			name:    "missing",
+			method:  "",
+			wantErr: true,
+		},
+		{
+			name:    "unknown",
+			method:  "octopus",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := AutoMergeInput{Method: test.method}
+			err := in.sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if in.Method != test.want {
+				t.Errorf("want %s, got %s", test.want, in.Method)
+			}
+		})
+	}
+}
@@ -46,8 +46,19 @@ type CommentCreateInput struct {
 	LineStartNew    bool   `json:"line_start_new"`
 	LineEnd         int    `json:"line_end"`
 	LineEndNew      bool   `json:"line_end_new"`
+	// Pending comments are visible only to their author until they get published with the review submission.
+	Pending bool `json:"pending"`
+	// Suggestion is the proposed replacement of the commented lines (optional, code comments only)
+	Suggestion *CommentSuggestionInput `json:"suggestion"`
 }
 
+type CommentSuggestionInput struct {
+	Lines []string `json:"lines"`
+}
+
+// suggestionMaxLines is the maximum number of lines a code suggestion can contain.
+const suggestionMaxLines = 1000
+
 func (in *CommentCreateInput) IsReply() bool {
 	return in.ParentID != 0
 }
@@ -60,6 +71,9 @@ func (in *CommentCreateInput) Validate() error {
 	// TODO: Validate Text size.
 
 	if in.SourceCommitSHA == "" && in.TargetCommitSHA == "" {
+		if in.Suggestion != nil {
+			return usererror.BadRequest("suggestions can be provided only for code comments")
+		}
 		return nil // not a code comment
 	}
 
@@ -79,6 +93,16 @@ func (in *CommentCreateInput) Validate() error {
 		return usererror.BadRequest("code comments require line numbers")
 	}
 
+	if in.Suggestion != nil {
+		if !in.LineStartNew || !in.LineEndNew {
+			return usererror.BadRequest("suggestions can be provided only for lines of the source branch")
+		}
+
+		if len(in.Suggestion.Lines) > suggestionMaxLines {
+			return usererror.BadRequestf("a suggestion can't contain more than %d lines", suggestionMaxLines)
+		}
+	}
+
 	return nil
 }
 
@@ -108,6 +132,10 @@ func (c *Controller) CommentCreate(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	if in.Pending && pr.CreatedBy == session.Principal.ID {
+		return nil, usererror.BadRequest("Can't add pending review comments to own pull requests.")
+	}
+
 	var cut git.DiffCutOutput
 	if in.IsCodeComment() {
 		// fetch code snippet from git for code comments
@@ -137,19 +165,23 @@ func (c *Controller) CommentCreate(
 		switch {
 		case in.IsCodeComment():
 			setAsCodeComment(act, cut, in.Path, in.SourceCommitSHA)
-			_ = act.SetPayload(&types.PullRequestActivityPayloadCodeComment{
+			payload := &types.PullRequestActivityPayloadCodeComment{
 				Title:        cut.LinesHeader,
 				Lines:        cut.Lines,
 				LineStartNew: in.LineStartNew,
 				LineEndNew:   in.LineEndNew,
-			})
+			}
+			if in.Suggestion != nil {
+				payload.Suggestion = &types.CodeSuggestion{Lines: in.Suggestion.Lines}
+			}
+			_ = act.SetPayload(payload)
 
 			err = c.writeActivity(ctx, pr, act)
 
 		case in.IsReply():
 			var parentAct *types.PullReqActivity
 
-			parentAct, err = c.checkIsReplyable(ctx, pr, in.ParentID)
+			parentAct, err = c.checkIsReplyable(ctx, session, pr, in.ParentID)
 			if err != nil {
 				return err
 			}
@@ -167,6 +199,11 @@ func (c *Controller) CommentCreate(
 			return fmt.Errorf("failed to write pull request comment: %w", err)
 		}
 
+		if act.Pending {
+			// pending review comments are counted once they get published.
+			return nil
+		}
+
 		pr.CommentCount++
 		if act.IsBlocking() {
 			pr.UnresolvedCount++
@@ -194,8 +231,9 @@ func (c *Controller) CommentCreate(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
-	// if it's a regular comment publish a comment create event
-	if act.Type == enum.PullReqActivityTypeComment && act.Kind == enum.PullReqActivityKindComment {
+	// if it's a regular comment publish a comment create event,
+	// for pending review comments the review submitted event is published instead.
+	if act.Type == enum.PullReqActivityTypeComment && act.Kind == enum.PullReqActivityKindComment && !act.Pending {
 		c.reportCommentCreated(ctx, pr, session.Principal.ID, act.ID, act.IsReply())
 	}
 
@@ -204,6 +242,7 @@ func (c *Controller) CommentCreate(
 
 func (c *Controller) checkIsReplyable(
 	ctx context.Context,
+	session *auth.Session,
 	pr *types.PullReq,
 	parentID int64,
 ) (*types.PullReqActivity, error) {
@@ -220,10 +259,19 @@ func (c *Controller) checkIsReplyable(
 		return nil, usererror.BadRequest("Parent pull request activity doesn't belong to the same pull request.")
 	}
 
+	// pending review comments of other users must not be revealed.
+	if parentAct.Pending && parentAct.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
 	if !parentAct.IsReplyable() {
 		return nil, usererror.BadRequest("Can't create a reply to the specified entry.")
 	}
 
+	if parentAct.Pending {
+		return nil, usererror.BadRequest("Can't create a reply to a pending review comment.")
+	}
+
 	return parentAct, nil
 }
 
@@ -295,6 +343,7 @@ func getCommentActivity(session *auth.Session, pr *types.PullReq, in *CommentCre
 		Metadata:   nil,
 		ResolvedBy: nil,
 		Resolved:   nil,
+		Pending:    in.Pending,
 		Author:     *session.Principal.ToPrincipalInfo(),
 	}
 
@@ -59,6 +59,9 @@ func (c *Controller) CommentDelete(
 			return nil
 		}
 
+		// pending review comments aren't included in the pull request comment counters.
+		isPending := act.Pending
+
 		now := time.Now().UnixMilli()
 
 		isBlocking := act.IsBlocking()
@@ -69,6 +72,10 @@ func (c *Controller) CommentDelete(
 			return fmt.Errorf("failed to mark comment as deleted: %w", err)
 		}
 
+		if isPending {
+			return nil
+		}
+
 		pr.CommentCount--
 		if isBlocking {
 			pr.UnresolvedCount--
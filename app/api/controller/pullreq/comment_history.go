@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommentHistory returns the versions of the text of a pull request comment, the latest version first.
+// Comments that have never been edited have no history.
+func (c *Controller) CommentHistory(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID int64,
+	opts types.Pagination,
+) ([]*types.PullReqTextVersion, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	count, err := c.textVersionStore.Count(ctx, pr.ID, &comment.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comment versions: %w", err)
+	}
+
+	versions, err := c.textVersionStore.List(ctx, pr.ID, &comment.ID, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list comment versions: %w", err)
+	}
+
+	return versions, count, nil
+}
+
+// writeTextVersion stores the current version of a pull request comment text or a pull request title and description.
+// When the text is edited for the first time, the original version gets stored as well.
+func (c *Controller) writeTextVersion(ctx context.Context, original, current *types.PullReqTextVersion) error {
+	count, err := c.textVersionStore.Count(ctx, current.PullReqID, current.ActivityID)
+	if err != nil {
+		return fmt.Errorf("failed to count text versions: %w", err)
+	}
+
+	if count == 0 {
+		if err = c.textVersionStore.Create(ctx, original); err != nil {
+			return fmt.Errorf("failed to store original text version: %w", err)
+		}
+	}
+
+	if err = c.textVersionStore.Create(ctx, current); err != nil {
+		return fmt.Errorf("failed to store text version: %w", err)
+	}
+
+	return nil
+}
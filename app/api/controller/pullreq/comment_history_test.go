@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+type textVersionStoreMock struct {
+	store.PullReqTextVersionStore
+	count   int64
+	created []string
+}
+
+func (s *textVersionStoreMock) Count(context.Context, int64, *int64) (int64, error) {
+	return s.count, nil
+}
+
+func (s *textVersionStoreMock) Create(_ context.Context, v *types.PullReqTextVersion) error {
+	s.created = append(s.created, v.Text)
+	s.count++
+	return nil
+}
+
+func TestWriteTextVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int64
+		wantCreated []string
+	}{
+		{
+			name:        "first edit",
+			count:       0,
+			wantCreated: []string{"original", "current"},
+		},
+		{
+			name:        "later edit",
+			count:       2,
+			wantCreated: []string{"current"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			textVersionStore := &textVersionStoreMock{count: test.count}
+			c := &Controller{textVersionStore: textVersionStore}
+
+			err := c.writeTextVersion(context.Background(),
+				&types.PullReqTextVersion{PullReqID: 1, Text: "original"},
+				&types.PullReqTextVersion{PullReqID: 1, Text: "current"})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if !slices.Equal(textVersionStore.created, test.wantCreated) {
+				t.Errorf("want %v, got %v", test.wantCreated, textVersionStore.created)
+			}
+		})
+	}
+}
@@ -71,14 +71,41 @@ func (c *Controller) CommentUpdate(
 		return act, nil
 	}
 
-	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
-		now := time.Now().UnixMilli()
-		act.Edited = now
-		act.Text = in.Text
-		return nil
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var original *types.PullReqTextVersion
+
+		act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
+			// only the author can edit a comment, but the time of the last edit is known
+			// only if the comment has never been edited.
+			original = &types.PullReqTextVersion{
+				PullReqID:  act.PullReqID,
+				ActivityID: &act.ID,
+				Text:       act.Text,
+				CreatedBy:  &act.CreatedBy,
+			}
+			if act.Edited == act.Created {
+				original.Created = act.Created
+			}
+
+			now := time.Now().UnixMilli()
+			act.Edited = now
+			act.Text = in.Text
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		return c.writeTextVersion(ctx, original, &types.PullReqTextVersion{
+			PullReqID:  act.PullReqID,
+			ActivityID: &act.ID,
+			Text:       act.Text,
+			Created:    act.Edited,
+			CreatedBy:  &session.Principal.ID,
+		})
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update comment: %w", err)
+		return nil, err
 	}
 
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
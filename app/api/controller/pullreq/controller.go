@@ -19,12 +19,16 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/sse"
@@ -61,6 +65,14 @@ type Controller struct {
 	protectionManager   *protection.Manager
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
+	mergeQueueStore     store.MergeQueueStore
+	mergeQueue          *mergequeue.Service
+	dependencyStore     store.PullReqDependencyStore
+	pullreqLabelStore   store.PullReqLabelStore
+	labelSvc            *label.Service
+	reactionStore       store.PullReqReactionStore
+	textVersionStore    store.PullReqTextVersionStore
+	signer              *gitsignature.Signer
 }
 
 func NewController(
@@ -85,6 +97,14 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	mergeQueueStore store.MergeQueueStore,
+	mergeQueue *mergequeue.Service,
+	dependencyStore store.PullReqDependencyStore,
+	pullreqLabelStore store.PullReqLabelStore,
+	labelSvc *label.Service,
+	reactionStore store.PullReqReactionStore,
+	textVersionStore store.PullReqTextVersionStore,
+	signer *gitsignature.Signer,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -108,6 +128,14 @@ func NewController(
 		protectionManager:   protectionManager,
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
+		mergeQueueStore:     mergeQueueStore,
+		mergeQueue:          mergeQueue,
+		dependencyStore:     dependencyStore,
+		pullreqLabelStore:   pullreqLabelStore,
+		labelSvc:            labelSvc,
+		reactionStore:       reactionStore,
+		textVersionStore:    textVersionStore,
+		signer:              signer,
 	}
 }
 
@@ -137,6 +165,34 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA, nil
 }
 
+// fetchSourceCommit makes the source commit of a pull request from a fork available in the target repository.
+func (c *Controller) fetchSourceCommit(ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sha string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commit into the target repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session, repoRef string, reqPermission enum.Permission,
 ) (*types.Repository, error) {
@@ -161,7 +217,7 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 }
 
 func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
-	pr *types.PullReq, commentID int64,
+	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
 	if commentID <= 0 {
 		return nil, usererror.BadRequest("A valid comment ID must be provided.")
@@ -180,6 +236,11 @@ func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
 		return nil, usererror.ErrNotFound
 	}
 
+	// pending review comments of other users must not be revealed.
+	if comment.Pending && comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
 	if comment.Kind == enum.PullReqActivityKindSystem {
 		return nil, usererror.BadRequest("Can't update a comment created by the system.")
 	}
@@ -194,7 +255,7 @@ func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
 func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
-	comment, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
@@ -207,13 +268,17 @@ func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 }
 
 func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
-	pr *types.PullReq, commentID int64,
+	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
-	comment, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
 
+	if comment.Pending {
+		return nil, usererror.BadRequest("Can't change status of pending review comments.")
+	}
+
 	if comment.SubOrder != 0 {
 		return nil, usererror.BadRequest("Can't change status of replies.")
 	}
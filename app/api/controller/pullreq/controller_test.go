@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestGetCommentCheckChangeStatusAccess(t *testing.T) {
+	const (
+		author = 1
+		other  = 2
+	)
+
+	pr := &types.PullReq{ID: 10, TargetRepoID: 20}
+	comment := func(id, createdBy, subOrder int64, pending bool) *types.PullReqActivity {
+		return &types.PullReqActivity{
+			ID:        id,
+			RepoID:    pr.TargetRepoID,
+			PullReqID: pr.ID,
+			Type:      enum.PullReqActivityTypeComment,
+			Kind:      enum.PullReqActivityKindComment,
+			SubOrder:  subOrder,
+			CreatedBy: createdBy,
+			Pending:   pending,
+		}
+	}
+
+	c := &Controller{activityStore: &activityStoreMock{comments: map[int64]*types.PullReqActivity{
+		1: comment(1, author, 0, false),
+		2: comment(2, author, 1, false),
+		3: comment(3, author, 0, true),
+	}}}
+
+	tests := []struct {
+		name        string
+		principalID int64
+		commentID   int64
+		wantErr     bool
+		wantErrIs   error
+	}{
+		{
+			name:        "published comment of other user",
+			principalID: other,
+			commentID:   1,
+		},
+		{
+			name:        "reply",
+			principalID: author,
+			commentID:   2,
+			wantErr:     true,
+		},
+		{
+			name:        "own pending comment",
+			principalID: author,
+			commentID:   3,
+			wantErr:     true,
+		},
+		{
+			name:        "pending comment of other user",
+			principalID: other,
+			commentID:   3,
+			wantErr:     true,
+			wantErrIs:   usererror.ErrNotFound,
+		},
+		{
+			name:        "unknown comment",
+			principalID: author,
+			commentID:   4,
+			wantErr:     true,
+			wantErrIs:   usererror.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{Principal: types.Principal{ID: test.principalID}}
+
+			_, err := c.getCommentCheckChangeStatusAccess(context.Background(), session, pr, test.commentID)
+			if !test.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Errorf("expected an error, got none")
+				return
+			}
+			if test.wantErrIs != nil && !errors.Is(err, test.wantErrIs) {
+				t.Errorf("want error %v, got %v", test.wantErrIs, err)
+			}
+		})
+	}
+}
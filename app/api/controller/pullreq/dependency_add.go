@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type DependencyAddInput struct {
+	// ParentNumber is the number of the pull request the pull request depends on.
+	ParentNumber int64 `json:"parent_number"`
+}
+
+// DependencyAdd adds a dependency of a pull request on a parent pull request.
+// The pull request must target the source branch of the parent pull request.
+func (c *Controller) DependencyAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *DependencyAddInput,
+) (*types.PullReqDependencies, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if in.ParentNumber <= 0 {
+		return nil, usererror.BadRequest("Must specify the parent pull request number.")
+	}
+
+	if in.ParentNumber == pr.Number {
+		return nil, usererror.BadRequest("Pull request can't depend on itself.")
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open.")
+	}
+
+	parent, err := c.pullreqStore.FindByNumber(ctx, repo.ID, in.ParentNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent pull request by number: %w", err)
+	}
+
+	if parent.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Parent pull request must be open.")
+	}
+
+	if parent.SourceRepoID != pr.TargetRepoID || parent.SourceBranch != pr.TargetBranch {
+		return nil, usererror.BadRequest(
+			"Pull request must target the source branch of the parent pull request.")
+	}
+
+	if err = c.verifyNoDependencyCycle(ctx, pr, parent); err != nil {
+		return nil, err
+	}
+
+	err = c.dependencyStore.Create(ctx, &types.PullReqDependency{
+		PullReqID: pr.ID,
+		ParentID:  parent.ID,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request dependency: %w", err)
+	}
+
+	return c.listDependencies(ctx, pr)
+}
+
+// verifyNoDependencyCycle returns an error if the pull request is
+// (directly or indirectly) a parent of the provided parent pull request.
+func (c *Controller) verifyNoDependencyCycle(ctx context.Context, pr, parent *types.PullReq) error {
+	visited := map[int64]struct{}{parent.ID: {}}
+	queue := []int64{parent.ID}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		parentIDs, err := c.dependencyStore.ListParentIDs(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list parent pull requests: %w", err)
+		}
+
+		for _, parentID := range parentIDs {
+			if parentID == pr.ID {
+				return usererror.BadRequest("Pull request dependencies can't form a cycle.")
+			}
+
+			if _, ok := visited[parentID]; ok {
+				continue
+			}
+
+			visited[parentID] = struct{}{}
+			queue = append(queue, parentID)
+		}
+	}
+
+	return nil
+}
+
+// verifyDependenciesMerged returns an error if any of the parent pull requests of the pull request isn't merged.
+func (c *Controller) verifyDependenciesMerged(ctx context.Context, pr *types.PullReq) error {
+	parentIDs, err := c.dependencyStore.ListParentIDs(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list parent pull requests: %w", err)
+	}
+
+	for _, parentID := range parentIDs {
+		parent, err := c.pullreqStore.Find(ctx, parentID)
+		if err != nil {
+			return fmt.Errorf("failed to find parent pull request: %w", err)
+		}
+
+		if parent.State != enum.PullReqStateMerged {
+			return usererror.BadRequestf(
+				"Pull request depends on pull request #%d which must be merged first.", parent.Number)
+		}
+	}
+
+	return nil
+}
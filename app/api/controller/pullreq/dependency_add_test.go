@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// dependencyStoreMock holds the parent IDs of pull requests.
+type dependencyStoreMock struct {
+	store.PullReqDependencyStore
+	parents map[int64][]int64
+}
+
+func (s dependencyStoreMock) ListParentIDs(_ context.Context, prID int64) ([]int64, error) {
+	return s.parents[prID], nil
+}
+
+type pullReqStoreMock struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s pullReqStoreMock) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return pr, nil
+}
+
+func (s pullReqStoreMock) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+func (s pullReqStoreMock) Update(_ context.Context, pr *types.PullReq) error {
+	s.prs[pr.ID] = pr
+	return nil
+}
+
+func TestVerifyNoDependencyCycle(t *testing.T) {
+	// 2 depends on 1, 3 depends on 2, 4 depends on 2 and 3.
+	c := &Controller{
+		dependencyStore: dependencyStoreMock{parents: map[int64][]int64{
+			2: {1},
+			3: {2},
+			4: {2, 3},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		prID     int64
+		parentID int64
+		wantErr  bool
+	}{
+		{
+			name:     "new root",
+			prID:     1,
+			parentID: 5,
+		},
+		{
+			name:     "sibling",
+			prID:     5,
+			parentID: 4,
+		},
+		{
+			name:     "direct cycle",
+			prID:     2,
+			parentID: 3,
+			wantErr:  true,
+		},
+		{
+			name:     "indirect cycle",
+			prID:     1,
+			parentID: 4,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.verifyNoDependencyCycle(context.Background(),
+				&types.PullReq{ID: test.prID}, &types.PullReq{ID: test.parentID})
+			if test.wantErr && err == nil {
+				t.Errorf("expected an error, got none")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyDependenciesMerged(t *testing.T) {
+	c := &Controller{
+		pullreqStore: pullReqStoreMock{prs: map[int64]*types.PullReq{
+			1: {ID: 1, Number: 11, State: enum.PullReqStateMerged},
+			2: {ID: 2, Number: 12, State: enum.PullReqStateMerged},
+			3: {ID: 3, Number: 13, State: enum.PullReqStateOpen},
+		}},
+		dependencyStore: dependencyStoreMock{parents: map[int64][]int64{
+			10: {1, 2},
+			20: {1, 3},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		prID    int64
+		wantErr bool
+	}{
+		{
+			name: "no dependencies",
+			prID: 30,
+		},
+		{
+			name: "all merged",
+			prID: 10,
+		},
+		{
+			name:    "parent open",
+			prID:    20,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.verifyDependenciesMerged(context.Background(), &types.PullReq{ID: test.prID})
+			if test.wantErr && err == nil {
+				t.Errorf("expected an error, got none")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
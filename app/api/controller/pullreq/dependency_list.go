@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DependencyList returns the parent and the child pull requests of a pull request.
+func (c *Controller) DependencyList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.PullReqDependencies, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return c.listDependencies(ctx, pr)
+}
+
+func (c *Controller) listDependencies(ctx context.Context, pr *types.PullReq) (*types.PullReqDependencies, error) {
+	parentIDs, err := c.dependencyStore.ListParentIDs(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parent pull requests: %w", err)
+	}
+
+	childIDs, err := c.dependencyStore.ListChildIDs(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child pull requests: %w", err)
+	}
+
+	parents, err := c.findPullReqs(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := c.findPullReqs(ctx, childIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PullReqDependencies{
+		Parents:  parents,
+		Children: children,
+	}, nil
+}
+
+func (c *Controller) findPullReqs(ctx context.Context, ids []int64) ([]*types.PullReq, error) {
+	prs := make([]*types.PullReq, len(ids))
+	for i, id := range ids {
+		pr, err := c.pullreqStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		prs[i] = pr
+	}
+
+	return prs, nil
+}
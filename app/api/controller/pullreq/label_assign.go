@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LabelAssignInput struct {
+	LabelID int64 `json:"label_id"`
+}
+
+// LabelAssign assigns a label to the pull request.
+// The label must be defined in the target repository or in one of its parent spaces.
+func (c *Controller) LabelAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *LabelAssignInput,
+) (*types.Label, error) {
+	if in.LabelID <= 0 {
+		return nil, usererror.BadRequest("Must specify label ID.")
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	label, err := c.labelSvc.FindForRepo(ctx, targetRepo, in.LabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.pullreqLabelStore.Assign(ctx, &types.PullReqLabel{
+		PullReqID: pr.ID,
+		LabelID:   label.ID,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		// the label is already assigned, nothing to do
+		return label, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign label to pull request: %w", err)
+	}
+
+	c.labelModified(ctx, session, targetRepo, pr, label, enum.LabelActionAssigned)
+
+	c.eventReporter.LabelAssigned(ctx, &pullreqevents.LabelAssignedPayload{
+		Base:    eventBase(pr, &session.Principal),
+		LabelID: label.ID,
+	})
+
+	return label, nil
+}
+
+// labelModified writes the pull request activity entry about the label change
+// and notifies the clients that the pull request has been updated.
+func (c *Controller) labelModified(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	label *types.Label,
+	action enum.LabelAction,
+) {
+	pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to get pull request activity number")
+		return
+	}
+
+	payload := &types.PullRequestActivityPayloadLabel{
+		Action:  action,
+		LabelID: label.ID,
+		Name:    label.Name,
+		Color:   label.Color,
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request label activity")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelUnassign removes a label from the pull request.
+func (c *Controller) LabelUnassign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	labelID int64,
+) error {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	label, err := c.labelSvc.FindForRepo(ctx, targetRepo, labelID)
+	if err != nil {
+		return err
+	}
+
+	err = c.pullreqLabelStore.Unassign(ctx, pr.ID, label.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.NotFound("Label is not assigned to the pull request")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unassign label from pull request: %w", err)
+	}
+
+	c.labelModified(ctx, session, targetRepo, pr, label, enum.LabelActionUnassigned)
+
+	c.eventReporter.LabelUnassigned(ctx, &pullreqevents.LabelUnassignedPayload{
+		Base:    eventBase(pr, &session.Principal),
+		LabelID: label.ID,
+	})
+
+	return nil
+}
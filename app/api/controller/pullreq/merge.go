@@ -73,6 +73,10 @@ func (in *MergeInput) sanitize() error {
 		return usererror.BadRequest("rebase doesn't support customizing commit title and message")
 	}
 
+	if in.Method == enum.MergeMethodFastForward && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("fast-forward doesn't support customizing commit title and message")
+	}
+
 	return nil
 }
 
@@ -150,6 +154,12 @@ func (c *Controller) Merge(
 		)
 	}
 
+	if !in.DryRun {
+		if err = c.verifyDependenciesMerged(ctx, pr); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
@@ -162,16 +172,21 @@ func (c *Controller) Merge(
 
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
+	canDeleteSourceBranch := true
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
+
+		// the source branch of a fork is deleted only if the user is allowed to push to the fork.
+		canDeleteSourceBranch = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo,
+			enum.PermissionRepoPush, false) == nil
 	}
 
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
@@ -189,7 +204,7 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
 	// check for error and ignore if it is codeowners file not found else throw error
 	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
@@ -206,6 +221,9 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+		Commits: protection.NewCommitLister(func(ctx context.Context, _ string) ([]git.CommitSignedData, error) {
+			return c.listPullReqCommitSignatures(ctx, targetRepo, pr)
+		}),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -258,6 +276,7 @@ func (c *Controller) Merge(
 					pr.MergeSHA = &mergeOutput.MergeSHA
 					pr.MergeConflicts = nil
 				}
+				pr.MergeNotFastForwardable = !mergeOutput.FastForwardable
 				pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
 				return nil
 			})
@@ -279,6 +298,7 @@ func (c *Controller) Merge(
 			// values only retured by dry run
 			DryRun:                        true,
 			ConflictFiles:                 pr.MergeConflicts,
+			FastForwardable:               pr.MergeCheckStatus != enum.MergeCheckStatusUnchecked && !pr.MergeNotFastForwardable,
 			AllowedMethods:                ruleOut.AllowedMethods,
 			RequiresCodeOwnersApproval:    ruleOut.RequiresCodeOwnersApproval,
 			RequiresCommentResolution:     ruleOut.RequiresCommentResolution,
@@ -304,6 +324,8 @@ func (c *Controller) Merge(
 		author = identityFromPrincipalInfo(pr.Author)
 	case enum.MergeMethodRebase:
 		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+	case enum.MergeMethodFastForward:
+		author = nil // Not important for the fast-forward merge: no commits are created.
 	}
 
 	var committer *git.Identity
@@ -313,6 +335,8 @@ func (c *Controller) Merge(
 		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
 	case enum.MergeMethodRebase:
 		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for the fast-forward merge: no commits are created.
 	}
 
 	// backfill commit title if none provided
@@ -322,7 +346,7 @@ func (c *Controller) Merge(
 			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
 		case enum.MergeMethodSquash:
 			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase:
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
 			// Not used.
 		}
 	}
@@ -351,6 +375,30 @@ func (c *Controller) Merge(
 	if err != nil {
 		return nil, nil, fmt.Errorf("merge check execution failed: %w", err)
 	}
+	if in.Method == enum.MergeMethodFastForward && !mergeOutput.FastForwardable {
+		pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+			if pr.SourceSHA != mergeOutput.HeadSHA {
+				return errors.New("source SHA has changed")
+			}
+
+			pr.MergeNotFastForwardable = true
+			return nil
+		})
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to mark pull request as not fast-forwardable")
+		} else {
+			if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+			}
+		}
+
+		return nil, &types.MergeViolations{
+			NotFastForwardable: true,
+			RuleViolations:     violations,
+		}, nil
+	}
+
 	//nolint:nestif
 	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
 		_, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
@@ -401,8 +449,13 @@ func (c *Controller) Merge(
 		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA
 		pr.MergeSHA = &mergeOutput.MergeSHA
 		pr.MergeConflicts = nil
+		pr.MergeNotFastForwardable = !mergeOutput.FastForwardable
 		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
 
+		// the auto-merge intent, if any, has been fulfilled.
+		pr.AutoMergeMethod = nil
+		pr.AutoMergeBy = nil
+
 		// update sequence for PR activities
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
@@ -439,7 +492,7 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if ruleOut.DeleteSourceBranch && canDeleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -472,3 +525,21 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// listPullReqCommitSignatures returns the signatures of all commits of the pull request.
+func (c *Controller) listPullReqCommitSignatures(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) ([]git.CommitSignedData, error) {
+	out, err := c.git.ListCommitSignatures(ctx, git.ListCommitSignaturesParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     pr.SourceSHA,
+		Exclude:    []string{pr.MergeBaseSHA},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit signatures: %w", err)
+	}
+
+	return out.Commits, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeQueueAddInput struct {
+	Method      enum.MergeMethod `json:"method"`
+	SourceSHA   string           `json:"source_sha"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
+	BypassRules bool             `json:"bypass_rules"`
+}
+
+func (in *MergeQueueAddInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	if in.Method == enum.MergeMethodFastForward {
+		return usererror.BadRequest("merge queue doesn't support the fast-forward merge method")
+	}
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("rebase doesn't support customizing commit title and message")
+	}
+
+	return nil
+}
+
+// MergeQueueAdd adds a pull request to the merge queue of its target branch.
+// The pull request must satisfy the protection rules like for a regular merge. The merge queue then merges
+// the pull request once the required status checks succeed on its speculative merge commit.
+func (c *Controller) MergeQueueAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueAddInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	const timeout = 30 * time.Second
+
+	unlock, err := c.lockPR(ctx, targetRepo.ID, 0, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	if err = c.verifyDependenciesMerged(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+
+	_, err = c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return nil, nil, usererror.BadRequest("Pull request is already in the merge queue")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        &session.Principal,
+		AllowBypass:  in.BypassRules,
+		IsRepoOwner:  isRepoOwner,
+		TargetRepo:   targetRepo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       in.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		Commits: protection.NewCommitLister(func(ctx context.Context, _ string) ([]git.CommitSignedData, error) {
+			return c.listPullReqCommitSignatures(ctx, targetRepo, pr)
+		}),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	// backfill commit title if none provided
+	if in.Title == "" {
+		switch in.Method {
+		case enum.MergeMethodMerge:
+			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+		case enum.MergeMethodSquash:
+			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+			// Not used.
+		}
+	}
+
+	entry, err := c.mergeQueue.Enqueue(ctx, &session.Principal, pr, in.Method, in.Title, in.Message)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to merge queue: %w", err)
+	}
+
+	return entry, nil, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue of its target branch.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	const timeout = 30 * time.Second
+
+	unlock, err := c.lockPR(ctx, targetRepo.ID, 0, timeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequest("Pull request is not in the merge queue")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	if err = c.mergeQueue.Dequeue(ctx, &session.Principal, targetRepo, pr, entry); err != nil {
+		return fmt.Errorf("failed to remove pull request from merge queue: %w", err)
+	}
+
+	return nil
+}
+
+// MergeQueueList returns the merge queue of a branch.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	entries, err := c.mergeQueueStore.ListForBranch(ctx, repo.ID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMergeQueueAddInputSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      MergeQueueAddInput
+		want    MergeQueueAddInput
+		wantErr bool
+	}{
+		{
+			name: "merge",
+			in:   MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodMerge, BypassRules: true},
+			want: MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodMerge, BypassRules: true},
+		},
+		{
+			name: "title and message are trimmed",
+			in:   MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodSquash, Title: " T ", Message: " M "},
+			want: MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodSquash, Title: "T", Message: "M"},
+		},
+		{
+			name: "rebase with blank title",
+			in:   MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodRebase, Title: " "},
+			want: MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodRebase},
+		},
+		{
+			name:    "missing source SHA",
+			in:      MergeQueueAddInput{},
+			wantErr: true,
+		},
+		{
+			name:    "missing method",
+			in:      MergeQueueAddInput{SourceSHA: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown method",
+			in:      MergeQueueAddInput{SourceSHA: "abc", Method: "octopus"},
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward",
+			in:      MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodFastForward},
+			wantErr: true,
+		},
+		{
+			name:    "rebase with title",
+			in:      MergeQueueAddInput{SourceSHA: "abc", Method: enum.MergeMethodRebase, Title: "T"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if in != test.want {
+				t.Errorf("want %+v, got %+v", test.want, in)
+			}
+		})
+	}
+}
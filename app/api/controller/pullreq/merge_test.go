@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMergeInputSanitize(t *testing.T) {
+	const sha = "1d0e5f8e6c1a5a0e4b3c2d1e0f9a8b7c6d5e4f3a"
+
+	tests := []struct {
+		name    string
+		in      MergeInput
+		wantErr bool
+	}{
+		{
+			name: "merge with title",
+			in:   MergeInput{Method: enum.MergeMethodMerge, SourceSHA: sha, Title: "Merge"},
+		},
+		{
+			name: "fast-forward",
+			in:   MergeInput{Method: enum.MergeMethodFastForward, SourceSHA: sha},
+		},
+		{
+			name: "fast-forward with blank title",
+			in:   MergeInput{Method: enum.MergeMethodFastForward, SourceSHA: sha, Title: "  "},
+		},
+		{
+			name:    "fast-forward with title",
+			in:      MergeInput{Method: enum.MergeMethodFastForward, SourceSHA: sha, Title: "Merge"},
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward with message",
+			in:      MergeInput{Method: enum.MergeMethodFastForward, SourceSHA: sha, Message: "Details"},
+			wantErr: true,
+		},
+		{
+			name:    "rebase with title",
+			in:      MergeInput{Method: enum.MergeMethodRebase, SourceSHA: sha, Title: "Merge"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown method",
+			in:      MergeInput{Method: "octopus", SourceSHA: sha},
+			wantErr: true,
+		},
+		{
+			name:    "missing method",
+			in:      MergeInput{SourceSHA: sha},
+			wantErr: true,
+		},
+		{
+			name: "missing method on dry run",
+			in:   MergeInput{SourceSHA: sha, DryRun: true},
+		},
+		{
+			name:    "missing source sha",
+			in:      MergeInput{Method: enum.MergeMethodFastForward},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			if err := in.sanitize(); (err != nil) != test.wantErr {
+				t.Errorf("sanitize() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
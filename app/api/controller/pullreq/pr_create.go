@@ -39,6 +39,11 @@ type CreateInput struct {
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
+
+	// Template is the name of the pull request template, stored in the target branch,
+	// that is used as the description if the description is empty.
+	// If not provided, the repository's default template is used, if it exists.
+	Template string `json:"template"`
 }
 
 // Create creates a new pull request.
@@ -66,6 +71,10 @@ func (c *Controller) Create(
 		}
 	}
 
+	if sourceRepo.ID != targetRepo.ID && sourceRepo.ForkID != targetRepo.ID {
+		return nil, usererror.BadRequest("source repository must be a fork of the target repository")
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -80,13 +89,27 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if strings.TrimSpace(in.Description) == "" {
+		template, err := c.findTemplate(ctx, targetRepo, in.TargetBranch, in.Template)
+		if err != nil {
+			return nil, err
+		}
+		if template != nil {
+			in.Description = template.Content
+		}
+	}
+
 	if err = c.checkIfAlreadyExists(ctx, targetRepo.ID, sourceRepo.ID, in.TargetBranch, in.SourceBranch); err != nil {
 		return nil, err
 	}
 
+	if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
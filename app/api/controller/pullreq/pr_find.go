@@ -62,5 +62,10 @@ func (c *Controller) Find(
 		pr.Stats.DiffStats = types.NewDiffStats(output.Commits, output.FilesChanged)
 	}
 
+	pr.Reactions, err = c.reactionStore.Summary(ctx, pr.ID, nil, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions of pull request: %w", err)
+	}
+
 	return pr, nil
 }
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// History returns the versions of the title and the description of a pull request, the latest version first.
+// Pull requests that have never been edited have no history.
+func (c *Controller) History(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	opts types.Pagination,
+) ([]*types.PullReqTextVersion, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	count, err := c.textVersionStore.Count(ctx, pr.ID, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pull request versions: %w", err)
+	}
+
+	versions, err := c.textVersionStore.List(ctx, pr.ID, nil, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pull request versions: %w", err)
+	}
+
+	return versions, count, nil
+}
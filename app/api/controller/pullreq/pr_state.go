@@ -120,9 +120,13 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA,
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
@@ -147,6 +151,9 @@ func (c *Controller) State(ctx context.Context,
 			pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
+			pr.MergeNotFastForwardable = false
+			pr.AutoMergeMethod = nil
+			pr.AutoMergeBy = nil
 		case changeReopen:
 			pr.SourceSHA = sourceSHA
 			pr.MergeBaseSHA = mergeBaseSHA
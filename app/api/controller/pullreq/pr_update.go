@@ -86,17 +86,45 @@ func (c *Controller) Update(ctx context.Context,
 	needToWriteActivity := in.Title != pr.Title
 	oldTitle := pr.Title
 
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		pr.Title = in.Title
-		pr.Description = in.Description
-		pr.Edited = time.Now().UnixMilli()
-		if needToWriteActivity {
-			pr.ActivitySeq++
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var original *types.PullReqTextVersion
+
+		pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+			original = &types.PullReqTextVersion{
+				PullReqID: pr.ID,
+				Title:     pr.Title,
+				Text:      pr.Description,
+			}
+
+			// the original version is attributed only if the pull request has never been edited,
+			// otherwise it's unknown who changed the title or the description last and when.
+			if pr.Edited == pr.Created {
+				original.Created = pr.Created
+				original.CreatedBy = &pr.CreatedBy
+			}
+
+			pr.Title = in.Title
+			pr.Description = in.Description
+			pr.Edited = time.Now().UnixMilli()
+			if needToWriteActivity {
+				pr.ActivitySeq++
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update pull request: %w", err)
 		}
-		return nil
+
+		return c.writeTextVersion(ctx, original, &types.PullReqTextVersion{
+			PullReqID: pr.ID,
+			Title:     pr.Title,
+			Text:      pr.Description,
+			Created:   pr.Edited,
+			CreatedBy: &session.Principal.ID,
+		})
 	})
 	if err != nil {
-		return pr, fmt.Errorf("failed to update pull request: %w", err)
+		return nil, err
 	}
 
 	if needToWriteActivity {
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ReactionAddInput struct {
+	Reaction enum.ReactionType `json:"reaction"`
+}
+
+func (in *ReactionAddInput) Validate() error {
+	reaction, ok := in.Reaction.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for reaction")
+	}
+
+	in.Reaction = reaction
+
+	return nil
+}
+
+// ReactionAdd adds a reaction of the current user to the pull request description.
+func (c *Controller) ReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *ReactionAddInput,
+) ([]types.ReactionSummary, error) {
+	return c.reactionAdd(ctx, session, repoRef, pullreqNum, 0, in)
+}
+
+// CommentReactionAdd adds a reaction of the current user to a pull request comment.
+func (c *Controller) CommentReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	in *ReactionAddInput,
+) ([]types.ReactionSummary, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	return c.reactionAdd(ctx, session, repoRef, pullreqNum, commentID, in)
+}
+
+func (c *Controller) reactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	in *ReactionAddInput,
+) ([]types.ReactionSummary, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	repo, pr, activityID, err := c.getReactionTarget(ctx, session, repoRef, pullreqNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.reactionStore.Create(ctx, &types.PullReqReaction{
+		PullReqID:   pr.ID,
+		ActivityID:  activityID,
+		PrincipalID: session.Principal.ID,
+		Type:        in.Reaction,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil && !errors.Is(err, store.ErrDuplicate) {
+		return nil, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	return c.reactionsModified(ctx, session, repo, pr, activityID)
+}
+
+// getReactionTarget returns the pull request and, if the comment ID is provided,
+// the ID of the pull request comment the current user can react to.
+func (c *Controller) getReactionTarget(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+) (*types.Repository, *types.PullReq, *int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if commentID == 0 {
+		return repo, pr, nil, nil
+	}
+
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if comment.Pending {
+		return nil, nil, nil, usererror.BadRequest("Can't react to pending review comments.")
+	}
+
+	return repo, pr, &comment.ID, nil
+}
+
+// reactionsModified notifies the open pull request pages about the changed reactions
+// and returns the updated reactions. Reactions don't trigger any other notifications.
+func (c *Controller) reactionsModified(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+	activityID *int64,
+) ([]types.ReactionSummary, error) {
+	if err := c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	reactions, err := c.reactionStore.Summary(ctx, pr.ID, activityID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+
+	return reactions, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestReactionAddInputValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		reaction enum.ReactionType
+		wantErr  bool
+	}{
+		{
+			name:     "plus one",
+			reaction: enum.ReactionTypePlusOne,
+		},
+		{
+			name:     "eyes",
+			reaction: enum.ReactionTypeEyes,
+		},
+		{
+			name:     "missing",
+			reaction: "",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown",
+			reaction: "thumbsup",
+			wantErr:  true,
+		},
+		{
+			name:     "case sensitive",
+			reaction: "Heart",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := ReactionAddInput{Reaction: test.reaction}
+			err := in.Validate()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if in.Reaction != test.reaction {
+				t.Errorf("want %s, got %s", test.reaction, in.Reaction)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReactionDelete removes a reaction of the current user from the pull request description.
+func (c *Controller) ReactionDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	reaction enum.ReactionType,
+) ([]types.ReactionSummary, error) {
+	return c.reactionDelete(ctx, session, repoRef, pullreqNum, 0, reaction)
+}
+
+// CommentReactionDelete removes a reaction of the current user from a pull request comment.
+func (c *Controller) CommentReactionDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	reaction enum.ReactionType,
+) ([]types.ReactionSummary, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	return c.reactionDelete(ctx, session, repoRef, pullreqNum, commentID, reaction)
+}
+
+func (c *Controller) reactionDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	reaction enum.ReactionType,
+) ([]types.ReactionSummary, error) {
+	reaction, ok := reaction.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequest("Invalid value provided for reaction")
+	}
+
+	repo, pr, activityID, err := c.getReactionTarget(ctx, session, repoRef, pullreqNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.reactionStore.Delete(ctx, pr.ID, activityID, session.Principal.ID, reaction)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Reaction not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	return c.reactionsModified(ctx, session, repo, pr, activityID)
+}
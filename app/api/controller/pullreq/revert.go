@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RevertInput struct {
+	// RevertBranch is the name of the new branch with the revert commit
+	// (optional, default: revert-pullreq-<number>).
+	RevertBranch string `json:"revert_branch"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertInput) sanitize(pr *types.PullReq) {
+	in.RevertBranch = strings.TrimSpace(in.RevertBranch)
+	if in.RevertBranch == "" {
+		in.RevertBranch = fmt.Sprintf("revert-pullreq-%d", pr.Number)
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", pr.Title)
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = fmt.Sprintf("Reverts #%d", pr.Number)
+	}
+}
+
+// Revert creates a new branch that reverts the changes of a merged pull request
+// and opens a pull request for it against the target branch of the merged pull request.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RevertInput,
+) (*types.PullReq, *types.MergeViolations, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return nil, nil, usererror.BadRequest("Only merged pull requests can be reverted.")
+	}
+
+	in.sanitize(pr)
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        targetRepo,
+		RefAction:   protection.RefActionCreate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{in.RevertBranch},
+		// the branch is created from the target branch, the only new commit is created by the server.
+		Commits:       protection.NoCommits,
+		ServerCommits: true,
+		SigningKey:    c.signer.SigningKey(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// Independent of the merge method, all changes of the pull request are between the merge target SHA
+	// (the target branch before the merge) and the merge SHA (the target branch after the merge):
+	// a merge commit and a squashed commit have the merge target SHA as their (first) parent,
+	// and rebased commits are applied on top of it.
+	now := time.Now()
+	revertOut, err := c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:   writeParams,
+		CommitSHA:     *pr.MergeSHA,
+		ParentSHA:     *pr.MergeTargetSHA,
+		BranchName:    pr.TargetBranch,
+		NewBranchName: in.RevertBranch,
+		Message: fmt.Sprintf("Revert %q (#%d)\n\nThis reverts pull request #%d merged as commit %s.",
+			pr.Title, pr.Number, pr.Number, *pr.MergeSHA),
+		Committer:     identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		CommitterDate: &now,
+		Author:        identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to revert pull request changes: %w", err)
+	}
+
+	if len(revertOut.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{ConflictFiles: revertOut.ConflictFiles}, nil
+	}
+
+	revertPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		Title:        in.Title,
+		Description:  in.Description,
+		SourceBranch: in.RevertBranch,
+		TargetBranch: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create revert pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadRevert{
+		RevertedPullReqNumber: pr.Number,
+		RevertPullReqNumber:   revertPR.Number,
+		MergeSHA:              *pr.MergeSHA,
+	}
+
+	c.writeRevertActivity(ctx, session, pr, payload)
+	c.writeRevertActivity(ctx, session, revertPR, payload)
+
+	return revertPR, nil, nil
+}
+
+// writeRevertActivity adds the revert activity to the timeline of the pull request.
+func (c *Controller) writeRevertActivity(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	payload *types.PullRequestActivityPayloadRevert,
+) {
+	updatedPR, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update activity sequence of pull request %d", pr.Number)
+		return
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, updatedPR, session.Principal.ID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request revert activity")
+	}
+}
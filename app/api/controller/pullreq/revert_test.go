@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestRevertInputSanitize(t *testing.T) {
+	pr := &types.PullReq{Number: 7, Title: "Add feature"}
+
+	tests := []struct {
+		name string
+		in   RevertInput
+		want RevertInput
+	}{
+		{
+			name: "defaults",
+			in:   RevertInput{},
+			want: RevertInput{
+				RevertBranch: "revert-pullreq-7",
+				Title:        `Revert "Add feature"`,
+				Description:  "Reverts #7",
+			},
+		},
+		{
+			name: "provided values are trimmed",
+			in: RevertInput{
+				RevertBranch: " revert-feature ",
+				Title:        " Revert feature ",
+				Description:  " It broke the build. ",
+				BypassRules:  true,
+			},
+			want: RevertInput{
+				RevertBranch: "revert-feature",
+				Title:        "Revert feature",
+				Description:  "It broke the build.",
+				BypassRules:  true,
+			},
+		},
+		{
+			name: "blank values",
+			in:   RevertInput{RevertBranch: " ", Title: " ", Description: " "},
+			want: RevertInput{
+				RevertBranch: "revert-pullreq-7",
+				Title:        `Revert "Add feature"`,
+				Description:  "Reverts #7",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			in.sanitize(pr)
+			if in != test.want {
+				t.Errorf("want %+v, got %+v", test.want, in)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/pullreq"
@@ -90,6 +91,12 @@ func (c *Controller) ReviewSubmit(
 
 	commitSHA := commit.Commit.SHA
 
+	// the pending review comments get published with the review.
+	pr, commentIDs, err := c.publishPendingComments(ctx, pr, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	var review *types.PullReqReview
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -112,6 +119,7 @@ func (c *Controller) ReviewSubmit(
 			Base:       eventBase(pr, &session.Principal),
 			Decision:   review.Decision,
 			ReviewerID: review.CreatedBy,
+			CommentIDs: commentIDs,
 		})
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA)
@@ -138,9 +146,81 @@ func (c *Controller) ReviewSubmit(
 		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after review submit")
 	}
 
+	if len(commentIDs) > 0 {
+		if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+		}
+	}
+
 	return review, nil
 }
 
+// publishPendingComments makes the pending review comments of the principal visible to everyone.
+// Top level comments are moved to the end of the pull request timeline.
+// It returns the updated pull request and the IDs of the published comments.
+func (c *Controller) publishPendingComments(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+) (*types.PullReq, []int64, error) {
+	var commentIDs []int64
+
+	err := controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		pr, err = c.pullreqStore.Find(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		pending, err := c.activityStore.ListPending(ctx, pr.ID, principalID)
+		if err != nil {
+			return fmt.Errorf("failed to list pending review comments: %w", err)
+		}
+
+		commentIDs = make([]int64, 0, len(pending))
+
+		for _, act := range pending {
+			if !act.IsReply() {
+				if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+					return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+				}
+
+				act.Order = pr.ActivitySeq
+			}
+
+			act.Pending = false
+
+			if err = c.activityStore.Update(ctx, act); err != nil {
+				return fmt.Errorf("failed to publish pending review comment: %w", err)
+			}
+
+			pr.CommentCount++
+			if act.IsBlocking() {
+				pr.UnresolvedCount++
+			}
+
+			commentIDs = append(commentIDs, act.ID)
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		err = c.pullreqStore.Update(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to increment pull request comment counters: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pr, commentIDs, nil
+}
+
 // updateReviewer updates pull request reviewer object.
 func (c *Controller) updateReviewer(ctx context.Context, session *auth.Session,
 	pr *types.PullReq, review *types.PullReqReview, sha string) (*types.PullReqReviewer, error) {
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type txMock struct{}
+
+func (txMock) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type activityStoreMock struct {
+	store.PullReqActivityStore
+	comments map[int64]*types.PullReqActivity
+	pending  []*types.PullReqActivity
+	updated  []int64
+}
+
+func (s *activityStoreMock) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	return s.comments[id], nil
+}
+
+func (s *activityStoreMock) ListPending(context.Context, int64, int64) ([]*types.PullReqActivity, error) {
+	return s.pending, nil
+}
+
+func (s *activityStoreMock) Update(_ context.Context, act *types.PullReqActivity) error {
+	s.updated = append(s.updated, act.ID)
+	return nil
+}
+
+func TestPublishPendingComments(t *testing.T) {
+	resolved := int64(1)
+
+	type activity struct {
+		id       int64
+		order    int64
+		subOrder int64
+		resolved *int64
+	}
+
+	tests := []struct {
+		name            string
+		pending         []activity
+		wantIDs         []int64
+		wantOrders      []int64
+		wantActivitySeq int64
+		wantComments    int
+		wantUnresolved  int
+	}{
+		{
+			name:            "nothing pending",
+			pending:         nil,
+			wantIDs:         []int64{},
+			wantActivitySeq: 10,
+			wantComments:    2,
+			wantUnresolved:  1,
+		},
+		{
+			name: "comments and replies",
+			pending: []activity{
+				{id: 1, order: 4},
+				{id: 2, order: 3, subOrder: 2},
+				{id: 3, order: 7, resolved: &resolved},
+			},
+			wantIDs:         []int64{1, 2, 3},
+			wantOrders:      []int64{11, 3, 12},
+			wantActivitySeq: 12,
+			wantComments:    5,
+			wantUnresolved:  2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{ID: 1, ActivitySeq: 10, CommentCount: 2, UnresolvedCount: 1}
+
+			pending := make([]*types.PullReqActivity, len(test.pending))
+			for i, act := range test.pending {
+				pending[i] = &types.PullReqActivity{
+					ID:       act.id,
+					Kind:     enum.PullReqActivityKindComment,
+					Order:    act.order,
+					SubOrder: act.subOrder,
+					Resolved: act.resolved,
+					Pending:  true,
+				}
+			}
+
+			activityStore := &activityStoreMock{pending: pending}
+			c := &Controller{
+				tx:            txMock{},
+				pullreqStore:  pullReqStoreMock{prs: map[int64]*types.PullReq{pr.ID: pr}},
+				activityStore: activityStore,
+			}
+
+			pr, ids, err := c.publishPendingComments(context.Background(), &types.PullReq{ID: 1}, 42)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if !slices.Equal(ids, test.wantIDs) {
+				t.Errorf("published: want %v, got %v", test.wantIDs, ids)
+			}
+			if !slices.Equal(activityStore.updated, test.wantIDs) {
+				t.Errorf("updated: want %v, got %v", test.wantIDs, activityStore.updated)
+			}
+
+			for i, act := range pending {
+				if act.Pending {
+					t.Errorf("comment %d is still pending", act.ID)
+				}
+				if act.Order != test.wantOrders[i] {
+					t.Errorf("comment %d order: want %d, got %d", act.ID, test.wantOrders[i], act.Order)
+				}
+			}
+
+			if pr.ActivitySeq != test.wantActivitySeq {
+				t.Errorf("activity seq: want %d, got %d", test.wantActivitySeq, pr.ActivitySeq)
+			}
+			if pr.CommentCount != test.wantComments {
+				t.Errorf("comment count: want %d, got %d", test.wantComments, pr.CommentCount)
+			}
+			if pr.UnresolvedCount != test.wantUnresolved {
+				t.Errorf("unresolved count: want %d, got %d", test.wantUnresolved, pr.UnresolvedCount)
+			}
+		})
+	}
+}
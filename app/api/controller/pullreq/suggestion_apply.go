@@ -0,0 +1,563 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// suggestionsMaxCount is the maximum number of suggestions that can be applied with a single commit.
+	suggestionsMaxCount = 100
+	// suggestionFileMaxSize is the maximum size of a file that can be modified by applying suggestions.
+	suggestionFileMaxSize = 4 * 1024 * 1024
+)
+
+type SuggestionsApplyInput struct {
+	// CommentIDs are the IDs of the code comments whose suggestions should be applied.
+	CommentIDs []int64 `json:"comment_ids"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *SuggestionsApplyInput) sanitize() error {
+	if len(in.CommentIDs) == 0 {
+		return usererror.BadRequest("At least one comment ID must be provided.")
+	}
+
+	ids := make([]int64, 0, len(in.CommentIDs))
+	seen := make(map[int64]struct{}, len(in.CommentIDs))
+	for _, id := range in.CommentIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > suggestionsMaxCount {
+		return usererror.BadRequestf("At most %d suggestions can be applied at once.", suggestionsMaxCount)
+	}
+
+	in.CommentIDs = ids
+
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		in.Title = "Apply suggestion from code review"
+		if len(in.CommentIDs) > 1 {
+			in.Title = "Apply suggestions from code review"
+		}
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+
+	return nil
+}
+
+type SuggestionsApplyOutput struct {
+	// CommitID is the commit on the source branch containing the applied suggestions.
+	CommitID string `json:"commit_id"`
+}
+
+// suggestion is a code comment with a suggestion that is about to be applied.
+type suggestion struct {
+	comment *types.PullReqActivity
+	lines   []string
+}
+
+func (s suggestion) lineStart() int {
+	return s.comment.CodeComment.LineNew
+}
+
+func (s suggestion) lineEnd() int {
+	return s.comment.CodeComment.LineNew + s.comment.CodeComment.SpanNew - 1
+}
+
+// SuggestionsApply applies the suggestions of the provided code comments with a single commit
+// on the source branch of the pull request and marks the code comments as resolved.
+// Suggestions whose lines were changed since the code comment was created can't be applied.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) SuggestionsApply(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *SuggestionsApplyInput,
+) (*SuggestionsApplyOutput, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	// the max time we give applying the suggestions to succeed
+	const timeout = time.Minute
+
+	unlock, err := c.lockPR(ctx, targetRepo.ID, pullreqNum, timeout+30*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Suggestions can be applied only to open pull requests.")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	// the source branch gets updated, so the user must be allowed to push to the source repository.
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false); err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	suggestionsPerFile, err := c.getSuggestions(ctx, session, pr, in.CommentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the source commits are available in the target repository (see the pull request head reference).
+	readParams := git.CreateReadParams(targetRepo)
+
+	for _, suggestions := range suggestionsPerFile {
+		if err = c.verifySuggestionsUpToDate(ctx, readParams, pr, suggestions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, sourceRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, sourceRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        sourceRepo,
+		RefAction:   protection.RefActionUpdate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{pr.SourceBranch},
+		// the only commit added to the branch is created by the server.
+		Commits:       protection.NoCommits,
+		ServerCommits: true,
+		SigningKey:    c.signer.SigningKey(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	paths := make([]string, 0, len(suggestionsPerFile))
+	for path := range suggestionsPerFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	actions := make([]git.CommitFileAction, len(paths))
+	for i, path := range paths {
+		blobSHA, content, err := c.readSuggestionFile(ctx, readParams, pr.SourceSHA, path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		payload, err := applySuggestions(content, suggestionsPerFile[path])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		actions[i] = git.CommitFileAction{
+			Action:  git.UpdateAction,
+			Path:    path,
+			Payload: []byte(payload),
+			SHA:     blobSHA, // the commit fails if the file has been changed on the source branch in the meantime
+		}
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         in.Title,
+		Message:       in.Message,
+		Branch:        pr.SourceBranch,
+		Actions:       actions,
+		Committer:     identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		CommitterDate: &now,
+		Author:        identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to commit suggestions to the source branch: %w", err)
+	}
+
+	err = c.markSuggestionsApplied(ctx, session, targetRepo, pullreqNum, in.CommentIDs, commit.CommitID)
+	if err != nil {
+		// the commit is already on the source branch, so we don't fail the request.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to mark suggestions as applied")
+	}
+
+	return &SuggestionsApplyOutput{CommitID: commit.CommitID}, nil, nil
+}
+
+// getSuggestions fetches the code comments with the provided IDs, verifies that their suggestions can be applied
+// and returns the suggestions grouped by file path.
+func (c *Controller) getSuggestions(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	commentIDs []int64,
+) (map[string][]suggestion, error) {
+	suggestionsPerFile := make(map[string][]suggestion)
+
+	for _, commentID := range commentIDs {
+		comment, err := c.getCommentCheckChangeStatusAccess(ctx, session, pr, commentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		codeSuggestion, err := getCodeSuggestion(comment)
+		if err != nil {
+			return nil, err
+		}
+
+		if codeSuggestion.IsApplied() {
+			return nil, usererror.BadRequestf("The suggestion of comment %d has already been applied.", comment.ID)
+		}
+
+		if comment.CodeComment.Outdated {
+			return nil, usererror.Conflict(fmt.Sprintf(
+				"The lines of comment %d have changed since the suggestion was made.", comment.ID))
+		}
+
+		path := comment.CodeComment.Path
+		suggestionsPerFile[path] = append(suggestionsPerFile[path], suggestion{
+			comment: comment,
+			lines:   codeSuggestion.Lines,
+		})
+	}
+
+	for path, suggestions := range suggestionsPerFile {
+		sort.Slice(suggestions, func(i, j int) bool {
+			return suggestions[i].lineStart() < suggestions[j].lineStart()
+		})
+
+		for i := 1; i < len(suggestions); i++ {
+			if suggestions[i].lineStart() <= suggestions[i-1].lineEnd() {
+				return nil, usererror.BadRequestf(
+					"Suggestions of comments %d and %d overlap in file %q and can't be applied together.",
+					suggestions[i-1].comment.ID, suggestions[i].comment.ID, path)
+			}
+		}
+	}
+
+	return suggestionsPerFile, nil
+}
+
+func getCodeSuggestion(comment *types.PullReqActivity) (*types.CodeSuggestion, error) {
+	if !comment.IsValidCodeComment() {
+		return nil, usererror.BadRequestf("Comment %d is not a code comment.", comment.ID)
+	}
+
+	payload, err := comment.GetPayload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payload of comment %d: %w", comment.ID, err)
+	}
+
+	codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+	if !ok || codeCommentPayload.Suggestion == nil {
+		return nil, usererror.BadRequestf("Comment %d doesn't contain a suggestion.", comment.ID)
+	}
+
+	return codeCommentPayload.Suggestion, nil
+}
+
+// verifySuggestionsUpToDate makes sure that the commented lines of code comments
+// that haven't been migrated to the latest source commit yet are unchanged in the latest source commit.
+func (c *Controller) verifySuggestionsUpToDate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	pr *types.PullReq,
+	suggestions []suggestion,
+) error {
+	var latestLines []string
+
+	for _, s := range suggestions {
+		cc := s.comment.CodeComment
+		if cc.SourceSHA == pr.SourceSHA {
+			continue
+		}
+
+		if latestLines == nil {
+			_, content, err := c.readSuggestionFile(ctx, readParams, pr.SourceSHA, cc.Path)
+			if err != nil {
+				return err
+			}
+			latestLines = strings.Split(content, "\n")
+		}
+
+		_, content, err := c.readSuggestionFile(ctx, readParams, cc.SourceSHA, cc.Path)
+		if err != nil {
+			return err
+		}
+		commentedLines := strings.Split(content, "\n")
+
+		if !equalLineRange(commentedLines, latestLines, s.lineStart(), s.lineEnd()) {
+			return usererror.Conflict(fmt.Sprintf(
+				"The lines of comment %d have changed since the suggestion was made.", s.comment.ID))
+		}
+	}
+
+	return nil
+}
+
+func equalLineRange(a, b []string, lineStart, lineEnd int) bool {
+	if lineStart < 1 || lineEnd > len(a) || lineEnd > len(b) {
+		return false
+	}
+
+	for i := lineStart - 1; i < lineEnd; i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readSuggestionFile returns the blob SHA and the content of a file at the provided git ref.
+func (c *Controller) readSuggestionFile(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+	path string,
+) (string, string, error) {
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       path,
+	})
+	if gittypes.IsPathNotFoundError(err) {
+		return "", "", usererror.Conflict(fmt.Sprintf("File %q no longer exists on the source branch.", path))
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get file %q: %w", path, err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return "", "", usererror.BadRequestf("Suggestions can be applied only to files, but %q isn't a file.", path)
+	}
+
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  suggestionFileMaxSize,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get content of file %q: %w", path, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if output.Size > output.ContentSize {
+		return "", "", usererror.BadRequestf("File %q is too large to apply suggestions.", path)
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read content of file %q: %w", path, err)
+	}
+
+	return node.Node.SHA, string(content), nil
+}
+
+// applySuggestions replaces the commented lines of the file content with the suggested lines.
+// The suggestions must be sorted by line number and must not overlap.
+// The suggested lines are written with the line endings of the file (LF or CRLF).
+func applySuggestions(content string, suggestions []suggestion) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	// a trailing new line doesn't start a new line of the file.
+	lineCount := len(lines)
+	if strings.HasSuffix(content, "\n") {
+		lineCount--
+	}
+
+	// the lines of a file with CRLF line endings keep the carriage return after the split.
+	crlf := strings.Contains(content, "\r\n")
+
+	// replace lines bottom-up so that the line numbers of the remaining suggestions stay valid.
+	for i := len(suggestions) - 1; i >= 0; i-- {
+		s := suggestions[i]
+		if s.lineStart() < 1 || s.lineEnd() > lineCount || s.lineEnd() < s.lineStart() {
+			return "", usererror.Conflict(fmt.Sprintf(
+				"The lines of comment %d no longer exist in the file.", s.comment.ID))
+		}
+
+		// the last line of a file without a trailing new line has no line ending.
+		lastLineOfFile := s.lineEnd() == len(lines)
+
+		suggested := make([]string, len(s.lines))
+		for j, line := range s.lines {
+			line = strings.TrimSuffix(line, "\r")
+			if crlf && (j < len(s.lines)-1 || !lastLineOfFile) {
+				line += "\r"
+			}
+			suggested[j] = line
+		}
+
+		replaced := make([]string, 0, len(lines)-(s.lineEnd()-s.lineStart()+1)+len(suggested))
+		replaced = append(replaced, lines[:s.lineStart()-1]...)
+		replaced = append(replaced, suggested...)
+		replaced = append(replaced, lines[s.lineEnd():]...)
+		lines = replaced
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// markSuggestionsApplied marks the suggestions of the code comments as applied and resolves the code comments.
+func (c *Controller) markSuggestionsApplied(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pullreqNum int64,
+	commentIDs []int64,
+	commitSHA string,
+) error {
+	var pr *types.PullReq
+
+	err := controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		pr, err = c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request by number: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+
+		for _, commentID := range commentIDs {
+			comment, err := c.activityStore.Find(ctx, commentID)
+			if err != nil {
+				return fmt.Errorf("failed to find comment by ID: %w", err)
+			}
+
+			_, err = c.activityStore.UpdateOptLock(ctx, comment, func(act *types.PullReqActivity) error {
+				payload, err := act.GetPayload()
+				if err != nil {
+					return fmt.Errorf("failed to get code comment payload: %w", err)
+				}
+
+				codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+				if !ok || codeCommentPayload.Suggestion == nil {
+					return fmt.Errorf("code comment %d doesn't contain a suggestion", act.ID)
+				}
+
+				codeCommentPayload.Suggestion.AppliedSHA = commitSHA
+				codeCommentPayload.Suggestion.AppliedBy = &session.Principal.ID
+				codeCommentPayload.Suggestion.Applied = &now
+
+				if err = act.SetPayload(codeCommentPayload); err != nil {
+					return fmt.Errorf("failed to set code comment payload: %w", err)
+				}
+
+				if act.Resolved == nil {
+					act.Resolved = &now
+					act.ResolvedBy = &session.Principal.ID
+				}
+
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to mark suggestion of comment %d as applied: %w", commentID, err)
+			}
+		}
+
+		unresolvedCount, err := c.activityStore.CountUnresolved(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count unresolved comments: %w", err)
+		}
+
+		pr.UnresolvedCount = unresolvedCount
+
+		err = c.pullreqStore.Update(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request's unresolved comment count: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestApplySuggestions(t *testing.T) {
+	type change struct {
+		line  int
+		span  int
+		lines []string
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		changes []change
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "single-line",
+			content: "a\nb\nc\n",
+			changes: []change{{line: 2, span: 1, lines: []string{"B"}}},
+			want:    "a\nB\nc\n",
+		},
+		{
+			name:    "multiple-lines",
+			content: "a\nb\nc\nd\n",
+			changes: []change{{line: 2, span: 2, lines: []string{"X"}}},
+			want:    "a\nX\nd\n",
+		},
+		{
+			name:    "remove-lines",
+			content: "a\nb\nc\n",
+			changes: []change{{line: 1, span: 2, lines: []string{}}},
+			want:    "c\n",
+		},
+		{
+			name:    "several-suggestions",
+			content: "a\nb\nc\nd\n",
+			changes: []change{
+				{line: 1, span: 1, lines: []string{"A1", "A2"}},
+				{line: 3, span: 2, lines: []string{"C"}},
+			},
+			want: "A1\nA2\nb\nC\n",
+		},
+		{
+			name:    "last-line-without-new-line",
+			content: "a\nb",
+			changes: []change{{line: 2, span: 1, lines: []string{"B"}}},
+			want:    "a\nB",
+		},
+		{
+			name:    "crlf",
+			content: "a\r\nb\r\nc\r\n",
+			changes: []change{{line: 2, span: 1, lines: []string{"B1", "B2"}}},
+			want:    "a\r\nB1\r\nB2\r\nc\r\n",
+		},
+		{
+			name:    "crlf-suggestion-with-crlf",
+			content: "a\r\nb\r\n",
+			changes: []change{{line: 1, span: 1, lines: []string{"A\r"}}},
+			want:    "A\r\nb\r\n",
+		},
+		{
+			name:    "crlf-last-line-without-new-line",
+			content: "a\r\nb",
+			changes: []change{{line: 2, span: 1, lines: []string{"B1", "B2"}}},
+			want:    "a\r\nB1\r\nB2",
+		},
+		{
+			name:    "line-out-of-range",
+			content: "a\nb\n",
+			changes: []change{{line: 3, span: 1, lines: []string{"C"}}},
+			wantErr: true,
+		},
+		{
+			name:    "span-out-of-range",
+			content: "a\nb\n",
+			changes: []change{{line: 2, span: 2, lines: []string{"B"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			suggestions := make([]suggestion, len(test.changes))
+			for i, c := range test.changes {
+				suggestions[i] = suggestion{
+					comment: &types.PullReqActivity{
+						ID: int64(i + 1),
+						CodeComment: &types.CodeCommentFields{
+							LineNew: c.line,
+							SpanNew: c.span,
+						},
+					},
+					lines: c.lines,
+				}
+			}
+
+			got, err := applySuggestions(test.content, suggestions)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got content %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEqualLineRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         []string
+		b         []string
+		lineStart int
+		lineEnd   int
+		want      bool
+	}{
+		{
+			name:      "equal",
+			a:         []string{"a", "b", "c"},
+			b:         []string{"a", "b", "c"},
+			lineStart: 1,
+			lineEnd:   3,
+			want:      true,
+		},
+		{
+			name:      "equal-range-different-elsewhere",
+			a:         []string{"a", "b", "c"},
+			b:         []string{"x", "b", "y"},
+			lineStart: 2,
+			lineEnd:   2,
+			want:      true,
+		},
+		{
+			name:      "different-range",
+			a:         []string{"a", "b", "c"},
+			b:         []string{"a", "x", "c"},
+			lineStart: 1,
+			lineEnd:   2,
+			want:      false,
+		},
+		{
+			name:      "range-beyond-end",
+			a:         []string{"a", "b", "c"},
+			b:         []string{"a", "b"},
+			lineStart: 2,
+			lineEnd:   3,
+			want:      false,
+		},
+		{
+			name:      "invalid-start",
+			a:         []string{"a"},
+			b:         []string{"a"},
+			lineStart: 0,
+			lineEnd:   1,
+			want:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := equalLineRange(test.a, test.b, test.lineStart, test.lineEnd); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// templateDefaultPath is the path of the repository's default pull request template.
+	templateDefaultPath = ".harness/PULL_REQUEST_TEMPLATE.md"
+	// templateDefaultName is the name under which the default pull request template is listed.
+	templateDefaultName = "default"
+	// templateDirPath is the path of the directory containing named pull request templates.
+	// The name of a template is its file name without the extension.
+	templateDirPath = ".harness/PULL_REQUEST_TEMPLATE"
+	templateExt     = ".md"
+
+	templateMaxSize  = 64 * 1024
+	templateMaxCount = 50
+)
+
+var errTemplateTooLarge = usererror.BadRequestf(
+	"Pull request template exceeds the maximum size of %d bytes.", templateMaxSize)
+
+// TemplateList returns the pull request description templates stored in the repository at the provided git ref.
+// If the git ref is empty, the templates are read from the default branch.
+func (c *Controller) TemplateList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) ([]types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	return c.listTemplates(ctx, repo, gitRef)
+}
+
+// findTemplate returns the pull request template with the provided name.
+// If the name is empty, the default template is returned if it exists, otherwise nil is returned.
+// Only the requested template is read from the repository.
+func (c *Controller) findTemplate(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	if name == templateDefaultName {
+		name = ""
+	}
+
+	templatePath := templateDefaultPath
+	if name != "" {
+		if strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+			return nil, usererror.BadRequestf("Invalid pull request template name %q", name)
+		}
+		templatePath = path.Join(templateDirPath, name+templateExt)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       templatePath,
+	})
+	if err != nil && !gittypes.IsPathNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get pull request template: %w", err)
+	}
+	if err != nil || node.Node.Mode != git.TreeNodeModeFile {
+		if name == "" {
+			return nil, nil
+		}
+
+		return nil, usererror.BadRequestf("Pull request template %q doesn't exist on branch %q", name, gitRef)
+	}
+
+	if name == "" {
+		name = templateDefaultName
+	}
+
+	template, err := c.readTemplate(ctx, readParams, name, &node.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (c *Controller) listTemplates(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+) ([]types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+
+	templates := make([]types.PullReqTemplate, 0)
+	names := make(map[string]struct{})
+
+	defaultNode, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       templateDefaultPath,
+	})
+	if err != nil && !gittypes.IsPathNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get default pull request template: %w", err)
+	}
+	if err == nil && defaultNode.Node.Mode == git.TreeNodeModeFile {
+		template, err := c.readTemplate(ctx, readParams, templateDefaultName, &defaultNode.Node)
+		if err != nil && !errors.Is(err, errTemplateTooLarge) {
+			return nil, err
+		}
+		if err == nil {
+			templates = append(templates, template)
+			names[template.Name] = struct{}{}
+		}
+	}
+
+	dir, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       templateDirPath,
+	})
+	if gittypes.IsPathNotFoundError(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	for i := range dir.Nodes {
+		node := &dir.Nodes[i]
+		if node.Mode != git.TreeNodeModeFile || path.Ext(node.Name) != templateExt {
+			continue
+		}
+
+		name := strings.TrimSuffix(node.Name, templateExt)
+		if _, exists := names[name]; exists {
+			continue
+		}
+
+		if len(templates) >= templateMaxCount {
+			log.Ctx(ctx).Warn().Msgf("repository contains more than %d pull request templates", templateMaxCount)
+			break
+		}
+
+		template, err := c.readTemplate(ctx, readParams, name, node)
+		if errors.Is(err, errTemplateTooLarge) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+		names[name] = struct{}{}
+	}
+
+	return templates, nil
+}
+
+func (c *Controller) readTemplate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	name string,
+	node *git.TreeNode,
+) (types.PullReqTemplate, error) {
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  templateMaxSize,
+	})
+	if err != nil {
+		return types.PullReqTemplate{}, fmt.Errorf("failed to get pull request template %q: %w", node.Path, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	// oversized templates are rejected, the content would be truncated to the size limit.
+	if output.Size > templateMaxSize {
+		log.Ctx(ctx).Warn().Msgf("pull request template %q exceeds the maximum size of %d bytes",
+			node.Path, templateMaxSize)
+		return types.PullReqTemplate{}, errTemplateTooLarge
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return types.PullReqTemplate{}, fmt.Errorf("failed to read pull request template %q: %w", node.Path, err)
+	}
+
+	return types.PullReqTemplate{
+		Name:    name,
+		Path:    node.Path,
+		Content: string(content),
+	}, nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+)
+
+// templateGitMock serves the files of a repository tree. The SHA of a file is its path.
+type templateGitMock struct {
+	git.Interface
+	files map[string]string
+	blobs []string
+}
+
+func (g *templateGitMock) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	if _, ok := g.files[params.Path]; ok {
+		return &git.GetTreeNodeOutput{Node: git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  params.Path,
+			Name: path.Base(params.Path),
+			Path: params.Path,
+		}}, nil
+	}
+
+	for filePath := range g.files {
+		if strings.HasPrefix(filePath, params.Path+"/") {
+			return &git.GetTreeNodeOutput{Node: git.TreeNode{
+				Type: git.TreeNodeTypeTree,
+				Mode: git.TreeNodeModeTree,
+				Name: path.Base(params.Path),
+				Path: params.Path,
+			}}, nil
+		}
+	}
+
+	return nil, &gittypes.PathNotFoundError{Path: params.Path}
+}
+
+func (g *templateGitMock) ListTreeNodes(
+	_ context.Context,
+	params *git.ListTreeNodeParams,
+) (*git.ListTreeNodeOutput, error) {
+	nodes := map[string]git.TreeNode{}
+	for filePath := range g.files {
+		rest, ok := strings.CutPrefix(filePath, params.Path+"/")
+		if !ok {
+			continue
+		}
+
+		name, _, isDir := strings.Cut(rest, "/")
+		node := git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  filePath,
+			Name: name,
+			Path: path.Join(params.Path, name),
+		}
+		if isDir {
+			node.Type = git.TreeNodeTypeTree
+			node.Mode = git.TreeNodeModeTree
+			node.SHA = ""
+		}
+		nodes[name] = node
+	}
+
+	if len(nodes) == 0 {
+		return nil, &gittypes.PathNotFoundError{Path: params.Path}
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := &git.ListTreeNodeOutput{}
+	for _, name := range names {
+		out.Nodes = append(out.Nodes, nodes[name])
+	}
+
+	return out, nil
+}
+
+func (g *templateGitMock) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content, ok := g.files[params.SHA]
+	if !ok {
+		return nil, errors.New("blob not found")
+	}
+
+	g.blobs = append(g.blobs, params.SHA)
+
+	size := int64(len(content))
+	if params.SizeLimit > 0 && size > params.SizeLimit {
+		content = content[:params.SizeLimit]
+	}
+
+	return &git.GetBlobOutput{
+		SHA:         params.SHA,
+		Size:        size,
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func newTemplateFiles(withDefault bool) map[string]string {
+	files := map[string]string{
+		templateDirPath + "/bug.md":         "## Bug",
+		templateDirPath + "/feature.md":     "## Feature",
+		templateDirPath + "/large.md":       strings.Repeat("a", templateMaxSize+1),
+		templateDirPath + "/notes.txt":      "notes",
+		templateDirPath + "/nested.md/a.md": "## Nested",
+		"README.md":                         "readme",
+	}
+	if withDefault {
+		files[templateDefaultPath] = "## Default"
+	}
+	return files
+}
+
+func TestFindTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		withDefault bool
+		template    string
+		want        *types.PullReqTemplate
+		wantBlobs   []string
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{
+			name:        "default",
+			withDefault: true,
+			template:    "",
+			want:        &types.PullReqTemplate{Name: "default", Path: templateDefaultPath, Content: "## Default"},
+			wantBlobs:   []string{templateDefaultPath},
+		},
+		{
+			name:        "default by name",
+			withDefault: true,
+			template:    "default",
+			want:        &types.PullReqTemplate{Name: "default", Path: templateDefaultPath, Content: "## Default"},
+			wantBlobs:   []string{templateDefaultPath},
+		},
+		{
+			name:        "no default",
+			withDefault: false,
+			template:    "",
+			want:        nil,
+		},
+		{
+			name:        "named",
+			withDefault: true,
+			template:    "bug",
+			want:        &types.PullReqTemplate{Name: "bug", Path: templateDirPath + "/bug.md", Content: "## Bug"},
+			wantBlobs:   []string{templateDirPath + "/bug.md"},
+		},
+		{
+			name:       "missing",
+			template:   "docs",
+			wantAnyErr: true,
+		},
+		{
+			name:       "directory",
+			template:   "nested",
+			wantAnyErr: true,
+		},
+		{
+			name:       "path traversal",
+			template:   "../../README",
+			wantAnyErr: true,
+		},
+		{
+			name:       "parent directory",
+			template:   "..",
+			wantAnyErr: true,
+		},
+		{
+			name:      "too large",
+			template:  "large",
+			wantBlobs: []string{templateDirPath + "/large.md"},
+			wantErr:   errTemplateTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitMock := &templateGitMock{files: newTemplateFiles(test.withDefault)}
+			c := &Controller{git: gitMock}
+
+			template, err := c.findTemplate(context.Background(), &types.Repository{GitUID: "repo"}, "main", test.template)
+			switch {
+			case test.wantErr != nil:
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("want error %v, got %v", test.wantErr, err)
+				}
+			case test.wantAnyErr:
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.want == nil && template != nil:
+				t.Errorf("want no template, got %+v", *template)
+			case test.want != nil && (template == nil || *template != *test.want):
+				t.Errorf("want %+v, got %+v", *test.want, template)
+			}
+
+			if strings.Join(gitMock.blobs, ",") != strings.Join(test.wantBlobs, ",") {
+				t.Errorf("read blobs: want %v, got %v", test.wantBlobs, gitMock.blobs)
+			}
+		})
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		withDefault bool
+		want        []string
+	}{
+		{
+			name:        "with default",
+			withDefault: true,
+			want:        []string{"default", "bug", "feature"},
+		},
+		{
+			name:        "without default",
+			withDefault: false,
+			want:        []string{"bug", "feature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{git: &templateGitMock{files: newTemplateFiles(test.withDefault)}}
+
+			templates, err := c.listTemplates(context.Background(), &types.Repository{GitUID: "repo"}, "main")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			names := make([]string, len(templates))
+			for i, template := range templates {
+				names[i] = template.Name
+			}
+
+			if strings.Join(names, ",") != strings.Join(test.want, ",") {
+				t.Errorf("want %v, got %v", test.want, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateBranchInput struct {
+	// Method is used to bring the source branch up to date with the target branch.
+	// Supported are merge (default) and rebase.
+	Method      enum.MergeMethod `json:"method"`
+	SourceSHA   string           `json:"source_sha"`
+	BypassRules bool             `json:"bypass_rules"`
+}
+
+func (in *UpdateBranchInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	if in.Method == "" {
+		in.Method = enum.MergeMethodMerge
+	}
+
+	if in.Method != enum.MergeMethodMerge && in.Method != enum.MergeMethodRebase {
+		return usererror.BadRequestf("unsupported update branch method: %s", in.Method)
+	}
+
+	return nil
+}
+
+type UpdateBranchOutput struct {
+	// SHA is the latest commit of the source branch after the update.
+	SHA string `json:"sha"`
+}
+
+// UpdateBranch brings the source branch of a pull request up to date with its target branch,
+// either by merging the target branch into the source branch or by rebasing the source branch on top of it.
+// The source branch is updated like on a regular push, so the pull request gets updated by the branch update event.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) UpdateBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *UpdateBranchInput,
+) (*UpdateBranchOutput, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	// the max time we give an update to succeed
+	const timeout = 3 * time.Minute
+
+	unlock, err := c.lockPR(ctx, targetRepo.ID, 0, timeout+30*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be updated.")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	// the source branch gets updated, so the user must be allowed to push to the source repository.
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false); err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(targetRepo),
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	targetSHA := targetBranch.Branch.SHA
+
+	// the source commits are available in the target repository (see the pull request head reference).
+	ancestorOut, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          git.CreateReadParams(targetRepo),
+		AncestorCommitSHA:   targetSHA,
+		DescendantCommitSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check if source branch is up to date: %w", err)
+	}
+	if ancestorOut.Ancestor {
+		return nil, nil, usererror.BadRequest("The source branch is already up to date with the target branch.")
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, sourceRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, sourceRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        sourceRepo,
+		RefAction:   protection.RefActionUpdate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{pr.SourceBranch},
+		// the commits of the target branch are added to the source branch,
+		// either with a merge commit or by rebasing the source commits, both created by the server.
+		Commits: protection.NewCommitLister(func(ctx context.Context, _ string) ([]git.CommitSignedData, error) {
+			out, err := c.git.ListCommitSignatures(ctx, git.ListCommitSignaturesParams{
+				ReadParams: git.CreateReadParams(targetRepo),
+				GitREF:     targetSHA,
+				Exclude:    []string{pr.SourceSHA},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit signatures: %w", err)
+			}
+
+			return out.Commits, nil
+		}),
+		ServerCommits: true,
+		SigningKey:    c.signer.SigningKey(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	sourceWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	if sourceRepo.ID != targetRepo.ID {
+		err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+			WriteParams:   sourceWriteParams,
+			SourceRepoUID: targetRepo.GitUID,
+			ObjectSHAs:    []string{targetSHA},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch target branch commits into source repository: %w", err)
+		}
+	}
+
+	now := time.Now()
+	params := &git.MergeParams{
+		WriteParams:   sourceWriteParams,
+		CommitterDate: &now,
+		AuthorDate:    &now,
+		RefType:       gitenum.RefTypeBranch,
+		RefName:       pr.SourceBranch,
+		Method:        gitenum.MergeMethod(in.Method),
+	}
+
+	switch in.Method {
+	case enum.MergeMethodMerge:
+		// merge the target branch into the source branch.
+		params.BaseBranch = pr.SourceBranch
+		params.HeadBranch = targetSHA
+		params.HeadExpectedSHA = targetSHA
+		params.Title = fmt.Sprintf("Merge branch '%s' into %s", pr.TargetBranch, pr.SourceBranch)
+		params.Author = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+		params.Committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+	case enum.MergeMethodRebase:
+		// rebase the source branch on top of the target branch.
+		params.BaseBranch = targetSHA
+		params.HeadBranch = pr.SourceBranch
+		params.HeadExpectedSHA = pr.SourceSHA
+		params.Committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	}
+
+	mergeOutput, err := c.git.Merge(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update source branch: %w", err)
+	}
+
+	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  mergeOutput.ConflictFiles,
+			RuleViolations: violations,
+		}, nil
+	}
+
+	return &UpdateBranchOutput{SHA: mergeOutput.MergeSHA}, nil, nil
+}
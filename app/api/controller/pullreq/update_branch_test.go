@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestUpdateBranchInputSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      UpdateBranchInput
+		want    UpdateBranchInput
+		wantErr bool
+	}{
+		{
+			name: "default method",
+			in:   UpdateBranchInput{SourceSHA: "abc"},
+			want: UpdateBranchInput{SourceSHA: "abc", Method: enum.MergeMethodMerge},
+		},
+		{
+			name: "rebase",
+			in:   UpdateBranchInput{SourceSHA: "abc", Method: enum.MergeMethodRebase, BypassRules: true},
+			want: UpdateBranchInput{SourceSHA: "abc", Method: enum.MergeMethodRebase, BypassRules: true},
+		},
+		{
+			name:    "missing source SHA",
+			in:      UpdateBranchInput{Method: enum.MergeMethodMerge},
+			wantErr: true,
+		},
+		{
+			name:    "squash",
+			in:      UpdateBranchInput{SourceSHA: "abc", Method: enum.MergeMethodSquash},
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward",
+			in:      UpdateBranchInput{SourceSHA: "abc", Method: enum.MergeMethodFastForward},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if in != test.want {
+				t.Errorf("want %+v, got %+v", test.want, in)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/sse"
@@ -47,6 +50,12 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
 	codeOwners *codeowners.Service,
+	mergeQueueStore store.MergeQueueStore, mergeQueue *mergequeue.Service,
+	dependencyStore store.PullReqDependencyStore,
+	pullreqLabelStore store.PullReqLabelStore, labelSvc *label.Service,
+	reactionStore store.PullReqReactionStore,
+	textVersionStore store.PullReqTextVersionStore,
+	signer *gitsignature.Signer,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -57,5 +66,11 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		mtxManager, codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners)
+		pullreqService, ruleManager, sseStreamer, codeOwners,
+		mergeQueueStore, mergeQueue,
+		dependencyStore,
+		pullreqLabelStore, labelSvc,
+		reactionStore,
+		textVersionStore,
+		signer)
 }
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	archiveBucketPathFmt = "archives/%d/%s/%s.%s"
+)
+
+// ArchiveOutput contains the archive of a repository.
+// Either SignedURL or Content is set, depending on whether the archive is served from the blob store.
+type ArchiveOutput struct {
+	FileName  string
+	Format    gitenum.ArchiveFormat
+	SignedURL string
+	Content   io.ReadCloser
+}
+
+// Archive returns an archive of the repository at the git ref encoded in the provided archive name
+// (e.g. "main.zip" or "v1.0.0.tar.gz"), optionally restricted to the provided path.
+// Archives of tags are cached in the blob store.
+func (c *Controller) Archive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archiveName string,
+	path string,
+) (*ArchiveOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	gitRef, format, ok := parseArchiveName(archiveName)
+	if !ok {
+		return nil, usererror.BadRequestf("Archive name must end in one of: %s.", archiveFormatList())
+	}
+
+	path = strings.Trim(path, "/")
+
+	// validate that the reference and path exist before any data is streamed.
+	if _, err = c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams:          git.CreateReadParams(repo),
+		GitREF:              gitRef,
+		Path:                path,
+		IncludeLatestCommit: false,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
+	}
+
+	name := repo.Identifier + "-" + strings.ReplaceAll(gitRef, "/", "-")
+	params := &git.ArchiveParams{
+		ReadParams: git.CreateReadParams(repo),
+		Format:     format,
+		Ref:        gitRef,
+		Prefix:     name + "/",
+	}
+	if path != "" {
+		params.Paths = []string{path}
+	}
+
+	out := &ArchiveOutput{
+		FileName: name + "." + string(format),
+		Format:   format,
+	}
+
+	// only archives of full tags are cached, as tags (unlike branches) aren't expected to move.
+	if path == "" {
+		tagRef, err := c.git.GetRef(ctx, git.GetRefParams{
+			ReadParams: git.CreateReadParams(repo),
+			Name:       gitRef,
+			Type:       gitenum.RefTypeTag,
+		})
+		if err != nil && !gitnesserrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get tag: %w", err)
+		}
+		if err == nil {
+			bucketPath := fmt.Sprintf(archiveBucketPathFmt, repo.ID, tagRef.SHA, name, format)
+			out.SignedURL, out.Content, err = c.getCachedArchive(ctx, params, bucketPath)
+			if err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		}
+	}
+
+	out.Content = c.streamArchive(ctx, params)
+
+	return out, nil
+}
+
+// getCachedArchive returns the archive from the blob store, the archive is generated if it's not cached yet.
+// If the blob store supports signed URLs, only the URL is returned and the archive content isn't downloaded.
+func (c *Controller) getCachedArchive(
+	ctx context.Context,
+	params *git.ArchiveParams,
+	bucketPath string,
+) (string, io.ReadCloser, error) {
+	signedURL, err := c.blobStore.GetSignedURL(ctx, bucketPath)
+	if errors.Is(err, blob.ErrNotSupported) {
+		content, err := c.downloadCachedArchive(ctx, params, bucketPath)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "", content, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	exists, err := c.blobStore.Exists(ctx, bucketPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to check if archive exists in blob store: %w", err)
+	}
+
+	if !exists {
+		if err = c.cacheArchive(ctx, params, bucketPath); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return signedURL, nil, nil
+}
+
+// downloadCachedArchive returns a reader for the archive in the blob store,
+// the archive is generated if it's not cached yet.
+func (c *Controller) downloadCachedArchive(
+	ctx context.Context,
+	params *git.ArchiveParams,
+	bucketPath string,
+) (io.ReadCloser, error) {
+	content, err := c.blobStore.Download(ctx, bucketPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		if err = c.cacheArchive(ctx, params, bucketPath); err != nil {
+			return nil, err
+		}
+		content, err = c.blobStore.Download(ctx, bucketPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive from blob store: %w", err)
+	}
+
+	return content, nil
+}
+
+// cacheArchive generates the archive and uploads it to the blob store.
+func (c *Controller) cacheArchive(
+	ctx context.Context,
+	params *git.ArchiveParams,
+	bucketPath string,
+) error {
+	archive := c.streamArchive(ctx, params)
+	defer func() {
+		if cErr := archive.Close(); cErr != nil {
+			log.Ctx(ctx).Warn().Err(cErr).Msg("failed to close archive stream")
+		}
+	}()
+
+	if err := c.blobStore.Upload(ctx, archive, bucketPath); err != nil {
+		return fmt.Errorf("failed to upload archive to blob store: %w", err)
+	}
+
+	return nil
+}
+
+// streamArchive generates the archive in the background and returns a reader for its content.
+// Any failure during the generation is returned as error by the reader.
+func (c *Controller) streamArchive(ctx context.Context, params *git.ArchiveParams) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		err := c.git.Archive(ctx, params, pw)
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr
+}
+
+// parseArchiveName splits the archive name into the git ref and the archive format.
+func parseArchiveName(archiveName string) (string, gitenum.ArchiveFormat, bool) {
+	for _, format := range gitenum.ArchiveFormats {
+		gitRef, ok := strings.CutSuffix(archiveName, "."+string(format))
+		if ok && gitRef != "" {
+			return gitRef, format, true
+		}
+	}
+
+	return "", "", false
+}
+
+func archiveFormatList() string {
+	formats := make([]string, len(gitenum.ArchiveFormats))
+	for i, format := range gitenum.ArchiveFormats {
+		formats[i] = "." + string(format)
+	}
+
+	return strings.Join(formats, ", ")
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"testing"
+
+	gitenum "github.com/harness/gitness/git/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArchiveName(t *testing.T) {
+	tests := []struct {
+		name       string
+		archive    string
+		wantRef    string
+		wantFormat gitenum.ArchiveFormat
+		wantOK     bool
+	}{
+		{
+			name:       "zip",
+			archive:    "main.zip",
+			wantRef:    "main",
+			wantFormat: gitenum.ArchiveFormatZip,
+			wantOK:     true,
+		},
+		{
+			name:       "tar.gz",
+			archive:    "main.tar.gz",
+			wantRef:    "main",
+			wantFormat: gitenum.ArchiveFormatTarGz,
+			wantOK:     true,
+		},
+		{
+			name:       "ref with slashes and dots",
+			archive:    "release/v1.2.tar.gz",
+			wantRef:    "release/v1.2",
+			wantFormat: gitenum.ArchiveFormatTarGz,
+			wantOK:     true,
+		},
+		{
+			name:       "commit sha",
+			archive:    "1d0e5f8e6c1a5a0e4b3c2d1e0f9a8b7c6d5e4f3a.zip",
+			wantRef:    "1d0e5f8e6c1a5a0e4b3c2d1e0f9a8b7c6d5e4f3a",
+			wantFormat: gitenum.ArchiveFormatZip,
+			wantOK:     true,
+		},
+		{
+			name:    "unsupported format",
+			archive: "main.tar",
+		},
+		{
+			name:    "no format",
+			archive: "main",
+		},
+		{
+			name:    "format only",
+			archive: ".zip",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitRef, format, ok := parseArchiveName(test.archive)
+			require.Equal(t, test.wantOK, ok)
+			require.Equal(t, test.wantRef, gitRef)
+			require.Equal(t, test.wantFormat, format)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CommitOperationPullReqInput holds the data of the pull request
+// that's opened for the new branch created by a commit operation.
+type CommitOperationPullReqInput struct {
+	IsDraft     bool   `json:"is_draft"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type CherryPickInput struct {
+	// FromSHA optionally specifies the start (exclusive) of a range of commits.
+	// If provided, all non-merge commits after FromSHA up to the commit are cherry-picked.
+	FromSHA string `json:"from_sha"`
+
+	// Branch is the branch the commits are applied to (default: default branch of the repository).
+	Branch string `json:"branch"`
+	// NewBranch optionally specifies a new branch that is created from Branch for the result.
+	NewBranch string `json:"new_branch"`
+	// PullReq optionally opens a pull request from NewBranch into Branch.
+	PullReq *CommitOperationPullReqInput `json:"pull_req"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CherryPickInput) sanitize(repo *types.Repository, commitSHA string) error {
+	in.FromSHA = strings.TrimSpace(in.FromSHA)
+
+	return sanitizeCommitOperationInput(repo, commitSHA, &in.Branch, &in.NewBranch, in.PullReq,
+		"Cherry-pick %s into %s")
+}
+
+// CherryPick applies the changes introduced by a commit (or a range of commits) to a branch.
+func (c *Controller) CherryPick(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *CherryPickInput,
+) (*types.CommitOperationResponse, *types.MergeViolations, error) {
+	requiredPermission := enum.PermissionRepoPush
+	if in.DryRunRules {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, requiredPermission, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = in.sanitize(repo, commitSHA); err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := c.verifyCommitOperationRules(ctx, session, repo, in.Branch, in.NewBranch, in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if in.DryRunRules {
+		return &types.CommitOperationResponse{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	out, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:   writeParams,
+		CommitSHA:     commitSHA,
+		FromSHA:       in.FromSHA,
+		BranchName:    in.Branch,
+		NewBranchName: in.NewBranch,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{ConflictFiles: out.ConflictFiles}, nil
+	}
+
+	response := newCommitOperationResponse(in.Branch, in.NewBranch, out.BaseSHA, out.CommitSHA, violations)
+
+	err = c.createCommitOperationPullReq(ctx, session, repoRef, writeParams, in.Branch, in.NewBranch, in.PullReq,
+		response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, nil, nil
+}
+
+// sanitizeCommitOperationInput sanitizes the branches and the pull request of a commit operation.
+// The default pull request title is generated from titleFormat using the commit SHA and the branch.
+func sanitizeCommitOperationInput(
+	repo *types.Repository,
+	commitSHA string,
+	branch, newBranch *string,
+	pullReq *CommitOperationPullReqInput,
+	titleFormat string,
+) error {
+	*branch = strings.TrimSpace(*branch)
+	if *branch == "" {
+		*branch = repo.DefaultBranch
+	}
+
+	*newBranch = strings.TrimSpace(*newBranch)
+
+	if pullReq == nil {
+		return nil
+	}
+
+	if *newBranch == "" {
+		return usererror.BadRequest("A pull request can only be opened if a new branch is created.")
+	}
+
+	pullReq.Title = strings.TrimSpace(pullReq.Title)
+	if pullReq.Title == "" {
+		pullReq.Title = fmt.Sprintf(titleFormat, shortSHA(commitSHA), *branch)
+	}
+
+	return nil
+}
+
+// createCommitOperationPullReq opens the pull request requested for the new branch of a commit operation.
+// If the pull request can't be opened, the new branch is deleted again.
+func (c *Controller) createCommitOperationPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	writeParams git.WriteParams,
+	branch, newBranch string,
+	in *CommitOperationPullReqInput,
+	response *types.CommitOperationResponse,
+) error {
+	if in == nil {
+		return nil
+	}
+
+	pr, err := c.pullreqCtrl.Create(ctx, session, repoRef, &pullreq.CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        in.Title,
+		Description:  in.Description,
+		SourceBranch: newBranch,
+		TargetBranch: branch,
+	})
+	if err != nil {
+		errDelete := c.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Name:        newBranch,
+			Type:        gitenum.RefTypeBranch,
+			NewValue:    "", // when NewValue is empty will delete the ref.
+			OldValue:    response.SHA,
+		})
+		if errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).
+				Msgf("failed to delete branch %q after failing to open the pull request", newBranch)
+		}
+
+		return err
+	}
+
+	response.PullReq = pr
+
+	return nil
+}
+
+func (c *Controller) verifyCommitOperationRules(ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	branch, newBranch string,
+	bypassRules bool,
+) ([]types.RuleViolations, error) {
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := branch
+	if newBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = newBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+		// the commits added to the branch are created by the server.
+		Commits:       protection.NoCommits,
+		ServerCommits: true,
+		SigningKey:    c.signer.SigningKey(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+func newCommitOperationResponse(
+	branch, newBranch string,
+	baseSHA, sha string,
+	violations []types.RuleViolations,
+) *types.CommitOperationResponse {
+	if newBranch != "" {
+		branch = newBranch
+	}
+
+	return &types.CommitOperationResponse{
+		Branch:         branch,
+		BaseSHA:        baseSHA,
+		SHA:            sha,
+		RuleViolations: violations,
+	}
+}
+
+func shortSHA(sha string) string {
+	const shortSHALength = 8
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
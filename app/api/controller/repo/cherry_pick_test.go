@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeCommitOperationInput(t *testing.T) {
+	const commitSHA = "1d0e5f8e6c1a5a0e4b3c2d1e0f9a8b7c6d5e4f3a"
+
+	repo := &types.Repository{DefaultBranch: "main"}
+
+	tests := []struct {
+		name          string
+		branch        string
+		newBranch     string
+		pullReq       *CommitOperationPullReqInput
+		wantErr       bool
+		wantBranch    string
+		wantNewBranch string
+		wantTitle     string
+	}{
+		{
+			name:       "default branch",
+			branch:     "",
+			wantBranch: "main",
+		},
+		{
+			name:          "trimmed branches",
+			branch:        " release ",
+			newBranch:     " fix ",
+			wantBranch:    "release",
+			wantNewBranch: "fix",
+		},
+		{
+			name:          "pull request with default title",
+			branch:        "main",
+			newBranch:     "fix",
+			pullReq:       &CommitOperationPullReqInput{Title: "  "},
+			wantBranch:    "main",
+			wantNewBranch: "fix",
+			wantTitle:     "Cherry-pick 1d0e5f8e into main",
+		},
+		{
+			name:          "pull request with title",
+			branch:        "main",
+			newBranch:     "fix",
+			pullReq:       &CommitOperationPullReqInput{Title: " Backport fix "},
+			wantBranch:    "main",
+			wantNewBranch: "fix",
+			wantTitle:     "Backport fix",
+		},
+		{
+			name:    "pull request without new branch",
+			branch:  "main",
+			pullReq: &CommitOperationPullReqInput{},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			branch, newBranch := test.branch, test.newBranch
+
+			err := sanitizeCommitOperationInput(repo, commitSHA, &branch, &newBranch, test.pullReq,
+				"Cherry-pick %s into %s")
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.wantBranch, branch)
+			require.Equal(t, test.wantNewBranch, newBranch)
+			if test.pullReq != nil {
+				require.Equal(t, test.wantTitle, test.pullReq.Title)
+			}
+		})
+	}
+}
+
+func TestNewCommitOperationResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		newBranch  string
+		wantBranch string
+	}{
+		{
+			name:       "existing branch",
+			branch:     "main",
+			wantBranch: "main",
+		},
+		{
+			name:       "new branch",
+			branch:     "main",
+			newBranch:  "fix",
+			wantBranch: "fix",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := newCommitOperationResponse(test.branch, test.newBranch, "base", "sha", nil)
+			require.Equal(t, test.wantBranch, response.Branch)
+			require.Equal(t, "base", response.BaseSHA)
+			require.Equal(t, "sha", response.SHA)
+		})
+	}
+}
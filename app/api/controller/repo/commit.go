@@ -94,6 +94,10 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		RefAction:   refAction,
 		RefType:     protection.RefTypeBranch,
 		RefNames:    []string{branchName},
+		// the only commit added to the branch is created by the server.
+		Commits:       protection.NoCommits,
+		ServerCommits: true,
+		SigningKey:    c.signer.SigningKey(),
 	})
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -22,16 +22,22 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -66,6 +72,16 @@ type Controller struct {
 	resourceLimiter    limiter.ResourceLimiter
 	mtxManager         lock.MutexManager
 	identifierCheck    check.RepoIdentifier
+	deployKeyStore     store.DeployKeyStore
+	publicKeyService   publickey.Service
+	blobStore          blob.Store
+	signatureVerifier  *gitsignature.Verifier
+	signer             *gitsignature.Signer
+	pullMirrorStore    store.PullMirrorStore
+	pushMirrorStore    store.PushMirrorStore
+	mirror             *mirror.Service
+	labelSvc           *label.Service
+	pullreqCtrl        *pullreq.Controller
 }
 
 func NewController(
@@ -88,6 +104,16 @@ func NewController(
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyService publickey.Service,
+	blobStore blob.Store,
+	signatureVerifier *gitsignature.Verifier,
+	signer *gitsignature.Signer,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	mirror *mirror.Service,
+	labelSvc *label.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
@@ -110,6 +136,16 @@ func NewController(
 		resourceLimiter:               limiter,
 		mtxManager:                    mtxManager,
 		identifierCheck:               identifierCheck,
+		deployKeyStore:                deployKeyStore,
+		publicKeyService:              publicKeyService,
+		blobStore:                     blobStore,
+		signatureVerifier:             signatureVerifier,
+		signer:                        signer,
+		pullMirrorStore:               pullMirrorStore,
+		pushMirrorStore:               pushMirrorStore,
+		mirror:                        mirror,
+		labelSvc:                      labelSvc,
+		pullreqCtrl:                   pullreqCtrl,
 	}
 }
 
@@ -182,6 +218,23 @@ func (c *Controller) fetchRules(
 	return protectionRules, isRepoOwner, nil
 }
 
+// newCommitsLister returns a CommitLister listing the commits reachable from the git ref
+// that aren't reachable from any existing reference of the repository.
+func (c *Controller) newCommitsLister(repo *types.Repository, gitRef string) protection.CommitLister {
+	return protection.NewCommitLister(func(ctx context.Context, _ string) ([]git.CommitSignedData, error) {
+		out, err := c.git.ListCommitSignatures(ctx, git.ListCommitSignaturesParams{
+			ReadParams:     git.CreateReadParams(repo),
+			GitREF:         gitRef,
+			ExcludeAllRefs: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commit signatures: %w", err)
+		}
+
+		return out.Commits, nil
+	})
+}
+
 func (c *Controller) getRuleUsers(ctx context.Context, r *types.Rule) (map[int64]*types.PrincipalInfo, error) {
 	rule, err := c.protectionManager.FromJSON(r.Type, r.Definition, false)
 	if err != nil {
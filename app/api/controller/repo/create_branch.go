@@ -66,6 +66,7 @@ func (c *Controller) CreateBranch(ctx context.Context,
 		RefAction:   protection.RefActionCreate,
 		RefType:     protection.RefTypeBranch,
 		RefNames:    []string{in.Name},
+		Commits:     c.newCommitsLister(repo, in.Target),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+type CreateDeployKeyInput struct {
+	Identifier string `json:"identifier"`
+	Content    string `json:"content"`
+	ReadOnly   bool   `json:"read_only"`
+}
+
+// CreateDeployKey adds a new deploy key to a repository.
+func (c *Controller) CreateDeployKey(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateDeployKeyInput,
+) (*types.DeployKey, error) {
+	if err := c.sanitizeCreateDeployKeyInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	key, comment, err := publickey.ParseKey(in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := gossh.FingerprintSHA256(key)
+
+	// a key identifies exactly one owner - the same key can't be used as a deploy key and a user key.
+	inUse, err := c.publicKeyService.IsKeyInUse(ctx, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check whether public key is in use: %w", err)
+	}
+	if inUse {
+		return nil, publickey.ErrKeyInUse
+	}
+
+	deployKey := &types.DeployKey{
+		RepoID:      repo.ID,
+		Identifier:  in.Identifier,
+		Created:     time.Now().UnixMilli(),
+		CreatedBy:   session.Principal.ID,
+		LastUsed:    nil,
+		ReadOnly:    in.ReadOnly,
+		Fingerprint: fingerprint,
+		Content:     strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))),
+		Comment:     comment,
+		Type:        key.Type(),
+	}
+
+	if err = c.deployKeyStore.Create(ctx, deployKey); err != nil {
+		return nil, fmt.Errorf("failed to create deploy key: %w", err)
+	}
+
+	return deployKey, nil
+}
+
+func (c *Controller) sanitizeCreateDeployKeyInput(in *CreateDeployKeyInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return usererror.BadRequest("The deploy key content is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errPublicForkOfPrivateRepo = usererror.BadRequest("A fork of a private repository can't be public.")
+
+type ForkInput struct {
+	ParentRef   string  `json:"parent_ref"`
+	Identifier  string  `json:"identifier"`
+	Description *string `json:"description"`
+	IsPublic    bool    `json:"is_public"`
+}
+
+// Fork creates a fork of the repository in the target space.
+// The fork shares the git objects of the upstream repository, only new objects are stored separately.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*types.Repository, error) {
+	upstream, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeForkInput(in, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		envVars, err := githook.GenerateEnvironmentVariables(
+			ctx,
+			c.urlProvider.GetInternalAPIURL(),
+			0,
+			session.Principal.ID,
+			true,
+			true,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+		}
+
+		gitResp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+			Actor:           *identityFromPrincipal(session.Principal),
+			EnvVars:         envVars,
+			UpstreamRepoUID: upstream.GitUID,
+		})
+		if err != nil {
+			return fmt.Errorf("error forking repository on git: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   *in.Description,
+			IsPublic:      in.IsPublic,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        upstream.ID,
+			DefaultBranch: gitResp.DefaultBranch,
+		}
+		err = c.repoStore.Create(ctx, repo)
+		if err != nil {
+			if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+				log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+			}
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		return c.updateNumForks(ctx, upstream.ID)
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	err = c.indexer.Index(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, upstream *types.Repository) error {
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	// the fork contains all the code of the upstream repository, so it mustn't be more visible.
+	if in.IsPublic && !upstream.IsPublic {
+		return errPublicForkOfPrivateRepo
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.Description == nil {
+		in.Description = &upstream.Description
+	}
+
+	description := strings.TrimSpace(*in.Description)
+	if err := check.Description(description); err != nil {
+		return err
+	}
+	in.Description = &description
+
+	return nil
+}
+
+// updateNumForks updates the number of active forks of the repository.
+func (c *Controller) updateNumForks(ctx context.Context, repoID int64) error {
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the repository got deleted, nothing to update.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	numForks, err := c.repoStore.CountForks(ctx, repoID, &types.RepoFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to count forks: %w", err)
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.NumForks = int(numForks)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update number of forks: %w", err)
+	}
+
+	return nil
+}
+
+// updateUpstreamNumForks updates the number of forks of the upstream repository after a fork got
+// deleted or restored. Failures are only logged, the number of forks is corrected on the next update.
+func (c *Controller) updateUpstreamNumForks(ctx context.Context, repo *types.Repository) {
+	if repo.ForkID == 0 {
+		return
+	}
+
+	if err := c.updateNumForks(ctx, repo.ForkID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Int64("upstream_repo_id", repo.ForkID).
+			Msg("failed to update number of forks of the upstream repository")
+	}
+}
+
+// dissociateForks makes all forks of the repository independent of its git objects
+// and detaches them from the repository. It has to be called before the repository is deleted.
+func (c *Controller) dissociateForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	forks, err := c.repoStore.ListForksWithDeleted(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{
+			WriteParams: writeParams,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to dissociate fork %d: %w", fork.ID, err)
+		}
+
+		// the fork is independent now, so it's no longer a fork (deleted forks included).
+		fork.ForkID = 0
+		if err = c.repoStore.Update(ctx, fork); err != nil {
+			return fmt.Errorf("failed to detach fork %d: %w", fork.ID, err)
+		}
+	}
+
+	return nil
+}
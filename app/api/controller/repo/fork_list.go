@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// forkListPageSize is the number of forks read from the store at once.
+const forkListPageSize = 100
+
+// ListForks lists the forks of a repository.
+// NOTE: Forks the principal isn't allowed to view are omitted from the result and the count.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoFilter,
+) ([]*types.Repository, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	visible, err := c.listVisibleForks(ctx, session, repo.ID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count := int64(len(visible))
+
+	// forks are spread across spaces with different permissions, so the visibility can't be part of the query.
+	// the pagination is applied to the visible forks instead.
+	page, size := filter.Page, filter.Size
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = forkListPageSize
+	}
+
+	start := (page - 1) * size
+	if start > len(visible) {
+		start = len(visible)
+	}
+	end := start + size
+	if end > len(visible) {
+		end = len(visible)
+	}
+
+	forks := visible[start:end]
+	for _, fork := range forks {
+		// backfill GitURL
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(fork.Path)
+	}
+
+	return forks, count, nil
+}
+
+// listVisibleForks returns all active forks of the repository the principal is allowed to view.
+func (c *Controller) listVisibleForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	storeFilter := &types.RepoFilter{
+		Size:  forkListPageSize,
+		Query: filter.Query,
+		Sort:  filter.Sort,
+		Order: filter.Order,
+		// only active forks are listed.
+		DeletedBeforeOrAt: nil,
+	}
+
+	var visible []*types.Repository
+	for storeFilter.Page = 1; ; storeFilter.Page++ {
+		forks, err := c.repoStore.ListForks(ctx, repoID, storeFilter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, fork, enum.PermissionRepoView, true)
+			if err != nil {
+				continue
+			}
+
+			visible = append(visible, fork)
+		}
+
+		if len(forks) < forkListPageSize {
+			return visible, nil
+		}
+	}
+}
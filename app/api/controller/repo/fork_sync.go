@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gittypes "github.com/harness/gitness/git/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var (
+	errRepoNotFork = usererror.BadRequest("Repository is not a fork.")
+
+	errForkDiverged = usererror.Conflict(
+		"The default branch of the fork diverged from the upstream repository and can't be fast-forwarded.")
+)
+
+type SyncForkInput struct {
+	BypassRules bool `json:"bypass_rules"`
+}
+
+type SyncForkOutput struct {
+	// Branch is the default branch of the fork that got synchronized.
+	Branch string `json:"branch"`
+	// SHA is the commit the branch is pointing to after the synchronization.
+	SHA string `json:"sha"`
+	// Updated is false if the branch was already up to date.
+	Updated bool `json:"updated"`
+}
+
+// SyncFork fast-forwards the default branch of a fork to the default branch of its upstream repository.
+func (c *Controller) SyncFork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SyncForkInput,
+) (*SyncForkOutput, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if repo.ForkID == 0 {
+		return nil, nil, errRepoNotFork
+	}
+
+	upstream, err := c.repoStore.Find(ctx, repo.ForkID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, usererror.BadRequest("The upstream repository doesn't exist anymore.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView, true); err != nil {
+		return nil, nil, fmt.Errorf("access check of the upstream repository failed: %w", err)
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: upstream.DefaultBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get default branch of the upstream repository: %w", err)
+	}
+
+	newSHA := upstreamBranch.Branch.SHA
+	oldSHA := gittypes.NilSHA
+	refAction := protection.RefActionCreate
+
+	forkBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if err != nil && !gitnesserrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get default branch of the fork: %w", err)
+	}
+	if err == nil {
+		oldSHA = forkBranch.Branch.SHA
+		refAction = protection.RefActionUpdate
+	}
+
+	if oldSHA == newSHA {
+		return &SyncForkOutput{Branch: repo.DefaultBranch, SHA: newSHA, Updated: false}, nil, nil
+	}
+
+	if refAction == protection.RefActionUpdate {
+		// the objects of the upstream repository are available in the fork through the alternates.
+		ancestorOut, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if the fork can be fast-forwarded: %w", err)
+		}
+		if !ancestorOut.Ancestor {
+			return nil, nil, errForkDiverged
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{repo.DefaultBranch},
+		Commits:     c.newCommitsLister(repo, newSHA),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        repo.DefaultBranch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    newSHA,
+		OldValue:    oldSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update default branch of the fork: %w", err)
+	}
+
+	return &SyncForkOutput{Branch: repo.DefaultBranch, SHA: newSHA, Updated: true}, nil, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeForkInput(t *testing.T) {
+	publicUpstream := &types.Repository{Identifier: "upstream", IsPublic: true}
+	privateUpstream := &types.Repository{Identifier: "upstream", IsPublic: false}
+
+	tests := []struct {
+		name           string
+		publicEnabled  bool
+		upstream       *types.Repository
+		in             ForkInput
+		wantErr        error
+		wantIdentifier string
+	}{
+		{
+			name:           "private fork of private repo",
+			publicEnabled:  true,
+			upstream:       privateUpstream,
+			in:             ForkInput{ParentRef: "space"},
+			wantIdentifier: "upstream",
+		},
+		{
+			name:           "public fork of public repo",
+			publicEnabled:  true,
+			upstream:       publicUpstream,
+			in:             ForkInput{ParentRef: "space", Identifier: "fork", IsPublic: true},
+			wantIdentifier: "fork",
+		},
+		{
+			name:           "private fork of public repo",
+			publicEnabled:  true,
+			upstream:       publicUpstream,
+			in:             ForkInput{ParentRef: "space", Identifier: "fork"},
+			wantIdentifier: "fork",
+		},
+		{
+			name:          "public fork of private repo",
+			publicEnabled: true,
+			upstream:      privateUpstream,
+			in:            ForkInput{ParentRef: "space", IsPublic: true},
+			wantErr:       errPublicForkOfPrivateRepo,
+		},
+		{
+			name:          "public fork with public resource creation disabled",
+			publicEnabled: false,
+			upstream:      publicUpstream,
+			in:            ForkInput{ParentRef: "space", IsPublic: true},
+			wantErr:       errPublicRepoCreationDisabled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				publicResourceCreationEnabled: test.publicEnabled,
+				identifierCheck:               check.RepoIdentifierDefault,
+			}
+
+			in := test.in
+			err := c.sanitizeForkInput(&in, test.upstream)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.wantIdentifier, in.Identifier)
+		})
+	}
+}
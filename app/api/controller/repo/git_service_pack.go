@@ -34,6 +34,33 @@ func (c *Controller) GitServicePack(
 	gitProtocol string,
 	r io.Reader,
 	w io.Writer,
+) error {
+	return c.gitServicePack(ctx, session, repoRef, service, gitProtocol, true, r, w)
+}
+
+// GitSSHServicePack executes the service pack part of git's ssh protocol (receive-/upload-pack).
+// In contrast to the smart http protocol, the whole conversation happens within a single call.
+func (c *Controller) GitSSHServicePack(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	service enum.GitServiceType,
+	gitProtocol string,
+	r io.Reader,
+	w io.Writer,
+) error {
+	return c.gitServicePack(ctx, session, repoRef, service, gitProtocol, false, r, w)
+}
+
+func (c *Controller) gitServicePack(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	service enum.GitServiceType,
+	gitProtocol string,
+	statelessRPC bool,
+	r io.Reader,
+	w io.Writer,
 ) error {
 	isWriteOperation := false
 	permission := enum.PermissionRepoView
@@ -50,10 +77,11 @@ func (c *Controller) GitServicePack(
 
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
-		Service:     string(service),
-		Data:        r,
-		Options:     nil,
-		GitProtocol: gitProtocol,
+		Service:      string(service),
+		Data:         r,
+		Options:      nil,
+		GitProtocol:  gitProtocol,
+		StatelessRPC: statelessRPC,
 	}
 
 	// setup read/writeparams depending on whether it's a write operation
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/types"
@@ -35,6 +36,10 @@ type ImportInput struct {
 	ProviderRepo string            `json:"provider_repo"`
 
 	Pipelines importer.PipelineOption `json:"pipelines"`
+
+	// Mirror marks the repository as a mirror that is periodically synced with the remote repository.
+	Mirror     bool   `json:"mirror"`
+	MirrorCron string `json:"mirror_cron"`
 }
 
 // Import creates a new empty repository and starts git import to it from a remote repository.
@@ -65,6 +70,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			&session.Principal,
 			c.publicResourceCreationEnabled,
 		)
+		repo.IsMirror = in.Mirror
 
 		err = c.repoStore.Create(ctx, repo)
 		if err != nil {
@@ -76,6 +82,14 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			return fmt.Errorf("failed to start import repository job: %w", err)
 		}
 
+		if in.Mirror {
+			_, err = c.mirror.CreatePullMirror(ctx, &session.Principal, repo, remoteRepository.CloneURL,
+				types.MirrorCredentials{Username: provider.Username, Password: provider.Password}, in.MirrorCron)
+			if err != nil {
+				return fmt.Errorf("failed to create pull mirror: %w", err)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -83,6 +97,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 	}
 
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 
 	return repo, nil
 }
@@ -103,6 +118,22 @@ func (c *Controller) sanitizeImportInput(in *ImportInput) error {
 
 	if in.Pipelines == "" {
 		in.Pipelines = importer.PipelineOptionConvert
+		if in.Mirror {
+			in.Pipelines = importer.PipelineOptionIgnore
+		}
+	}
+
+	if in.Mirror {
+		// the pipeline conversion commits to the repository, but mirrors never get local commits.
+		if in.Pipelines == importer.PipelineOptionConvert {
+			return usererror.BadRequest("Pipelines can't be converted when importing a mirror repository.")
+		}
+
+		cron, err := c.mirror.SanitizeCron(in.MirrorCron)
+		if err != nil {
+			return err
+		}
+		in.MirrorCron = cron
 	}
 
 	return nil
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeImportInput(t *testing.T) {
+	c := &Controller{
+		identifierCheck: check.RepoIdentifierDefault,
+		mirror:          &mirror.Service{},
+	}
+
+	tests := []struct {
+		name          string
+		in            ImportInput
+		wantPipelines importer.PipelineOption
+		wantErr       bool
+	}{
+		{
+			name:          "default pipelines",
+			in:            ImportInput{ParentRef: "space", Identifier: "repo"},
+			wantPipelines: importer.PipelineOptionConvert,
+		},
+		{
+			name:          "ignore pipelines",
+			in:            ImportInput{ParentRef: "space", Identifier: "repo", Pipelines: importer.PipelineOptionIgnore},
+			wantPipelines: importer.PipelineOptionIgnore,
+		},
+		{
+			name:          "mirror default pipelines",
+			in:            ImportInput{ParentRef: "space", Identifier: "repo", Mirror: true, MirrorCron: "0 * * * *"},
+			wantPipelines: importer.PipelineOptionIgnore,
+		},
+		{
+			name: "mirror convert pipelines",
+			in: ImportInput{ParentRef: "space", Identifier: "repo", Mirror: true, MirrorCron: "0 * * * *",
+				Pipelines: importer.PipelineOptionConvert},
+			wantErr: true,
+		},
+		{
+			name:    "missing parent",
+			in:      ImportInput{Identifier: "repo"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := c.sanitizeImportInput(&in)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.wantPipelines, in.Pipelines)
+		})
+	}
+}
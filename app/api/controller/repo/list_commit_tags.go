@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -33,6 +34,8 @@ type CommitTag struct {
 	Message     string           `json:"message,omitempty"`
 	Tagger      *types.Signature `json:"tagger,omitempty"`
 	Commit      *types.Commit    `json:"commit,omitempty"`
+	// Verification is the verification result of the signature of the tag (nil if the tag isn't signed).
+	Verification *types.SignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -60,12 +63,32 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		return nil, err
 	}
 
+	// the signatures of all tags and their commits are verified at once,
+	// the tag signature is at index 2*i and the commit signature at index 2*i+1.
+	signedObjects := make([]gitsignature.SignedObject, 2*len(rpcOut.Tags))
+	for i := range rpcOut.Tags {
+		signedObjects[2*i] = gitsignature.TagSignedObject(&rpcOut.Tags[i])
+		if rpcOut.Tags[i].Commit != nil {
+			signedObjects[2*i+1] = gitsignature.CommitSignedObject(rpcOut.Tags[i].Commit)
+		}
+	}
+
+	verifications, err := c.signatureVerifier.VerifyMany(ctx, signedObjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signatures: %w", err)
+	}
+
 	tags := make([]CommitTag, len(rpcOut.Tags))
 	for i := range rpcOut.Tags {
 		tags[i], err = mapCommitTag(rpcOut.Tags[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+
+		tags[i].Verification = verifications[2*i]
+		if rpcOut.Tags[i].Commit != nil {
+			tags[i].Commit.Verification = verifications[2*i+1]
+		}
 	}
 
 	return tags, nil
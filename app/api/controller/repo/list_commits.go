@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -58,6 +59,16 @@ func (c *Controller) ListCommits(ctx context.Context,
 		return types.ListCommitResponse{}, err
 	}
 
+	signedObjects := make([]gitsignature.SignedObject, len(rpcOut.Commits))
+	for i := range rpcOut.Commits {
+		signedObjects[i] = gitsignature.CommitSignedObject(&rpcOut.Commits[i])
+	}
+
+	verifications, err := c.signatureVerifier.VerifyMany(ctx, signedObjects)
+	if err != nil {
+		return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	commits := make([]types.Commit, len(rpcOut.Commits))
 	for i := range rpcOut.Commits {
 		var commit *types.Commit
@@ -65,6 +76,8 @@ func (c *Controller) ListCommits(ctx context.Context,
 		if err != nil {
 			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
 		}
+
+		commit.Verification = verifications[i]
 		commits[i] = *commit
 	}
 
@@ -25,8 +25,9 @@ import (
 )
 
 type MergeCheck struct {
-	Mergeable     bool     `json:"mergeable"`
-	ConflictFiles []string `json:"conflict_files,omitempty"`
+	Mergeable       bool     `json:"mergeable"`
+	FastForwardable bool     `json:"fast_forwardable"`
+	ConflictFiles   []string `json:"conflict_files,omitempty"`
 }
 
 func (c *Controller) MergeCheck(
@@ -61,12 +62,14 @@ func (c *Controller) MergeCheck(
 	}
 	if len(mergeOutput.ConflictFiles) > 0 {
 		return MergeCheck{
-			Mergeable:     false,
-			ConflictFiles: mergeOutput.ConflictFiles,
+			Mergeable:       false,
+			FastForwardable: mergeOutput.FastForwardable,
+			ConflictFiles:   mergeOutput.ConflictFiles,
 		}, nil
 	}
 
 	return MergeCheck{
-		Mergeable: true,
+		Mergeable:       true,
+		FastForwardable: mergeOutput.FastForwardable,
 	}, nil
 }
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateMirrorInput is used for updating a mirror repository.
+// NOTE: If either the username or the password is provided, both credentials are replaced.
+type UpdateMirrorInput struct {
+	RemoteURL *string `json:"remote_url"`
+	Username  *string `json:"username"`
+	Password  *string `json:"password"`
+	Cron      *string `json:"cron"`
+}
+
+// UpdateMirror updates the remote repository, the credentials and the schedule of a mirror repository.
+func (c *Controller) UpdateMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UpdateMirrorInput,
+) (*types.PullMirror, error) {
+	if err := c.sanitizeUpdateMirrorInput(in); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repo.IsMirror {
+		return nil, errRepoNotMirror
+	}
+
+	mirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	var credentials *types.MirrorCredentials
+	if in.Username != nil || in.Password != nil {
+		credentials = &types.MirrorCredentials{}
+		if in.Username != nil {
+			credentials.Username = *in.Username
+		}
+		if in.Password != nil {
+			credentials.Password = *in.Password
+		}
+	}
+
+	err = c.mirror.UpdatePullMirror(ctx, mirror, in.RemoteURL, credentials, in.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+func (c *Controller) sanitizeUpdateMirrorInput(in *UpdateMirrorInput) error {
+	if in.RemoteURL != nil {
+		remoteURL, err := sanitizeMirrorRemoteURL(*in.RemoteURL)
+		if err != nil {
+			return err
+		}
+		in.RemoteURL = &remoteURL
+	}
+
+	if in.Cron != nil {
+		cron, err := c.mirror.SanitizeCron(*in.Cron)
+		if err != nil {
+			return err
+		}
+		in.Cron = &cron
+	}
+
+	return nil
+}
+
+// sanitizeMirrorRemoteURL verifies that the remote URL of a mirror is a http(s) URL without credentials.
+func sanitizeMirrorRemoteURL(remoteURL string) (string, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	u, err := url.Parse(remoteURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", usererror.BadRequest("The remote URL must be a valid http or https URL.")
+	}
+	if u.User != nil {
+		return "", usererror.BadRequest("The remote URL mustn't contain credentials.")
+	}
+
+	return remoteURL, nil
+}
@@ -72,6 +72,14 @@ func (c *Controller) PurgeNoAuth(
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
+	c.updateUpstreamNumForks(ctx, repo)
+
+	if repo.IsMirror {
+		if err := c.mirror.DeletePullMirror(ctx, repo.ID); err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to stop pull mirror synchronization")
+		}
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -92,6 +100,13 @@ func (c *Controller) DeleteGitRepository(
 		return nil
 	}
 
+	// forks share the git objects of the repository, they have to become independent before the deletion.
+	if repo.ID != 0 {
+		if err := c.dissociateForks(ctx, session, repo); err != nil {
+			return fmt.Errorf("failed to dissociate forks of repository %s: %w", repo.GitUID, err)
+		}
+	}
+
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return fmt.Errorf("failed to create RPC write params: %w", err)
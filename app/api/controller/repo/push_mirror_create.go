@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreatePushMirrorInput struct {
+	Identifier string   `json:"identifier"`
+	RemoteURL  string   `json:"remote_url"`
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	Refs       []string `json:"refs"`
+}
+
+// CreatePushMirror adds a new push mirror to a repository.
+// The references of the repository are pushed to the push mirror whenever a matching branch or tag changes.
+func (c *Controller) CreatePushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreatePushMirrorInput,
+) (*types.PushMirror, error) {
+	if err := c.sanitizeCreatePushMirrorInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := types.MirrorCredentials{
+		Username: in.Username,
+		Password: in.Password,
+	}
+
+	return c.mirror.CreatePushMirror(ctx, &session.Principal, repo, in.Identifier, in.RemoteURL, credentials, in.Refs)
+}
+
+func (c *Controller) sanitizeCreatePushMirrorInput(in *CreatePushMirrorInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	remoteURL, err := sanitizeMirrorRemoteURL(in.RemoteURL)
+	if err != nil {
+		return err
+	}
+	in.RemoteURL = remoteURL
+
+	return nil
+}
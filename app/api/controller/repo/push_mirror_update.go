@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdatePushMirrorInput is used for updating a push mirror.
+// NOTE: If either the username or the password is provided, both credentials are replaced.
+type UpdatePushMirrorInput struct {
+	RemoteURL *string   `json:"remote_url"`
+	Username  *string   `json:"username"`
+	Password  *string   `json:"password"`
+	Refs      *[]string `json:"refs"`
+}
+
+// UpdatePushMirror updates the remote repository, the credentials and the refs of a push mirror.
+func (c *Controller) UpdatePushMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *UpdatePushMirrorInput,
+) (*types.PushMirror, error) {
+	if in.RemoteURL != nil {
+		remoteURL, err := sanitizeMirrorRemoteURL(*in.RemoteURL)
+		if err != nil {
+			return nil, err
+		}
+		in.RemoteURL = &remoteURL
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	var credentials *types.MirrorCredentials
+	if in.Username != nil || in.Password != nil {
+		credentials = &types.MirrorCredentials{}
+		if in.Username != nil {
+			credentials.Username = *in.Username
+		}
+		if in.Password != nil {
+			credentials.Password = *in.Password
+		}
+	}
+
+	var refs []string
+	if in.Refs != nil {
+		// an empty list resets the refs to the defaults.
+		refs = append([]string{}, *in.Refs...)
+	}
+
+	err = c.mirror.UpdatePushMirror(ctx, mirror, in.RemoteURL, credentials, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return mirror, nil
+}
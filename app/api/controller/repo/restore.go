@@ -78,5 +78,7 @@ func (c *Controller) RestoreNoAuth(
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore the repo: %w", err)
 	}
+
+	c.updateUpstreamNumForks(ctx, repo)
 	return repo, nil
 }
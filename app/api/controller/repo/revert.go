@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RevertInput struct {
+	// Message optionally overwrites the commit message of the revert commit.
+	Message string `json:"message"`
+
+	// Branch is the branch the revert commit is added to (default: default branch of the repository).
+	Branch string `json:"branch"`
+	// NewBranch optionally specifies a new branch that is created from Branch for the result.
+	NewBranch string `json:"new_branch"`
+	// PullReq optionally opens a pull request from NewBranch into Branch.
+	PullReq *CommitOperationPullReqInput `json:"pull_req"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertInput) sanitize(repo *types.Repository, commitSHA string) error {
+	return sanitizeCommitOperationInput(repo, commitSHA, &in.Branch, &in.NewBranch, in.PullReq,
+		"Revert %s in %s")
+}
+
+// Revert adds a commit to a branch that reverses the changes introduced by a commit.
+func (c *Controller) Revert(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *RevertInput,
+) (*types.CommitOperationResponse, *types.MergeViolations, error) {
+	requiredPermission := enum.PermissionRepoPush
+	if in.DryRunRules {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, requiredPermission, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = in.sanitize(repo, commitSHA); err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := c.verifyCommitOperationRules(ctx, session, repo, in.Branch, in.NewBranch, in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if in.DryRunRules {
+		return &types.CommitOperationResponse{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	out, err := c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:   writeParams,
+		CommitSHA:     commitSHA,
+		BranchName:    in.Branch,
+		NewBranchName: in.NewBranch,
+		Message:       in.Message,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{ConflictFiles: out.ConflictFiles}, nil
+	}
+
+	response := newCommitOperationResponse(in.Branch, in.NewBranch, out.BaseSHA, out.CommitSHA, violations)
+
+	err = c.createCommitOperationPullReq(ctx, session, repoRef, writeParams, in.Branch, in.NewBranch, in.PullReq,
+		response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, nil, nil
+}
@@ -84,5 +84,7 @@ func (c *Controller) SoftDeleteNoAuth(
 		return fmt.Errorf("failed to soft delete repo from db: %w", err)
 	}
 
+	c.updateUpstreamNumForks(ctx, repo)
+
 	return nil
 }
@@ -16,10 +16,12 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -55,6 +57,12 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	if in.IsPublic != nil && *in.IsPublic && repo.ForkID != 0 {
+		if err = c.checkUpstreamIsPublic(ctx, repo.ForkID); err != nil {
+			return nil, err
+		}
+	}
+
 	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
 		// update values only if provided
 		if in.Description != nil {
@@ -76,6 +84,25 @@ func (c *Controller) Update(ctx context.Context,
 	return repo, nil
 }
 
+// checkUpstreamIsPublic returns an error if the upstream repository of a fork is private,
+// as a fork of a private repository can't be made public.
+func (c *Controller) checkUpstreamIsPublic(ctx context.Context, upstreamID int64) error {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the upstream repository got deleted.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if !upstream.IsPublic {
+		return errPublicForkOfPrivateRepo
+	}
+
+	return nil
+}
+
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 	if in.IsPublic != nil {
 		if *in.IsPublic && !c.publicResourceCreationEnabled {
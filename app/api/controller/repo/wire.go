@@ -16,14 +16,20 @@ package repo
 
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -58,10 +64,22 @@ func ProvideController(
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyService publickey.Service,
+	blobStore blob.Store,
+	signatureVerifier *gitsignature.Verifier,
+	signer *gitsignature.Signer,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	mirror *mirror.Service,
+	labelSvc *label.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
 		principalStore, ruleStore, principalInfoCache, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager, identifierCheck)
+		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager, identifierCheck,
+		deployKeyStore, publicKeyService, blobStore, signatureVerifier, signer, pullMirrorStore, pushMirrorStore, mirror, labelSvc,
+		pullreqCtrl)
 }
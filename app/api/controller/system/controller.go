@@ -17,6 +17,7 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
@@ -24,12 +25,18 @@ import (
 type Controller struct {
 	principalStore store.PrincipalStore
 	config         *types.Config
+	signer         *gitsignature.Signer
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	signer *gitsignature.Signer,
+) *Controller {
 	return &Controller{
 		principalStore: principalStore,
 		config:         config,
+		signer:         signer,
 	}
 }
 
@@ -41,3 +48,9 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 
 	return usrCount == 0 || c.config.UserSignupEnabled, nil
 }
+
+// SigningKey returns the public part of the key used to sign the git objects created by the server,
+// or nil if server-side signing is disabled.
+func (c *Controller) SigningKey() *types.SigningKey {
+	return c.signer.SigningKey()
+}
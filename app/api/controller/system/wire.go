@@ -15,6 +15,7 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -26,6 +27,10 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	signer *gitsignature.Signer,
+) *Controller {
+	return NewController(principalStore, config, signer)
 }
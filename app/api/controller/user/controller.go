@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -34,6 +35,9 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	publicKeyStore    store.PublicKeyStore
+	publicKeyService  publickey.Service
+	gpgKeyStore       store.GPGKeyStore
 }
 
 func NewController(
@@ -43,6 +47,9 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	publicKeyService publickey.Service,
+	gpgKeyStore store.GPGKeyStore,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +58,9 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		publicKeyStore:    publicKeyStore,
+		publicKeyService:  publicKeyService,
+		gpgKeyStore:       gpgKeyStore,
 	}
 }
 
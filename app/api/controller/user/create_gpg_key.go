@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitsignature"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateGPGKeyInput struct {
+	Content string `json:"content"`
+}
+
+// CreateGPGKey adds a new gpg public key to a user.
+func (c *Controller) CreateGPGKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreateGPGKeyInput,
+) (*types.GPGKey, error) {
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return nil, usererror.BadRequest("The gpg key content is required")
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	key, err := gitsignature.ParseGPGKey(in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// a key identifies exactly one signer - the same key can't be registered twice (even across users).
+	_, err = c.gpgKeyStore.FindByFingerprint(ctx, key.Fingerprint)
+	if err == nil {
+		return nil, gitsignature.ErrGPGKeyInUse
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find gpg key by fingerprint: %w", err)
+	}
+
+	gpgKey := &types.GPGKey{
+		PrincipalID: user.ID,
+		Created:     time.Now().UnixMilli(),
+		KeyID:       key.KeyID,
+		Fingerprint: key.Fingerprint,
+		Emails:      key.Emails,
+		Content:     key.Content,
+	}
+
+	if err = c.gpgKeyStore.Create(ctx, gpgKey); err != nil {
+		return nil, fmt.Errorf("failed to create gpg key: %w", err)
+	}
+
+	return gpgKey, nil
+}
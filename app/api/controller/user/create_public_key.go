@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publickey"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+type CreatePublicKeyInput struct {
+	Identifier string              `json:"identifier"`
+	Content    string              `json:"content"`
+	Usage      enum.PublicKeyUsage `json:"usage"`
+}
+
+// CreatePublicKey adds a new ssh public key to a user.
+func (c *Controller) CreatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	if err := c.sanitizeCreatePublicKeyInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	key, comment, err := publickey.ParseKey(in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := gossh.FingerprintSHA256(key)
+
+	// a key identifies exactly one owner - the same key can't be registered twice (even across users).
+	inUse, err := c.isPublicKeyInUse(ctx, fingerprint, in.Usage)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, publickey.ErrKeyInUse
+	}
+
+	publicKey := &types.PublicKey{
+		PrincipalID: user.ID,
+		Identifier:  in.Identifier,
+		Created:     time.Now().UnixMilli(),
+		LastUsed:    nil,
+		Fingerprint: fingerprint,
+		Content:     strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))),
+		Comment:     comment,
+		Type:        key.Type(),
+		Usage:       in.Usage,
+	}
+
+	if err = c.publicKeyStore.Create(ctx, publicKey); err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// isPublicKeyInUse checks whether the key is already registered for the provided usage.
+// Authentication keys also conflict with deploy keys, as both identify the owner during ssh authentication.
+func (c *Controller) isPublicKeyInUse(
+	ctx context.Context,
+	fingerprint string,
+	usage enum.PublicKeyUsage,
+) (bool, error) {
+	if usage == enum.PublicKeyUsageAuth {
+		inUse, err := c.publicKeyService.IsKeyInUse(ctx, fingerprint)
+		if err != nil {
+			return false, fmt.Errorf("failed to check whether public key is in use: %w", err)
+		}
+
+		return inUse, nil
+	}
+
+	_, err := c.publicKeyStore.FindByFingerprint(ctx, fingerprint, usage)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	return true, nil
+}
+
+func (c *Controller) sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	usage, ok := in.Usage.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid public key usage")
+	}
+	in.Usage = usage
+
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return usererror.BadRequest("The public key content is required")
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +36,9 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	publicKeyService publickey.Service,
+	gpgKeyStore store.GPGKeyStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +46,8 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		publicKeyStore,
+		publicKeyService,
+		gpgKeyStore)
 }
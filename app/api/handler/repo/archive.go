@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	gitenum "github.com/harness/gitness/git/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleArchive downloads an archive of the repository at the requested git ref.
+func HandleArchive(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		archiveName, err := request.GetRemainderFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		path := request.QueryParamOrDefault(r, request.QueryParamPath, "")
+
+		out, err := repoCtrl.Archive(ctx, session, repoRef, archiveName, path)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if out.Content == nil {
+			http.Redirect(w, r, out.SignedURL, http.StatusTemporaryRedirect)
+			return
+		}
+		defer func() {
+			if cErr := out.Content.Close(); cErr != nil {
+				log.Ctx(ctx).Warn().Err(cErr).Msg("failed to close archive after rendering")
+			}
+		}()
+
+		contentType := "application/gzip"
+		if out.Format == gitenum.ArchiveFormatZip {
+			contentType = "application/zip"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", out.FileName))
+		render.Reader(ctx, w, http.StatusOK, out.Content)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type defineSpaceLabelRequest struct {
+	spaceRequest
+	label.DefineInput
+}
+
+type updateSpaceLabelRequest struct {
+	spaceRequest
+	LabelID int64 `path:"label_id"`
+	label.UpdateInput
+}
+
+type deleteSpaceLabelRequest struct {
+	spaceRequest
+	LabelID int64 `path:"label_id"`
+}
+
+type defineRepoLabelRequest struct {
+	repoRequest
+	label.DefineInput
+}
+
+type updateRepoLabelRequest struct {
+	repoRequest
+	LabelID int64 `path:"label_id"`
+	label.UpdateInput
+}
+
+type deleteRepoLabelRequest struct {
+	repoRequest
+	LabelID int64 `path:"label_id"`
+}
+
+var queryParameterQueryLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the labels are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterInheritedLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInherited,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The boolean used to include labels defined in parent spaces."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func labelOperations(reflector *openapi3.Reflector) {
+	opSpaceDefine := openapi3.Operation{}
+	opSpaceDefine.WithTags("space")
+	opSpaceDefine.WithMapOfAnything(map[string]interface{}{"operationId": "defineSpaceLabel"})
+	_ = reflector.SetRequest(&opSpaceDefine, new(defineSpaceLabelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceDefine, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/labels", opSpaceDefine)
+
+	opSpaceUpdate := openapi3.Operation{}
+	opSpaceUpdate.WithTags("space")
+	opSpaceUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceLabel"})
+	_ = reflector.SetRequest(&opSpaceUpdate, new(updateSpaceLabelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSpaceUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/labels/{label_id}", opSpaceUpdate)
+
+	opSpaceDelete := openapi3.Operation{}
+	opSpaceDelete.WithTags("space")
+	opSpaceDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceLabel"})
+	_ = reflector.SetRequest(&opSpaceDelete, new(deleteSpaceLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSpaceDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSpaceDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/labels/{label_id}", opSpaceDelete)
+
+	opSpaceList := openapi3.Operation{}
+	opSpaceList.WithTags("space")
+	opSpaceList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceLabels"})
+	opSpaceList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opSpaceList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceList, new([]types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/labels", opSpaceList)
+
+	opRepoDefine := openapi3.Operation{}
+	opRepoDefine.WithTags("repository")
+	opRepoDefine.WithMapOfAnything(map[string]interface{}{"operationId": "defineRepoLabel"})
+	_ = reflector.SetRequest(&opRepoDefine, new(defineRepoLabelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoDefine, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/labels", opRepoDefine)
+
+	opRepoUpdate := openapi3.Operation{}
+	opRepoUpdate.WithTags("repository")
+	opRepoUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoLabel"})
+	_ = reflector.SetRequest(&opRepoUpdate, new(updateRepoLabelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/labels/{label_id}", opRepoUpdate)
+
+	opRepoDelete := openapi3.Operation{}
+	opRepoDelete.WithTags("repository")
+	opRepoDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoLabel"})
+	_ = reflector.SetRequest(&opRepoDelete, new(deleteRepoLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/labels/{label_id}", opRepoDelete)
+
+	opRepoList := openapi3.Operation{}
+	opRepoList.WithTags("repository")
+	opRepoList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoLabels"})
+	opRepoList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opRepoList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoList, new([]types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/labels", opRepoList)
+}
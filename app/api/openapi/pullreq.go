@@ -66,6 +66,51 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type revertPullReq struct {
+	pullReqRequest
+	pullreq.RevertInput
+}
+
+type updateBranchPullReq struct {
+	pullReqRequest
+	pullreq.UpdateBranchInput
+}
+
+type suggestionsApplyPullReq struct {
+	pullReqRequest
+	pullreq.SuggestionsApplyInput
+}
+
+type mergeQueueAddPullReq struct {
+	pullReqRequest
+	pullreq.MergeQueueAddInput
+}
+
+type autoMergeEnablePullReq struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
+type dependencyAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.DependencyAddInput
+}
+
+type dependencyDeletePullReqRequest struct {
+	pullReqRequest
+	ParentNumber int64 `path:"pullreq_parent_number"`
+}
+
+type labelAssignPullReqRequest struct {
+	pullReqRequest
+	pullreq.LabelAssignInput
+}
+
+type labelUnassignPullReqRequest struct {
+	pullReqRequest
+	LabelID int64 `path:"label_id"`
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -90,6 +135,26 @@ type commentStatusPullReqRequest struct {
 	pullreq.CommentStatusInput
 }
 
+type reactionAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.ReactionAddInput
+}
+
+type reactionDeletePullReqRequest struct {
+	pullReqRequest
+	Reaction enum.ReactionType `path:"reaction"`
+}
+
+type commentReactionAddPullReqRequest struct {
+	pullReqCommentRequest
+	pullreq.ReactionAddInput
+}
+
+type commentReactionDeletePullReqRequest struct {
+	pullReqCommentRequest
+	Reaction enum.ReactionType `path:"reaction"`
+}
+
 type reviewerListPullReqRequest struct {
 	pullReqRequest
 }
@@ -193,6 +258,39 @@ var queryParameterTargetBranchPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterBranchMergeQueue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Branch of the merge queue. Defaults to the default branch of the repository."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterLabelIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabelID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The IDs of labels that must all be assigned to the pull requests."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterCreatedByPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamCreatedBy,
@@ -312,6 +410,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq", createPullReq)
 
+	listTemplates := openapi3.Operation{}
+	listTemplates.WithTags("pullreq")
+	listTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTemplates"})
+	listTemplates.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&listTemplates, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listTemplates, new([]types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listTemplates, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listTemplates, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq-templates", listTemplates)
+
 	listPullReq := openapi3.Operation{}
 	listPullReq.WithTags("pullreq")
 	listPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReq"})
@@ -319,6 +430,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterLabelIDPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
@@ -425,6 +537,58 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/status", commentStatusPullReq)
 
+	reactionAddPullReq := openapi3.Operation{}
+	reactionAddPullReq.WithTags("pullreq")
+	reactionAddPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "reactionAddPullReq"})
+	_ = reflector.SetRequest(&reactionAddPullReq, new(reactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions", reactionAddPullReq)
+
+	reactionDeletePullReq := openapi3.Operation{}
+	reactionDeletePullReq.WithTags("pullreq")
+	reactionDeletePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "reactionDeletePullReq"})
+	_ = reflector.SetRequest(&reactionDeletePullReq, new(reactionDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions/{reaction}", reactionDeletePullReq)
+
+	commentReactionAddPullReq := openapi3.Operation{}
+	commentReactionAddPullReq.WithTags("pullreq")
+	commentReactionAddPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentReactionAddPullReq"})
+	_ = reflector.SetRequest(&commentReactionAddPullReq, new(commentReactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions", commentReactionAddPullReq)
+
+	commentReactionDeletePullReq := openapi3.Operation{}
+	commentReactionDeletePullReq.WithTags("pullreq")
+	commentReactionDeletePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentReactionDeletePullReq"})
+	_ = reflector.SetRequest(&commentReactionDeletePullReq, new(commentReactionDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new([]types.ReactionSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions/{reaction}", commentReactionDeletePullReq)
+
 	reviewerAdd := openapi3.Operation{}
 	reviewerAdd.WithTags("pullreq")
 	reviewerAdd.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerAddPullReq"})
@@ -488,6 +652,184 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	revertPullReqOp := openapi3.Operation{}
+	revertPullReqOp.WithTags("pullreq")
+	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
+	_ = reflector.SetRequest(&revertPullReqOp, new(revertPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
+
+	updateBranchPullReqOp := openapi3.Operation{}
+	updateBranchPullReqOp.WithTags("pullreq")
+	updateBranchPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "updateBranchPullReqOp"})
+	_ = reflector.SetRequest(&updateBranchPullReqOp, new(updateBranchPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(pullreq.UpdateBranchOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/update-branch", updateBranchPullReqOp)
+
+	suggestionsApplyPullReqOp := openapi3.Operation{}
+	suggestionsApplyPullReqOp.WithTags("pullreq")
+	suggestionsApplyPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "suggestionsApplyPullReqOp"})
+	_ = reflector.SetRequest(&suggestionsApplyPullReqOp, new(suggestionsApplyPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(pullreq.SuggestionsApplyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&suggestionsApplyPullReqOp, new(types.MergeViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/suggestions/apply", suggestionsApplyPullReqOp)
+
+	mergeQueueAddPullReqOp := openapi3.Operation{}
+	mergeQueueAddPullReqOp.WithTags("pullreq")
+	mergeQueueAddPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueAddPullReqOp"})
+	_ = reflector.SetRequest(&mergeQueueAddPullReqOp, new(mergeQueueAddPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(types.MergeQueueEntry), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&mergeQueueAddPullReqOp, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueAddPullReqOp)
+
+	mergeQueueRemovePullReqOp := openapi3.Operation{}
+	mergeQueueRemovePullReqOp.WithTags("pullreq")
+	mergeQueueRemovePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueRemovePullReqOp"})
+	_ = reflector.SetRequest(&mergeQueueRemovePullReqOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeQueueRemovePullReqOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&mergeQueueRemovePullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueRemovePullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueRemovePullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueRemovePullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueRemovePullReqOp)
+
+	mergeQueueListOp := openapi3.Operation{}
+	mergeQueueListOp.WithTags("pullreq")
+	mergeQueueListOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueList"})
+	mergeQueueListOp.WithParameters(queryParameterBranchMergeQueue)
+	_ = reflector.SetRequest(&mergeQueueListOp, new(listPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, []types.MergeQueueEntry{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/merge-queue", mergeQueueListOp)
+
+	autoMergeEnablePullReqOp := openapi3.Operation{}
+	autoMergeEnablePullReqOp.WithTags("pullreq")
+	autoMergeEnablePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeEnablePullReqOp"})
+	_ = reflector.SetRequest(&autoMergeEnablePullReqOp, new(autoMergeEnablePullReq), http.MethodPut)
+	_ = reflector.SetJSONResponse(&autoMergeEnablePullReqOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeEnablePullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeEnablePullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeEnablePullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeEnablePullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeEnablePullReqOp)
+
+	autoMergeDisablePullReqOp := openapi3.Operation{}
+	autoMergeDisablePullReqOp.WithTags("pullreq")
+	autoMergeDisablePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisablePullReqOp"})
+	_ = reflector.SetRequest(&autoMergeDisablePullReqOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&autoMergeDisablePullReqOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeDisablePullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeDisablePullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeDisablePullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeDisablePullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeDisablePullReqOp)
+
+	dependencyList := openapi3.Operation{}
+	dependencyList.WithTags("pullreq")
+	dependencyList.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyListPullReq"})
+	_ = reflector.SetRequest(&dependencyList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&dependencyList, new(types.PullReqDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", dependencyList)
+
+	dependencyAdd := openapi3.Operation{}
+	dependencyAdd.WithTags("pullreq")
+	dependencyAdd.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyAddPullReq"})
+	_ = reflector.SetRequest(&dependencyAdd, new(dependencyAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(types.PullReqDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", dependencyAdd)
+
+	dependencyDelete := openapi3.Operation{}
+	dependencyDelete.WithTags("pullreq")
+	dependencyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyDeletePullReq"})
+	_ = reflector.SetRequest(&dependencyDelete, new(dependencyDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&dependencyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies/{pullreq_parent_number}", dependencyDelete)
+
+	labelList := openapi3.Operation{}
+	labelList.WithTags("pullreq")
+	labelList.WithMapOfAnything(map[string]interface{}{"operationId": "labelListPullReq"})
+	_ = reflector.SetRequest(&labelList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&labelList, new([]types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", labelList)
+
+	labelAssign := openapi3.Operation{}
+	labelAssign.WithTags("pullreq")
+	labelAssign.WithMapOfAnything(map[string]interface{}{"operationId": "labelAssignPullReq"})
+	_ = reflector.SetRequest(&labelAssign, new(labelAssignPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&labelAssign, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", labelAssign)
+
+	labelUnassign := openapi3.Operation{}
+	labelUnassign.WithTags("pullreq")
+	labelUnassign.WithMapOfAnything(map[string]interface{}{"operationId": "labelUnassignPullReq"})
+	_ = reflector.SetRequest(&labelUnassign, new(labelUnassignPullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&labelUnassign, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels/{label_id}", labelUnassign)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -500,6 +842,32 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/commits", opListCommits)
 
+	opHistory := openapi3.Operation{}
+	opHistory.WithTags("pullreq")
+	opHistory.WithMapOfAnything(map[string]interface{}{"operationId": "historyPullReq"})
+	opHistory.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opHistory, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opHistory, []types.PullReqTextVersion{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/history", opHistory)
+
+	opCommentHistory := openapi3.Operation{}
+	opCommentHistory.WithTags("pullreq")
+	opCommentHistory.WithMapOfAnything(map[string]interface{}{"operationId": "commentHistoryPullReq"})
+	opCommentHistory.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opCommentHistory, new(pullReqCommentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCommentHistory, []types.PullReqTextVersion{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommentHistory, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommentHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/history", opCommentHistory)
+
 	opMetaData := openapi3.Operation{}
 	opMetaData.WithTags("pullreq")
 	opMetaData.WithMapOfAnything(map[string]interface{}{"operationId": "pullReqMetaData"})
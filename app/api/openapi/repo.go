@@ -64,6 +64,11 @@ type pathsDetailsRequest struct {
 	repo.PathsDetailsInput
 }
 
+type archiveRequest struct {
+	repoRequest
+	ArchiveName string `path:"archive_name"`
+}
+
 type getBlameRequest struct {
 	repoRequest
 	Path string `path:"path"`
@@ -230,6 +235,21 @@ var queryParameterPath = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArchivePath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Path to which the content of the archive should be restricted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(""),
+			},
+		},
+	},
+}
+
 var queryParameterSince = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSince,
@@ -631,6 +651,20 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
+	opArchive.WithParameters(queryParameterArchivePath)
+	_ = reflector.SetRequest(&opArchive, new(archiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opArchive, http.StatusOK, "")
+	_ = reflector.SetJSONResponse(&opArchive, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{archive_name}", opArchive)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -932,4 +966,228 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
+
+	opListDeployKeys := openapi3.Operation{}
+	opListDeployKeys.WithTags("repository")
+	opListDeployKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listDeployKeys"})
+	opListDeployKeys.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListDeployKeys, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, []types.DeployKey{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/deploy-keys", opListDeployKeys)
+
+	opCreateDeployKey := openapi3.Operation{}
+	opCreateDeployKey.WithTags("repository")
+	opCreateDeployKey.WithMapOfAnything(map[string]interface{}{"operationId": "createDeployKey"})
+	_ = reflector.SetRequest(&opCreateDeployKey, struct {
+		repoRequest
+		repo.CreateDeployKeyInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(types.DeployKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/deploy-keys", opCreateDeployKey)
+
+	opDeleteDeployKey := openapi3.Operation{}
+	opDeleteDeployKey.WithTags("repository")
+	opDeleteDeployKey.WithMapOfAnything(map[string]interface{}{"operationId": "deleteDeployKey"})
+	_ = reflector.SetRequest(&opDeleteDeployKey, struct {
+		repoRequest
+		Identifier string `path:"deploy_key_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/deploy-keys/{deploy_key_identifier}", opDeleteDeployKey)
+
+	opFindMirror := openapi3.Operation{}
+	opFindMirror.WithTags("repository")
+	opFindMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findMirror"})
+	_ = reflector.SetRequest(&opFindMirror, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opFindMirror)
+
+	opUpdateMirror := openapi3.Operation{}
+	opUpdateMirror.WithTags("repository")
+	opUpdateMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updateMirror"})
+	_ = reflector.SetRequest(&opUpdateMirror, struct {
+		repoRequest
+		repo.UpdateMirrorInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/mirror", opUpdateMirror)
+
+	opListPushMirrors := openapi3.Operation{}
+	opListPushMirrors.WithTags("repository")
+	opListPushMirrors.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrors"})
+	_ = reflector.SetRequest(&opListPushMirrors, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new([]types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirrors", opListPushMirrors)
+
+	opCreatePushMirror := openapi3.Operation{}
+	opCreatePushMirror.WithTags("repository")
+	opCreatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createPushMirror"})
+	_ = reflector.SetRequest(&opCreatePushMirror, struct {
+		repoRequest
+		repo.CreatePushMirrorInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirrors", opCreatePushMirror)
+
+	opFindPushMirror := openapi3.Operation{}
+	opFindPushMirror.WithTags("repository")
+	opFindPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findPushMirror"})
+	_ = reflector.SetRequest(&opFindPushMirror, struct {
+		repoRequest
+		Identifier string `path:"push_mirror_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/mirrors/{push_mirror_identifier}", opFindPushMirror)
+
+	opUpdatePushMirror := openapi3.Operation{}
+	opUpdatePushMirror.WithTags("repository")
+	opUpdatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updatePushMirror"})
+	_ = reflector.SetRequest(&opUpdatePushMirror, struct {
+		repoRequest
+		Identifier string `path:"push_mirror_identifier"`
+		repo.UpdatePushMirrorInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/mirrors/{push_mirror_identifier}", opUpdatePushMirror)
+
+	opDeletePushMirror := openapi3.Operation{}
+	opDeletePushMirror.WithTags("repository")
+	opDeletePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deletePushMirror"})
+	_ = reflector.SetRequest(&opDeletePushMirror, struct {
+		repoRequest
+		Identifier string `path:"push_mirror_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/mirrors/{push_mirror_identifier}", opDeletePushMirror)
+
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, struct {
+		repoRequest
+		repo.ForkInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncFork"})
+	_ = reflector.SetRequest(&opSyncFork, struct {
+		repoRequest
+		repo.SyncForkInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(repo.SyncForkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork/sync", opSyncFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []types.Repository{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, struct {
+		GetCommitRequest
+		repo.CherryPickInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.CommitOperationResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/{commit_sha}/cherry-pick", opCherryPick)
+
+	opRevert := openapi3.Operation{}
+	opRevert.WithTags("repository")
+	opRevert.WithMapOfAnything(map[string]interface{}{"operationId": "revertCommit"})
+	_ = reflector.SetRequest(&opRevert, struct {
+		GetCommitRequest
+		repo.RevertInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.CommitOperationResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/{commit_sha}/revert", opRevert)
 }
@@ -31,6 +31,36 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type createPublicKeyRequest struct {
+	user.CreatePublicKeyInput
+}
+
+type publicKeyRequest struct {
+	Identifier string `path:"public_key_identifier"`
+}
+
+type createGPGKeyRequest struct {
+	user.CreateGPGKeyInput
+}
+
+type gpgKeyRequest struct {
+	KeyID string `path:"gpg_key_id"`
+}
+
+var queryParameterQueryPublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the public keys are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -99,4 +129,59 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("user")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKeys"})
+	opListPublicKeys.WithParameters(queryParameterQueryPublicKey, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListPublicKeys, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListPublicKeys)
+
+	opCreatePublicKey := openapi3.Operation{}
+	opCreatePublicKey.WithTags("user")
+	opCreatePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreatePublicKey, new(createPublicKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreatePublicKey)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("user")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(publicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_identifier}", opDeletePublicKey)
+
+	opListGPGKeys := openapi3.Operation{}
+	opListGPGKeys.WithTags("user")
+	opListGPGKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listGPGKeys"})
+	_ = reflector.SetRequest(&opListGPGKeys, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListGPGKeys, new([]types.GPGKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListGPGKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/gpg-keys", opListGPGKeys)
+
+	opCreateGPGKey := openapi3.Operation{}
+	opCreateGPGKey.WithTags("user")
+	opCreateGPGKey.WithMapOfAnything(map[string]interface{}{"operationId": "createGPGKey"})
+	_ = reflector.SetRequest(&opCreateGPGKey, new(createGPGKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateGPGKey, new(types.GPGKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateGPGKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateGPGKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateGPGKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/gpg-keys", opCreateGPGKey)
+
+	opDeleteGPGKey := openapi3.Operation{}
+	opDeleteGPGKey.WithTags("user")
+	opDeleteGPGKey.WithMapOfAnything(map[string]interface{}{"operationId": "deleteGPGKey"})
+	_ = reflector.SetRequest(&opDeleteGPGKey, new(gpgKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteGPGKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteGPGKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteGPGKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/gpg-keys/{gpg_key_id}", opDeleteGPGKey)
 }
@@ -55,6 +55,12 @@ type (
 		adminUsersRequest
 		user.UpdateAdminInput
 	}
+
+	// adminUsersPublicKeyRequest is the request for public key specific admin user operations.
+	adminUsersPublicKeyRequest struct {
+		adminUsersRequest
+		publicKeyRequest
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -117,4 +123,24 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("admin")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "adminListPublicKeys"})
+	opListPublicKeys.WithParameters(queryParameterQueryPublicKey, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListPublicKeys, new(adminUsersRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/users/{user_uid}/keys", opListPublicKeys)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("admin")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(adminUsersPublicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/users/{user_uid}/keys/{public_key_identifier}", opDeletePublicKey)
 }
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamLFSObjectID = "lfs_object_id"
+	PathParamLFSLockID   = "lfs_lock_id"
+
+	QueryParamLFSLockID  = "id"
+	QueryParamLFSCursor  = "cursor"
+	QueryParamLFSRefspec = "refspec"
+)
+
+// GetLFSObjectIDFromPath extracts the lfs object id (oid) from the URL.
+func GetLFSObjectIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamLFSObjectID)
+}
+
+// GetLFSLockIDFromPath extracts the lfs lock id from the URL.
+func GetLFSLockIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamLFSLockID)
+}
+
+// ParseLFSLockFilter extracts the lfs lock filter from the url.
+// The cursor of the git lfs locks api is the page number.
+func ParseLFSLockFilter(r *http.Request) (*types.LFSLockFilter, error) {
+	page, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLFSCursor, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLFSLockID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LFSLockFilter{
+		Pagination: types.Pagination{
+			Page: int(page),
+			Size: int(limit),
+		},
+		ID:   id,
+		Path: QueryParamOrDefault(r, QueryParamPath, ""),
+		Ref:  QueryParamOrDefault(r, QueryParamLFSRefspec, ""),
+	}, nil
+}
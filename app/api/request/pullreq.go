@@ -25,6 +25,8 @@ const (
 	PathParamPullReqNumber    = "pullreq_number"
 	PathParamPullReqCommentID = "pullreq_comment_id"
 	PathParamReviewerID       = "pullreq_reviewer_id"
+	PathParamPullReqParentNum = "pullreq_parent_number"
+	PathParamReaction         = "reaction"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -35,10 +37,23 @@ func GetReviewerIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamReviewerID)
 }
 
+func GetPullReqParentNumberFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPullReqParentNum)
+}
+
 func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+func GetReactionFromPath(r *http.Request) (enum.ReactionType, error) {
+	reaction, err := PathParamOrError(r, PathParamReaction)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.ReactionType(reaction), nil
+}
+
 // ParseSortPullReq extracts the pull request sort parameter from the url.
 func ParseSortPullReq(r *http.Request) enum.PullReqSort {
 	result, _ := enum.PullReqSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
@@ -70,6 +85,11 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	// label_id is optional, can be provided multiple times
+	labelIDs, err := QueryParamListAsPositiveInt64(r, QueryParamLabelID)
+	if err != nil {
+		return nil, err
+	}
 	return &types.PullReqFilter{
 		Page:          ParsePage(r),
 		Size:          ParseLimit(r),
@@ -79,6 +99,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		SourceBranch:  r.URL.Query().Get("source_branch"),
 		TargetBranch:  r.URL.Query().Get("target_branch"),
 		States:        parsePullReqStates(r),
+		LabelIDs:      labelIDs,
 		Sort:          ParseSortPullReq(r),
 		Order:         ParseOrder(r),
 	}, nil
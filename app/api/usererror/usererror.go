@@ -70,6 +70,9 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrMirrorRepoCantBeModified is returned if a user tries to modify the content of a mirror repository.
+	ErrMirrorRepoCantBeModified = New(http.StatusBadRequest, "The content of a mirror repository can't be modified")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -76,6 +77,11 @@ func (a *MembershipAuthorizer) Check(
 		return true, nil // system admin can call any API
 	}
 
+	// deploy keys are restricted to a single repository, independent of any memberships.
+	if deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+		return checkWithDeployKeyMetadata(deployKeyMetadata, scope, resource, permission), nil
+	}
+
 	var spacePath string
 
 	//nolint:exhaustive // we want to fail on anything else
@@ -179,3 +185,29 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// checkWithDeployKeyMetadata checks access using the deploy key provided in the metadata.
+func checkWithDeployKeyMetadata(
+	deployKeyMetadata *auth.DeployKeyMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) bool {
+	if resource.Type != enum.ResourceTypeRepo {
+		return false
+	}
+
+	repoPath := paths.Concatenate(scope.SpacePath, resource.Identifier)
+	if !strings.EqualFold(repoPath, deployKeyMetadata.RepoPath) {
+		return false
+	}
+
+	switch permission {
+	case enum.PermissionRepoView:
+		return true
+	case enum.PermissionRepoPush:
+		return !deployKeyMetadata.ReadOnly
+	default:
+		return false
+	}
+}
@@ -46,3 +46,16 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// DeployKeyMetadata contains information about the deploy key that was used during auth.
+// Deploy keys are restricted to a single repository.
+type DeployKeyMetadata struct {
+	DeployKeyID int64
+	RepoID      int64
+	RepoPath    string
+	ReadOnly    bool
+}
+
+func (m *DeployKeyMetadata) ImpactsAuthorization() bool {
+	return true
+}
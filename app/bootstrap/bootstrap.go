@@ -52,6 +52,24 @@ func NewPipelineServiceSession() *auth.Session {
 	}
 }
 
+// deployKeyServicePrincipal is the principal that is used for
+// operations executed via repository deploy keys.
+var deployKeyServicePrincipal *types.Principal
+
+// NewDeployKeySession returns a session for the provided deploy key.
+// The session's principal is restricted to the repository of the deploy key.
+func NewDeployKeySession(deployKey *types.DeployKey, repoPath string) *auth.Session {
+	return &auth.Session{
+		Principal: *deployKeyServicePrincipal,
+		Metadata: &auth.DeployKeyMetadata{
+			DeployKeyID: deployKey.ID,
+			RepoID:      deployKey.RepoID,
+			RepoPath:    repoPath,
+			ReadOnly:    deployKey.ReadOnly,
+		},
+	}
+}
+
 // Bootstrap is an abstraction of a function that bootstraps a system.
 type Bootstrap func(context.Context) error
 
@@ -66,6 +84,10 @@ func System(config *types.Config, userCtrl *user.Controller,
 			return fmt.Errorf("failed to setup pipeline service: %w", err)
 		}
 
+		if err := DeployKeyService(ctx, config, serviceCtrl); err != nil {
+			return fmt.Errorf("failed to setup deploy key service: %w", err)
+		}
+
 		if err := AdminUser(ctx, config, userCtrl); err != nil {
 			return fmt.Errorf("failed to setup admin user: %w", err)
 		}
@@ -196,6 +218,36 @@ func PipelineService(
 	return nil
 }
 
+// DeployKeyService sets up the deploy key service principal that is used for
+// operations executed via repository deploy keys.
+func DeployKeyService(
+	ctx context.Context,
+	config *types.Config,
+	serviceCtrl *service.Controller,
+) error {
+	svc, err := serviceCtrl.FindNoAuth(ctx, config.Principal.DeployKey.UID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		svc, err = createServicePrincipal(
+			ctx,
+			serviceCtrl,
+			config.Principal.DeployKey.UID,
+			config.Principal.DeployKey.Email,
+			config.Principal.DeployKey.DisplayName,
+			false,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to setup deploy key service: %w", err)
+	}
+
+	deployKeyServicePrincipal = svc.ToPrincipal()
+
+	log.Ctx(ctx).Info().Msgf("Completed setup of deploy key service '%s' (id: %d).", svc.UID, svc.ID)
+
+	return nil
+}
+
 func createServicePrincipal(
 	ctx context.Context,
 	serviceCtrl *service.Controller,
@@ -29,6 +29,8 @@ type ReviewSubmittedPayload struct {
 	Base
 	ReviewerID int64
 	Decision   enum.PullReqReviewDecision
+	// CommentIDs are the IDs of the pending review comments published with the review.
+	CommentIDs []int64
 }
 
 func (r *Reporter) ReviewSubmitted(
@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitssh implements git's ssh protocol on top of the ssh server.
+package gitssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/ssh"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	extensionPrincipalID = "gitness-principal-id"
+	extensionDeployKeyID = "gitness-deploy-key-id"
+
+	envGitProtocol = "GIT_PROTOCOL"
+)
+
+var (
+	errUnsupportedCommand = errors.New("unsupported command")
+	errInvalidRepoPath    = errors.New("invalid repository path")
+)
+
+// Handler handles git operations executed over ssh.
+type Handler struct {
+	repoCtrl         *repo.Controller
+	publicKeyService publickey.Service
+	repoStore        store.RepoStore
+	principalStore   store.PrincipalStore
+	deployKeyStore   store.DeployKeyStore
+}
+
+func NewHandler(
+	repoCtrl *repo.Controller,
+	publicKeyService publickey.Service,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	deployKeyStore store.DeployKeyStore,
+) *Handler {
+	return &Handler{
+		repoCtrl:         repoCtrl,
+		publicKeyService: publicKeyService,
+		repoStore:        repoStore,
+		principalStore:   principalStore,
+		deployKeyStore:   deployKeyStore,
+	}
+}
+
+// HandlePublicKey authenticates the client using the provided public key.
+// The ssh username is ignored, the principal is identified by the public key alone.
+func (h *Handler) HandlePublicKey(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+	owner, err := h.publicKeyService.ValidateKey(context.Background(), key)
+	if err != nil {
+		log.Debug().Err(err).Msgf("ssh public key authentication failed for %s", conn.RemoteAddr())
+		return nil, fmt.Errorf("public key authentication failed: %w", err)
+	}
+
+	// only the owner is passed on, the session is built from it once a command is executed.
+	extensions := map[string]string{}
+	if owner.DeployKey != nil {
+		extensions[extensionDeployKeyID] = strconv.FormatInt(owner.DeployKey.ID, 10)
+	} else {
+		extensions[extensionPrincipalID] = strconv.FormatInt(owner.Principal.ID, 10)
+	}
+
+	return &gossh.Permissions{Extensions: extensions}, nil
+}
+
+// HandleCommand executes the git command requested by the client.
+func (h *Handler) HandleCommand(ctx context.Context, sshSession *ssh.Session) error {
+	session, err := h.getAuthSession(ctx, sshSession)
+	if err != nil {
+		h.writeError(ctx, sshSession, err)
+		return err
+	}
+
+	// add logger with ssh specific information to context.
+	log := log.Logger.With().
+		Str("ssh.remote_addr", sshSession.RemoteAddr.String()).
+		Str("ssh.principal_uid", session.Principal.UID).
+		Str("ssh.command", sshSession.Command).
+		Logger()
+	ctx = log.WithContext(ctx)
+
+	if sshSession.Command == "" {
+		name := session.Principal.UID
+		if deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+			name = deployKeyMetadata.RepoPath
+		}
+
+		_, _ = fmt.Fprintf(sshSession.Stderr,
+			"Hi %s! You've successfully authenticated, but shell access is not supported.\n",
+			name)
+		return nil
+	}
+
+	service, repoRef, err := parseCommand(sshSession.Command)
+	if err != nil {
+		h.writeError(ctx, sshSession, err)
+		return err
+	}
+
+	err = h.repoCtrl.GitSSHServicePack(
+		ctx,
+		session,
+		repoRef,
+		service,
+		sshSession.Env[envGitProtocol],
+		sshSession.Stdin,
+		sshSession.Stdout,
+	)
+	if err != nil {
+		h.writeError(ctx, sshSession, err)
+		return err
+	}
+
+	return nil
+}
+
+func (h *Handler) getAuthSession(ctx context.Context, sshSession *ssh.Session) (*auth.Session, error) {
+	if sshSession.Permissions == nil {
+		return nil, errors.New("ssh session is missing permissions")
+	}
+
+	if rawID, ok := sshSession.Permissions.Extensions[extensionDeployKeyID]; ok {
+		deployKeyID, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deploy key id of ssh session: %w", err)
+		}
+
+		deployKey, err := h.deployKeyStore.Find(ctx, deployKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find deploy key of ssh session: %w", err)
+		}
+
+		repo, err := h.repoStore.Find(ctx, deployKey.RepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository of deploy key: %w", err)
+		}
+
+		return bootstrap.NewDeployKeySession(deployKey, repo.Path), nil
+	}
+
+	principalID, err := strconv.ParseInt(sshSession.Permissions.Extensions[extensionPrincipalID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse principal id of ssh session: %w", err)
+	}
+
+	principal, err := h.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of ssh session: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, publickey.ErrPrincipalBlocked
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}, nil
+}
+
+// writeError writes a user friendly error to stderr of the client.
+func (h *Handler) writeError(ctx context.Context, sshSession *ssh.Session, err error) {
+	var msg string
+	switch {
+	case errors.Is(err, errUnsupportedCommand), errors.Is(err, errInvalidRepoPath):
+		msg = err.Error()
+	case errors.Is(err, publickey.ErrPrincipalBlocked), errors.Is(err, publickey.ErrUnknownKey):
+		msg = "access denied"
+	default:
+		msg = usererror.Translate(ctx, err).Message
+	}
+
+	_, _ = fmt.Fprintf(sshSession.Stderr, "fatal: %s\n", msg)
+}
+
+// parseCommand parses the command sent by the git client, e.g. "git-upload-pack 'space/repo.git'".
+func parseCommand(command string) (enum.GitServiceType, string, error) {
+	verb, arg, ok := strings.Cut(strings.TrimSpace(command), " ")
+	if !ok {
+		return "", "", errUnsupportedCommand
+	}
+
+	var service enum.GitServiceType
+	switch verb {
+	case "git-upload-pack":
+		service = enum.GitServiceTypeUploadPack
+	case "git-receive-pack":
+		service = enum.GitServiceTypeReceivePack
+	default:
+		return "", "", errUnsupportedCommand
+	}
+
+	repoPath := strings.TrimSpace(arg)
+	if len(repoPath) >= 2 && (repoPath[0] == '\'' || repoPath[0] == '"') && repoPath[len(repoPath)-1] == repoPath[0] {
+		repoPath = repoPath[1 : len(repoPath)-1]
+	}
+
+	repoPath = strings.TrimPrefix(repoPath, "/")
+	repoPath = strings.TrimSuffix(repoPath, "/")
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+	if repoPath == "" || strings.ContainsAny(repoPath, "'\"") {
+		return "", "", errInvalidRepoPath
+	}
+
+	return service, repoPath, nil
+}
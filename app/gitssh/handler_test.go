@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		wantService enum.GitServiceType
+		wantRepoRef string
+		wantErr     error
+	}{
+		{
+			name:        "upload pack",
+			command:     "git-upload-pack 'space/repo.git'",
+			wantService: enum.GitServiceTypeUploadPack,
+			wantRepoRef: "space/repo",
+		},
+		{
+			name:        "receive pack with leading slash",
+			command:     "git-receive-pack '/space/sub/repo.git'",
+			wantService: enum.GitServiceTypeReceivePack,
+			wantRepoRef: "space/sub/repo",
+		},
+		{
+			name:        "unquoted without suffix",
+			command:     "git-upload-pack space/repo",
+			wantService: enum.GitServiceTypeUploadPack,
+			wantRepoRef: "space/repo",
+		},
+		{
+			name:    "unsupported command",
+			command: "rm -rf /",
+			wantErr: errUnsupportedCommand,
+		},
+		{
+			name:    "missing path",
+			command: "git-upload-pack",
+			wantErr: errUnsupportedCommand,
+		},
+		{
+			name:    "invalid path",
+			command: "git-upload-pack '/.git'",
+			wantErr: errInvalidRepoPath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, repoRef, err := parseCommand(test.command)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.wantService, service)
+			require.Equal(t, test.wantRepoRef, repoRef)
+		})
+	}
+}
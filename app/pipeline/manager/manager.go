@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -148,6 +149,7 @@ type Manager struct {
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	Reporter *pullreqevents.Reporter
 }
 
 func New(
@@ -167,6 +169,7 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	reporter *pullreqevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -185,6 +188,7 @@ func New(
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
+		Reporter:         reporter,
 	}
 }
 
@@ -465,6 +469,7 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+		Reporter:    m.Reporter,
 	}
 	return t.do(noContext, stage)
 }
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -43,6 +44,7 @@ type teardown struct {
 	Repos       store.RepoStore
 	Steps       store.StepStore
 	Stages      store.StageStore
+	Reporter    *pullreqevents.Reporter
 }
 
 //nolint:gocognit // refactor if needed.
@@ -183,8 +185,16 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 	err = checks.Write(ctx, t.Checks, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
+		return nil
 	}
 
+	t.Reporter.CheckReported(ctx, &pullreqevents.CheckReportedPayload{
+		RepoID:     execution.RepoID,
+		SHA:        execution.After,
+		Identifier: pipeline.Identifier,
+		Status:     execution.Status.ConvertToCheckStatus(),
+	})
+
 	return nil
 }
 
@@ -239,6 +239,15 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleLabelList(spaceCtrl))
+				r.Post("/", handlerspace.HandleLabelDefine(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleLabelUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleLabelDelete(spaceCtrl))
+				})
+			})
 		})
 	})
 }
@@ -290,6 +299,10 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
+			})
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
@@ -301,6 +314,8 @@ func setupRepos(r chi.Router,
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
 					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
+					r.Post("/revert", handlerrepo.HandleRevert(repoCtrl))
 				})
 			})
 
@@ -346,6 +361,31 @@ func setupRepos(r chi.Router,
 			SetupUploads(r, uploadCtrl)
 
 			SetupRules(r, repoCtrl)
+
+			SetupLabels(r, repoCtrl)
+
+			SetupDeployKeys(r, repoCtrl)
+
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindMirror(repoCtrl))
+				r.Patch("/", handlerrepo.HandleUpdateMirror(repoCtrl))
+			})
+
+			r.Route("/fork", func(r chi.Router) {
+				r.Post("/", handlerrepo.HandleFork(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleSyncFork(repoCtrl))
+			})
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+
+			r.Route("/mirrors", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleListPushMirrors(repoCtrl))
+				r.Post("/", handlerrepo.HandleCreatePushMirror(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorIdentifier), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandleFindPushMirror(repoCtrl))
+					r.Patch("/", handlerrepo.HandleUpdatePushMirror(repoCtrl))
+					r.Delete("/", handlerrepo.HandleDeletePushMirror(repoCtrl))
+				})
+			})
 		})
 	})
 }
@@ -485,13 +525,17 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller) {
 }
 
 func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+	r.Get("/pullreq-templates", handlerpullreq.HandleTemplateList(pullreqCtrl))
+
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
+			r.Get("/history", handlerpullreq.HandleHistory(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
@@ -500,8 +544,18 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
 					r.Put("/status", handlerpullreq.HandleCommentStatus(pullreqCtrl))
+					r.Get("/history", handlerpullreq.HandleCommentHistory(pullreqCtrl))
+					r.Route("/reactions", func(r chi.Router) {
+						r.Put("/", handlerpullreq.HandleCommentReactionAdd(pullreqCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamReaction),
+							handlerpullreq.HandleCommentReactionDelete(pullreqCtrl))
+					})
 				})
 			})
+			r.Route("/reactions", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleReactionAdd(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamReaction), handlerpullreq.HandleReactionDelete(pullreqCtrl))
+			})
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
@@ -513,6 +567,31 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Post("/update-branch", handlerpullreq.HandleUpdateBranch(pullreqCtrl))
+			r.Post("/suggestions/apply", handlerpullreq.HandleSuggestionsApply(pullreqCtrl))
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/dependencies", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleDependencyList(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleDependencyAdd(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqParentNum), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleDependencyDelete(pullreqCtrl))
+				})
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleLabelList(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleLabelAssign(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleLabelUnassign(pullreqCtrl))
+				})
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -573,6 +652,27 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
+func SetupLabels(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/labels", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleLabelList(repoCtrl))
+		r.Post("/", handlerrepo.HandleLabelDefine(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+			r.Patch("/", handlerrepo.HandleLabelUpdate(repoCtrl))
+			r.Delete("/", handlerrepo.HandleLabelDelete(repoCtrl))
+		})
+	})
+}
+
+func SetupDeployKeys(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/deploy-keys", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleListDeployKeys(repoCtrl))
+		r.Post("/", handlerrepo.HandleCreateDeployKey(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamDeployKeyIdentifier), func(r chi.Router) {
+			r.Delete("/", handlerrepo.HandleDeleteDeployKey(repoCtrl))
+		})
+	})
+}
+
 func setupUser(r chi.Router, userCtrl *user.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
@@ -601,6 +701,28 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// SSH PUBLIC KEYS
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// GPG KEYS
+		r.Route("/gpg-keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListGPGKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreateGPGKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamGPGKeyID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeleteGPGKey(userCtrl))
+			})
+		})
 	})
 }
 
@@ -664,6 +786,12 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+
+				r.Route("/keys", func(r chi.Router) {
+					r.Get("/", users.HandleListPublicKeys(userCtrl))
+					r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
+						users.HandleDeletePublicKey(userCtrl))
+				})
 			})
 		})
 	})
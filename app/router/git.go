@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
@@ -44,6 +46,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -79,6 +82,22 @@ func NewGitHandler(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
+			// lfs
+			r.Route("/info/lfs", func(r chi.Router) {
+				r.Post("/objects/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+				r.Route(fmt.Sprintf("/objects/{%s}", request.PathParamLFSObjectID), func(r chi.Router) {
+					r.Get("/", handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+					r.Put("/", handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+				})
+				r.Route("/locks", func(r chi.Router) {
+					r.Get("/", handlerlfs.HandleListLocks(lfsCtrl, urlProvider))
+					r.Post("/", handlerlfs.HandleCreateLock(lfsCtrl, urlProvider))
+					r.Post("/verify", handlerlfs.HandleVerifyLocks(lfsCtrl, urlProvider))
+					r.Post(fmt.Sprintf("/{%s}/unlock", request.PathParamLFSLockID),
+						handlerlfs.HandleUnlock(lfsCtrl, urlProvider))
+				})
+			})
+
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler())
 			r.Get("/objects/info/alternates", stubGitHandler())
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/gitness/ssh"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// SSHServer is the ssh server for gitness.
+type SSHServer struct {
+	*ssh.Server
+}
+
+// loadOrGenerateHostKeys loads all existing host keys from the provided paths.
+// In case none of the host keys exist, a new ed25519 key is generated and stored at the first path.
+func loadOrGenerateHostKeys(gitRoot string, keyPaths []string) ([]gossh.Signer, error) {
+	if len(keyPaths) == 0 {
+		return nil, errors.New("no ssh server host keys configured")
+	}
+
+	paths := make([]string, len(keyPaths))
+	for i, path := range keyPaths {
+		paths[i] = path
+		if !filepath.IsAbs(path) {
+			paths[i] = filepath.Join(gitRoot, path)
+		}
+	}
+
+	signers := make([]gossh.Signer, 0, len(paths))
+	for _, path := range paths {
+		pemBytes, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ssh host key %q: %w", path, err)
+		}
+
+		signer, err := gossh.ParsePrivateKey(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh host key %q: %w", path, err)
+		}
+
+		signers = append(signers, signer)
+	}
+
+	if len(signers) > 0 {
+		return signers, nil
+	}
+
+	signer, err := generateHostKey(paths[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ssh host key: %w", err)
+	}
+
+	log.Info().Msgf("generated new ssh host key %q", paths[0])
+
+	return []gossh.Signer{signer}, nil
+}
+
+func generateHostKey(path string) (gossh.Signer, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+	}
+
+	block, err := gossh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create directory for host key: %w", err)
+	}
+
+	if err = os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write host key: %w", err)
+	}
+
+	return gossh.NewSignerFromKey(privateKey)
+}
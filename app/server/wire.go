@@ -15,15 +15,19 @@
 package server
 
 import (
+	"fmt"
+
+	"github.com/harness/gitness/app/gitssh"
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/http"
+	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
 
 // WireSet provides a wire set for this package.
-var WireSet = wire.NewSet(ProvideServer)
+var WireSet = wire.NewSet(ProvideServer, ProvideSSHServer)
 
 // ProvideServer provides a server instance.
 func ProvideServer(config *types.Config, router *router.Router) *Server {
@@ -38,3 +42,29 @@ func ProvideServer(config *types.Config, router *router.Router) *Server {
 		),
 	}
 }
+
+// ProvideSSHServer provides an ssh server instance.
+// NOTE: host keys are only loaded (or generated) in case the ssh server is enabled.
+func ProvideSSHServer(config *types.Config, handler *gitssh.Handler) (*SSHServer, error) {
+	if !config.SSH.Enable {
+		return &SSHServer{}, nil
+	}
+
+	hostKeys, err := loadOrGenerateHostKeys(config.Git.Root, config.SSH.ServerHostKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ssh host keys: %w", err)
+	}
+
+	return &SSHServer{
+		ssh.NewServer(
+			ssh.Config{
+				Host:              config.SSH.Host,
+				Port:              config.SSH.Port,
+				HostKeys:          hostKeys,
+				KeepAliveInterval: config.SSH.KeepAliveInterval,
+			},
+			handler.HandlePublicKey,
+			handler.HandleCommand,
+		),
+	}, nil
+}
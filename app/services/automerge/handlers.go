@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errAutoMergeDisabled = errors.New("auto-merge is not enabled")
+
+// handleBranchUpdated cancels the auto-merge intent if new commits are pushed
+// to the source branch of a pull request that has already been approved.
+// Otherwise, it tries to merge the pull request, as the new commits might fulfill the merge requirements.
+func (s *Service) handleBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen || pr.AutoMergeMethod == nil {
+		return nil
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	approved := false
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved {
+			approved = true
+			break
+		}
+	}
+
+	if !approved {
+		return s.tryMerge(ctx, pr)
+	}
+
+	return s.cancel(ctx, pr, "New commits have been pushed to the source branch.")
+}
+
+// handleReviewSubmitted tries to merge the pull request after it has been approved.
+func (s *Service) handleReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	return s.tryMerge(ctx, pr)
+}
+
+// handleCheckReported tries to merge all pull requests with auto-merge enabled
+// whose source branch points to the commit of a successfully completed status check.
+func (s *Service) handleCheckReported(ctx context.Context,
+	event *events.Event[*pullreqevents.CheckReportedPayload],
+) error {
+	if event.Payload.Status != enum.CheckStatusSuccess {
+		return nil
+	}
+
+	prs, err := s.pullreqStore.ListAutoMerge(ctx, event.Payload.RepoID, event.Payload.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto-merge enabled: %w", err)
+	}
+
+	for _, pr := range prs {
+		if err = s.tryMerge(ctx, pr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
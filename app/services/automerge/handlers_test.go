@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// pullReqStoreMock returns the pull request for every lookup. UpdateOptLock applies the mutation
+// on the latest version of the pull request, which might differ from the one returned by Find.
+type pullReqStoreMock struct {
+	store.PullReqStore
+	pr      *types.PullReq
+	latest  *types.PullReq
+	found   int
+	listed  int
+	updated int
+}
+
+func (s *pullReqStoreMock) Find(context.Context, int64) (*types.PullReq, error) {
+	s.found++
+	pr := *s.pr
+	return &pr, nil
+}
+
+func (s *pullReqStoreMock) ListAutoMerge(context.Context, int64, string) ([]*types.PullReq, error) {
+	s.listed++
+	pr := *s.pr
+	return []*types.PullReq{&pr}, nil
+}
+
+func (s *pullReqStoreMock) UpdateOptLock(
+	_ context.Context,
+	_ *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	s.updated++
+	pr := *s.latest
+	if err := mutateFn(&pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+var errRepoNotFound = errors.New("repository not found")
+
+// repoStoreMock fails every lookup, which stops the merge attempt right after it started.
+type repoStoreMock struct {
+	store.RepoStore
+	found int
+}
+
+func (s *repoStoreMock) Find(context.Context, int64) (*types.Repository, error) {
+	s.found++
+	return nil, errRepoNotFound
+}
+
+type reviewerStoreMock struct {
+	store.PullReqReviewerStore
+	reviewers []*types.PullReqReviewer
+}
+
+func (s reviewerStoreMock) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return s.reviewers, nil
+}
+
+func TestHandleBranchUpdated(t *testing.T) {
+	method := enum.MergeMethodSquash
+	principalID := int64(1)
+	autoMerge := types.PullReq{State: enum.PullReqStateOpen, AutoMergeMethod: &method, AutoMergeBy: &principalID}
+	approved := []*types.PullReqReviewer{
+		{ReviewDecision: enum.PullReqReviewDecisionChangeReq},
+		{ReviewDecision: enum.PullReqReviewDecisionApproved},
+	}
+
+	tests := []struct {
+		name        string
+		pr          types.PullReq
+		reviewers   []*types.PullReqReviewer
+		wantUpdated int
+		wantMerge   bool
+	}{
+		{
+			name:      "closed",
+			pr:        types.PullReq{State: enum.PullReqStateClosed, AutoMergeMethod: &method},
+			reviewers: approved,
+		},
+		{
+			name:      "auto-merge disabled",
+			pr:        types.PullReq{State: enum.PullReqStateOpen},
+			reviewers: approved,
+		},
+		{
+			name: "not approved",
+			pr:   autoMerge,
+			reviewers: []*types.PullReqReviewer{
+				{ReviewDecision: enum.PullReqReviewDecisionChangeReq},
+				{ReviewDecision: enum.PullReqReviewDecisionReviewed},
+			},
+			wantMerge: true,
+		},
+		{
+			name:        "approved",
+			pr:          autoMerge,
+			reviewers:   approved,
+			wantUpdated: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// auto-merge has been disabled in the meantime, so the cancellation stops at the update.
+			pullreqStore := &pullReqStoreMock{pr: &test.pr, latest: &types.PullReq{State: enum.PullReqStateOpen}}
+			repoStore := &repoStoreMock{}
+			s := &Service{
+				pullreqStore:  pullreqStore,
+				repoStore:     repoStore,
+				reviewerStore: reviewerStoreMock{reviewers: test.reviewers},
+			}
+
+			err := s.handleBranchUpdated(context.Background(), &events.Event[*pullreqevents.BranchUpdatedPayload]{
+				Payload: &pullreqevents.BranchUpdatedPayload{Base: pullreqevents.Base{PullReqID: 1}},
+			})
+			if err != nil && !errors.Is(err, errRepoNotFound) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.wantMerge != (repoStore.found > 0) {
+				t.Errorf("merge attempted: want=%t got=%t", test.wantMerge, repoStore.found > 0)
+			}
+			if pullreqStore.updated != test.wantUpdated {
+				t.Errorf("updated: want=%d got=%d", test.wantUpdated, pullreqStore.updated)
+			}
+		})
+	}
+}
+
+func TestHandleReviewSubmitted(t *testing.T) {
+	method := enum.MergeMethodSquash
+	principalID := int64(1)
+
+	tests := []struct {
+		name      string
+		decision  enum.PullReqReviewDecision
+		pr        types.PullReq
+		wantFound int
+	}{
+		{
+			name:     "changes requested",
+			decision: enum.PullReqReviewDecisionChangeReq,
+			pr:       types.PullReq{State: enum.PullReqStateOpen, AutoMergeMethod: &method, AutoMergeBy: &principalID},
+		},
+		{
+			name:      "approved closed",
+			decision:  enum.PullReqReviewDecisionApproved,
+			pr:        types.PullReq{State: enum.PullReqStateClosed, AutoMergeMethod: &method, AutoMergeBy: &principalID},
+			wantFound: 1,
+		},
+		{
+			name:     "approved draft",
+			decision: enum.PullReqReviewDecisionApproved,
+			pr: types.PullReq{State: enum.PullReqStateOpen, IsDraft: true,
+				AutoMergeMethod: &method, AutoMergeBy: &principalID},
+			wantFound: 1,
+		},
+		{
+			name:      "approved without auto-merge",
+			decision:  enum.PullReqReviewDecisionApproved,
+			pr:        types.PullReq{State: enum.PullReqStateOpen},
+			wantFound: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the repository and principal stores aren't set: the pull request must not be merged.
+			pullreqStore := &pullReqStoreMock{pr: &test.pr}
+			s := &Service{pullreqStore: pullreqStore}
+
+			err := s.handleReviewSubmitted(context.Background(), &events.Event[*pullreqevents.ReviewSubmittedPayload]{
+				Payload: &pullreqevents.ReviewSubmittedPayload{
+					Base:     pullreqevents.Base{PullReqID: 1},
+					Decision: test.decision,
+				},
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if pullreqStore.found != test.wantFound {
+				t.Errorf("found: want=%d got=%d", test.wantFound, pullreqStore.found)
+			}
+		})
+	}
+}
+
+func TestHandleCheckReported(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     enum.CheckStatus
+		wantListed int
+	}{
+		{
+			name:   "pending",
+			status: enum.CheckStatusPending,
+		},
+		{
+			name:   "failure",
+			status: enum.CheckStatusFailure,
+		},
+		{
+			name:       "success",
+			status:     enum.CheckStatusSuccess,
+			wantListed: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the listed pull request is a draft, so it must not be merged.
+			pullreqStore := &pullReqStoreMock{pr: &types.PullReq{State: enum.PullReqStateOpen, IsDraft: true}}
+			s := &Service{pullreqStore: pullreqStore}
+
+			err := s.handleCheckReported(context.Background(), &events.Event[*pullreqevents.CheckReportedPayload]{
+				Payload: &pullreqevents.CheckReportedPayload{RepoID: 1, SHA: "abc", Status: test.status},
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if pullreqStore.listed != test.wantListed {
+				t.Errorf("listed: want=%d got=%d", test.wantListed, pullreqStore.listed)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pullreqctrl "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const eventReaderGroupName = "gitness:automerge"
+
+// Service merges pull requests that have auto-merge enabled
+// as soon as all merge requirements of the target branch are satisfied.
+type Service struct {
+	pullreqCtrl    *pullreqctrl.Controller
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	pullreqStore   store.PullReqStore
+	reviewerStore  store.PullReqReviewerStore
+	activityStore  store.PullReqActivityStore
+	sseStreamer    sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqCtrl *pullreqctrl.Controller,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	activityStore store.PullReqActivityStore,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	service := &Service{
+		pullreqCtrl:    pullreqCtrl,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		pullreqStore:   pullreqStore,
+		reviewerStore:  reviewerStore,
+		activityStore:  activityStore,
+		sseStreamer:    sseStreamer,
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterBranchUpdated(service.handleBranchUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleReviewSubmitted)
+			_ = r.RegisterCheckReported(service.handleCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for auto-merge: %w", err)
+	}
+
+	return service, nil
+}
+
+// tryMerge attempts to merge the pull request on behalf of the principal that enabled auto-merge.
+// The merge operation verifies all protection rules, so the pull request is merged only if there are no violations.
+func (s *Service) tryMerge(ctx context.Context, pr *types.PullReq) error {
+	if pr.State != enum.PullReqStateOpen || pr.IsDraft || pr.AutoMergeMethod == nil || pr.AutoMergeBy == nil {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, *pr.AutoMergeBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that enabled auto-merge: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	out, violations, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &pullreqctrl.MergeInput{
+		Method:    *pr.AutoMergeMethod,
+		SourceSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		// The merge requirements (or the permissions of the principal) are evaluated again on the next event,
+		// so failures aren't retried here.
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Int64("pullreq_number", pr.Number).
+			Msg("failed to auto-merge pull request")
+		return nil
+	}
+	if violations != nil {
+		log.Ctx(ctx).Debug().
+			Int64("repo_id", repo.ID).
+			Int64("pullreq_number", pr.Number).
+			Msg("pull request is not ready to be auto-merged")
+		return nil
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repo.ID).
+		Int64("pullreq_number", pr.Number).
+		Str("merge_sha", out.SHA).
+		Msg("pull request auto-merged")
+
+	return nil
+}
+
+// cancel clears the auto-merge intent of the pull request and writes the pull request activity.
+func (s *Service) cancel(ctx context.Context, pr *types.PullReq, reason string) error {
+	method := pr.AutoMergeMethod
+
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.AutoMergeMethod == nil {
+			return errAutoMergeDisabled
+		}
+
+		pr.AutoMergeMethod = nil
+		pr.AutoMergeBy = nil
+		pr.ActivitySeq++
+		return nil
+	})
+	if errors.Is(err, errAutoMergeDisabled) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to clear auto-merge of pull request: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", pr.TargetRepoID).
+		Int64("pullreq_number", pr.Number).
+		Msgf("pull request auto-merge cancelled: %s", reason)
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{
+		Action: enum.AutoMergeActionCancelled,
+		Method: *method,
+		Reason: reason,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr,
+		bootstrap.NewSystemServiceSession().Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request auto-merge activity")
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find target repository")
+		return nil
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
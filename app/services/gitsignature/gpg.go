@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+var gpgSignatureArmorPrefix = []byte("-----BEGIN PGP SIGNATURE-----")
+
+var (
+	// ErrInvalidGPGKey is returned if a gpg key can't be parsed.
+	ErrInvalidGPGKey = usererror.New(http.StatusBadRequest, "The gpg key is invalid")
+
+	// ErrGPGKeyInUse is returned if a gpg key is already registered (for any user).
+	ErrGPGKeyInUse = usererror.New(http.StatusConflict, "The gpg key is already in use")
+
+	// ErrUnknownGPGSigner is returned if the key that created a gpg signature isn't part of the keyring.
+	ErrUnknownGPGSigner = errors.New("unknown gpg signer")
+)
+
+// GPGKey is a parsed armored gpg public key.
+type GPGKey struct {
+	KeyID       string
+	Fingerprint string
+	Emails      []string
+	Content     string
+}
+
+// IsGPGSignature returns true if the provided signature is an armored gpg signature.
+func IsGPGSignature(signature []byte) bool {
+	return bytes.HasPrefix(signature, gpgSignatureArmorPrefix)
+}
+
+// ParseGPGKey parses an armored gpg public key. The content must contain exactly one public key.
+func ParseGPGKey(content string) (*GPGKey, error) {
+	content = strings.TrimSpace(content)
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+	if err != nil {
+		return nil, ErrInvalidGPGKey
+	}
+
+	if len(entities) != 1 {
+		return nil, usererror.BadRequest("The gpg key content must contain exactly one public key")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return nil, usererror.BadRequest("The gpg key content must not contain a private key")
+	}
+
+	emails := make([]string, 0, len(entity.Identities))
+	for _, identity := range entity.Identities {
+		if identity.UserId != nil && identity.UserId.Email != "" {
+			emails = append(emails, identity.UserId.Email)
+		}
+	}
+	sort.Strings(emails)
+
+	return &GPGKey{
+		KeyID:       entity.PrimaryKey.KeyIdString(),
+		Fingerprint: strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+		Emails:      emails,
+		Content:     content,
+	}, nil
+}
+
+// ParseGPGKeyRing parses the provided armored gpg public keys into a single keyring.
+// Keys that can't be parsed are skipped.
+func ParseGPGKeyRing(contents ...string) openpgp.EntityList {
+	keyring := make(openpgp.EntityList, 0, len(contents))
+	for _, content := range contents {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+		if err != nil {
+			continue
+		}
+		keyring = append(keyring, entities...)
+	}
+
+	return keyring
+}
+
+// VerifyGPGSignature verifies the armored gpg signature of the provided content against the keyring.
+// It returns the key ID of the primary key of the signer.
+func VerifyGPGSignature(keyring openpgp.EntityList, signature []byte, content []byte) (string, error) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(
+		keyring,
+		bytes.NewReader(content),
+		bytes.NewReader(signature),
+		nil,
+	)
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		return "", ErrUnknownGPGSigner
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to verify gpg signature: %w", err)
+	}
+
+	return signer.PrimaryKey.KeyIdString(), nil
+}
+
+// GPGSignatureKeyID returns the ID of the key that created the armored gpg signature,
+// or an empty string if the signature doesn't contain the information.
+func GPGSignatureKeyID(signature []byte) string {
+	block, err := armor.Decode(bytes.NewReader(signature))
+	if err != nil {
+		return ""
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return ""
+	}
+
+	sig, ok := p.(*packet.Signature)
+	if !ok || sig.IssuerKeyId == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%016X", *sig.IssuerKeyId)
+}
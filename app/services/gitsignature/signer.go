@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git/signing"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var _ signing.Signer = (*Signer)(nil)
+
+// Signer signs the git objects created by the server (e.g. merge commits) with the instance signing key.
+type Signer struct {
+	key       *types.SigningKey
+	gpgEntity *openpgp.Entity
+	sshSigner gossh.Signer
+}
+
+// NewSigner returns a Signer using the instance signing key of the configured type.
+// If the key doesn't exist yet, it's imported from the configuration or generated,
+// and stored (encrypted) in the database.
+// NOTE: If signing is disabled, the returned signer is disabled as well.
+func NewSigner(
+	ctx context.Context,
+	config *types.Config,
+	signingKeyStore store.SigningKeyStore,
+	encrypter encrypt.Encrypter,
+) (*Signer, error) {
+	if !config.Git.Signing.Enabled {
+		return &Signer{}, nil
+	}
+
+	keyType := config.Git.Signing.Type
+	if keyType != enum.GitSignatureTypeGPG && keyType != enum.GitSignatureTypeSSH {
+		return nil, fmt.Errorf("unsupported signing key type %q", keyType)
+	}
+
+	key, err := signingKeyStore.FindByType(ctx, keyType)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		key, err = createSigningKey(ctx, config, signingKeyStore, encrypter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the %s signing key: %w", keyType, err)
+	}
+
+	privateKey, err := encrypter.Decrypt(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the signing key: %w", err)
+	}
+
+	signer := &Signer{key: key}
+
+	switch keyType {
+	case enum.GitSignatureTypeGPG:
+		signer.gpgEntity, err = parseGPGPrivateKey(privateKey)
+	case enum.GitSignatureTypeSSH:
+		signer.sshSigner, err = gossh.ParsePrivateKey([]byte(privateKey))
+	case enum.GitSignatureTypeUnknown:
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the signing key: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("git objects created by the server are signed with %s key %s", keyType, key.Fingerprint)
+
+	return signer, nil
+}
+
+// Enabled returns true if the signer signs the git objects created by the server.
+func (s *Signer) Enabled() bool {
+	return s.key != nil
+}
+
+// SigningKey returns the public information of the instance signing key, or nil if signing is disabled.
+func (s *Signer) SigningKey() *types.SigningKey {
+	return s.key
+}
+
+// Sign returns the armored signature of the provided content.
+func (s *Signer) Sign(_ context.Context, content []byte) ([]byte, error) {
+	switch {
+	case s.gpgEntity != nil:
+		buf := &bytes.Buffer{}
+		if err := openpgp.ArmoredDetachSign(buf, s.gpgEntity, bytes.NewReader(content), nil); err != nil {
+			return nil, fmt.Errorf("failed to create gpg signature: %w", err)
+		}
+		return buf.Bytes(), nil
+	case s.sshSigner != nil:
+		return SignSSH(s.sshSigner, content)
+	default:
+		return nil, errors.New("signing is disabled")
+	}
+}
+
+// createSigningKey imports the signing key from the configuration (or generates a new one) and stores it.
+func createSigningKey(
+	ctx context.Context,
+	config *types.Config,
+	signingKeyStore store.SigningKeyStore,
+	encrypter encrypt.Encrypter,
+) (*types.SigningKey, error) {
+	var (
+		key        *types.SigningKey
+		privateKey string
+		err        error
+	)
+
+	switch config.Git.Signing.Type {
+	case enum.GitSignatureTypeGPG:
+		key, privateKey, err = gpgSigningKey(config)
+	case enum.GitSignatureTypeSSH:
+		key, privateKey, err = sshSigningKey(config)
+	case enum.GitSignatureTypeUnknown:
+		err = fmt.Errorf("unsupported signing key type %q", config.Git.Signing.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key.Created = time.Now().UnixMilli()
+
+	key.PrivateKey, err = encrypter.Encrypt(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the signing key: %w", err)
+	}
+
+	err = signingKeyStore.Create(ctx, key)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the key got created concurrently by a different instance.
+		return signingKeyStore.FindByType(ctx, key.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store the signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// gpgSigningKey returns the gpg signing key from the configuration, or generates a new one.
+func gpgSigningKey(config *types.Config) (*types.SigningKey, string, error) {
+	var (
+		entity *openpgp.Entity
+		err    error
+	)
+
+	if config.Git.Signing.Key != "" {
+		entity, err = parseGPGPrivateKey(config.Git.Signing.Key)
+	} else {
+		entity, err = openpgp.NewEntity(
+			config.Principal.System.DisplayName,
+			"",
+			config.Principal.System.Email,
+			&packet.Config{Algorithm: packet.PubKeyAlgoEdDSA},
+		)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get gpg signing key: %w", err)
+	}
+
+	publicKey := &strings.Builder{}
+	if err = armorGPGEntity(publicKey, openpgp.PublicKeyType, entity.Serialize); err != nil {
+		return nil, "", err
+	}
+
+	privateKey := &strings.Builder{}
+	err = armorGPGEntity(privateKey, openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivate(w, nil)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &types.SigningKey{
+		Type:        enum.GitSignatureTypeGPG,
+		Fingerprint: strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+		PublicKey:   publicKey.String(),
+	}, privateKey.String(), nil
+}
+
+// sshSigningKey returns the ssh signing key from the configuration, or generates a new ed25519 key.
+func sshSigningKey(config *types.Config) (*types.SigningKey, string, error) {
+	privateKey := config.Git.Signing.Key
+	if privateKey == "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate ssh signing key: %w", err)
+		}
+
+		block, err := gossh.MarshalPrivateKey(key, config.Principal.System.Email)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal ssh signing key: %w", err)
+		}
+
+		privateKey = string(pem.EncodeToMemory(block))
+	}
+
+	signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse ssh signing key: %w", err)
+	}
+
+	return &types.SigningKey{
+		Type:        enum.GitSignatureTypeSSH,
+		Fingerprint: gossh.FingerprintSHA256(signer.PublicKey()),
+		PublicKey:   strings.TrimSpace(string(gossh.MarshalAuthorizedKey(signer.PublicKey()))),
+	}, privateKey, nil
+}
+
+func parseGPGPrivateKey(content string) (*openpgp.Entity, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read armored gpg key: %w", err)
+	}
+
+	if len(entities) != 1 {
+		return nil, errors.New("the gpg key content must contain exactly one key")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, errors.New("the gpg key content doesn't contain a private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		return nil, errors.New("the gpg private key mustn't be protected by a passphrase")
+	}
+
+	return entity, nil
+}
+
+func armorGPGEntity(w io.Writer, blockType string, serialize func(w io.Writer) error) error {
+	armored, err := armor.Encode(w, blockType, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create armor encoder: %w", err)
+	}
+
+	if err = serialize(armored); err != nil {
+		return fmt.Errorf("failed to serialize gpg key: %w", err)
+	}
+
+	if err = armored.Close(); err != nil {
+		return fmt.Errorf("failed to close armor encoder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// The format of ssh signatures is described in https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignaturePEMType   = "SSH SIGNATURE"
+	sshSignatureNamespace = "git"
+)
+
+var sshSignatureArmorPrefix = []byte("-----BEGIN " + sshSignaturePEMType + "-----")
+
+// ErrInvalidSSHSignature is returned if an ssh signature can't be parsed or doesn't match the signed content.
+var ErrInvalidSSHSignature = errors.New("invalid ssh signature")
+
+// sshSignature is the binary representation of an ssh signature (without the magic preamble).
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the binary representation of the data signed by an ssh signature (without the magic preamble).
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// IsSSHSignature returns true if the provided signature is an armored ssh signature.
+func IsSSHSignature(signature []byte) bool {
+	return bytes.HasPrefix(signature, sshSignatureArmorPrefix)
+}
+
+// VerifySSHSignature verifies the armored ssh signature of the provided content
+// and returns the public key that created the signature.
+// NOTE: The signature is verified against the public key embedded in the signature,
+// it's up to the caller to decide whether the key is trusted.
+func VerifySSHSignature(signature []byte, content []byte) (gossh.PublicKey, error) {
+	sig, publicKey, err := parseSSHSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("%w: unsupported hash algorithm %q", ErrInvalidSSHSignature, sig.HashAlgorithm)
+	}
+	_, _ = h.Write(content)
+
+	signedData := append([]byte(sshSignatureMagic), gossh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	blob := &gossh.Signature{}
+	if err = gossh.Unmarshal(sig.Signature, blob); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse signature blob: %w", ErrInvalidSSHSignature, err)
+	}
+
+	if err = publicKey.Verify(signedData, blob); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSSHSignature, err)
+	}
+
+	return publicKey, nil
+}
+
+// SignSSH creates an armored ssh signature of the provided content.
+func SignSSH(signer gossh.Signer, content []byte) ([]byte, error) {
+	const hashAlgorithm = "sha512"
+
+	h := sha512.Sum512(content)
+	signedData := append([]byte(sshSignatureMagic), gossh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          h[:],
+	})...)
+
+	var (
+		blob *gossh.Signature
+		err  error
+	)
+	if algorithmSigner, ok := signer.(gossh.AlgorithmSigner); ok && signer.PublicKey().Type() == gossh.KeyAlgoRSA {
+		// ssh signatures mustn't use the legacy rsa signature algorithm (sha1).
+		blob, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, gossh.KeyAlgoRSASHA512)
+	} else {
+		blob, err = signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign content: %w", err)
+	}
+
+	signature := append([]byte(sshSignatureMagic), gossh.Marshal(sshSignature{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: hashAlgorithm,
+		Signature:     gossh.Marshal(blob),
+	})...)
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  sshSignaturePEMType,
+		Bytes: signature,
+	}), nil
+}
+
+// ParseSSHSignaturePublicKey returns the public key embedded in the armored ssh signature.
+func ParseSSHSignaturePublicKey(signature []byte) (gossh.PublicKey, error) {
+	_, publicKey, err := parseSSHSignature(signature)
+	return publicKey, err
+}
+
+func parseSSHSignature(signature []byte) (*sshSignature, gossh.PublicKey, error) {
+	block, _ := pem.Decode(signature)
+	if block == nil || block.Type != sshSignaturePEMType {
+		return nil, nil, fmt.Errorf("%w: signature isn't armored", ErrInvalidSSHSignature)
+	}
+
+	data, ok := bytes.CutPrefix(block.Bytes, []byte(sshSignatureMagic))
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: missing magic preamble", ErrInvalidSSHSignature)
+	}
+
+	sig := &sshSignature{}
+	if err := gossh.Unmarshal(data, sig); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidSSHSignature, err)
+	}
+
+	if sig.Version != sshSignatureVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSSHSignature, sig.Version)
+	}
+
+	if sig.Namespace != sshSignatureNamespace {
+		return nil, nil, fmt.Errorf("%w: unexpected namespace %q", ErrInvalidSSHSignature, sig.Namespace)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to parse public key: %w", ErrInvalidSSHSignature, err)
+	}
+
+	return sig, publicKey, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"errors"
+	"testing"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	testSSHContent = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author max <max@mail.com> 1666401234 -0700\n" +
+		"committer max <max@mail.com> 1666401234 -0700\n" +
+		"\n" +
+		"some title\n"
+
+	testSSHSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAg2fjpEO/6qL+znAy9Kw8Ecmvy4Q
+ItFFxX6ffnILYYA9EAAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQC4LA9zMVHRD8TFGJTXYPUC0/0FEbYX7PH1sCG5AwEAXlyyeQlzf4F1l88tvpbPX4u
+8zCFtC0DYJUvVDaHXsSwk=
+-----END SSH SIGNATURE-----
+`
+
+	testSSHFingerprint = "SHA256:n/2/xt/5UdIUcmxXR5yp/53a8HDnAN7/E7/jI5NLnOM"
+)
+
+func TestVerifySSHSignature(t *testing.T) {
+	if !IsSSHSignature([]byte(testSSHSignature)) {
+		t.Fatal("expected signature to be detected as ssh signature")
+	}
+
+	publicKey, err := VerifySSHSignature([]byte(testSSHSignature), []byte(testSSHContent))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if fingerprint := gossh.FingerprintSHA256(publicKey); fingerprint != testSSHFingerprint {
+		t.Errorf("expected fingerprint %q, got %q", testSSHFingerprint, fingerprint)
+	}
+
+	_, err = VerifySSHSignature([]byte(testSSHSignature), []byte(testSSHContent+"tampered"))
+	if !errors.Is(err, ErrInvalidSSHSignature) {
+		t.Errorf("expected invalid signature error, got: %v", err)
+	}
+
+	_, err = VerifySSHSignature([]byte("not a signature"), []byte(testSSHContent))
+	if !errors.Is(err, ErrInvalidSSHSignature) {
+		t.Errorf("expected invalid signature error, got: %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Verifier verifies signatures of commits and tags against the keys registered by the principals.
+// A signature is verified if it's valid and was created with a key of the principal
+// whose email matches the email of the committer (or tagger).
+type Verifier struct {
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	publicKeyStore     store.PublicKeyStore
+	gpgKeyStore        store.GPGKeyStore
+}
+
+func NewVerifier(
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	publicKeyStore store.PublicKeyStore,
+	gpgKeyStore store.GPGKeyStore,
+) *Verifier {
+	return &Verifier{
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		publicKeyStore:     publicKeyStore,
+		gpgKeyStore:        gpgKeyStore,
+	}
+}
+
+// SignedObject is a signed commit or tag together with the email of the principal expected to have signed it.
+type SignedObject struct {
+	SignedData *git.SignedData
+	Email      string
+}
+
+// CommitSignedObject returns the signed object of a commit, signed by its committer.
+func CommitSignedObject(commit *git.Commit) SignedObject {
+	return SignedObject{
+		SignedData: commit.SignedData,
+		Email:      commit.Committer.Identity.Email,
+	}
+}
+
+// TagSignedObject returns the signed object of a tag, signed by its tagger.
+func TagSignedObject(tag *git.CommitTag) SignedObject {
+	if tag.Tagger == nil {
+		return SignedObject{}
+	}
+
+	return SignedObject{
+		SignedData: tag.SignedData,
+		Email:      tag.Tagger.Identity.Email,
+	}
+}
+
+// VerifyCommit returns the verification result of the signature of the commit (nil if the commit isn't signed).
+func (v *Verifier) VerifyCommit(ctx context.Context, commit *git.Commit) (*types.SignatureVerification, error) {
+	return v.Verify(ctx, commit.SignedData, commit.Committer.Identity.Email)
+}
+
+// VerifyTag returns the verification result of the signature of the tag (nil if the tag isn't signed).
+func (v *Verifier) VerifyTag(ctx context.Context, tag *git.CommitTag) (*types.SignatureVerification, error) {
+	obj := TagSignedObject(tag)
+	return v.Verify(ctx, obj.SignedData, obj.Email)
+}
+
+// Verify returns the verification result of the signature of a commit or tag
+// that's expected to be signed by the principal with the provided email.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	signedData *git.SignedData,
+	email string,
+) (*types.SignatureVerification, error) {
+	res, err := v.VerifyMany(ctx, []SignedObject{{SignedData: signedData, Email: email}})
+	if err != nil {
+		return nil, err
+	}
+
+	return res[0], nil
+}
+
+// VerifyMany returns the verification results of the signatures of multiple commits or tags
+// (nil for objects that aren't signed). The keys required for the verification are loaded at once.
+func (v *Verifier) VerifyMany(
+	ctx context.Context,
+	objects []SignedObject,
+) ([]*types.SignatureVerification, error) {
+	keys, err := v.loadKeys(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.SignatureVerification, len(objects))
+	for i := range objects {
+		res[i] = keys.verify(objects[i].SignedData, objects[i].Email)
+	}
+
+	return res, nil
+}
+
+// signatureKeys contains the keys and principals required to verify a set of signatures.
+type signatureKeys struct {
+	// principals maps the lower case email to the principal.
+	principals map[string]*types.Principal
+	// gpgKeyRings maps the principal id to the gpg keys of the principal.
+	gpgKeyRings map[int64]openpgp.EntityList
+	// sshKeys maps the fingerprint to the ssh signing key.
+	sshKeys map[string]*types.PublicKey
+	// sshKeyOwners maps the principal id to the owner of an ssh signing key.
+	sshKeyOwners map[int64]*types.PrincipalInfo
+}
+
+// loadKeys loads the keys of all signers of the provided objects.
+func (v *Verifier) loadKeys(ctx context.Context, objects []SignedObject) (*signatureKeys, error) {
+	keys := &signatureKeys{
+		principals:   map[string]*types.Principal{},
+		gpgKeyRings:  map[int64]openpgp.EntityList{},
+		sshKeys:      map[string]*types.PublicKey{},
+		sshKeyOwners: map[int64]*types.PrincipalInfo{},
+	}
+
+	var emails []string
+	var fingerprints []string
+	for i := range objects {
+		if objects[i].SignedData == nil {
+			continue
+		}
+
+		signature := objects[i].SignedData.Signature
+		switch {
+		case IsGPGSignature(signature):
+			if objects[i].Email != "" {
+				emails = append(emails, objects[i].Email)
+			}
+		case IsSSHSignature(signature):
+			publicKey, err := ParseSSHSignaturePublicKey(signature)
+			if err == nil {
+				fingerprints = append(fingerprints, gossh.FingerprintSHA256(publicKey))
+			}
+		}
+	}
+
+	if err := v.loadGPGKeys(ctx, keys, emails); err != nil {
+		return nil, err
+	}
+
+	if err := v.loadSSHKeys(ctx, keys, fingerprints); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (v *Verifier) loadGPGKeys(ctx context.Context, keys *signatureKeys, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	principals, err := v.principalStore.FindManyByEmail(ctx, emails)
+	if err != nil {
+		return fmt.Errorf("failed to find principals by email: %w", err)
+	}
+
+	if len(principals) == 0 {
+		return nil
+	}
+
+	principalIDs := make([]int64, len(principals))
+	for i, principal := range principals {
+		keys.principals[strings.ToLower(principal.Email)] = principal
+		principalIDs[i] = principal.ID
+	}
+
+	gpgKeys, err := v.gpgKeyStore.ListByPrincipalIDs(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list gpg keys: %w", err)
+	}
+
+	contents := map[int64][]string{}
+	for i := range gpgKeys {
+		contents[gpgKeys[i].PrincipalID] = append(contents[gpgKeys[i].PrincipalID], gpgKeys[i].Content)
+	}
+
+	for principalID, principalContents := range contents {
+		keys.gpgKeyRings[principalID] = ParseGPGKeyRing(principalContents...)
+	}
+
+	return nil
+}
+
+func (v *Verifier) loadSSHKeys(ctx context.Context, keys *signatureKeys, fingerprints []string) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	sshKeys, err := v.publicKeyStore.ListByFingerprints(ctx, fingerprints, enum.PublicKeyUsageSign)
+	if err != nil {
+		return fmt.Errorf("failed to list ssh signing keys: %w", err)
+	}
+
+	if len(sshKeys) == 0 {
+		return nil
+	}
+
+	ownerIDs := make([]int64, len(sshKeys))
+	for i := range sshKeys {
+		keys.sshKeys[sshKeys[i].Fingerprint] = &sshKeys[i]
+		ownerIDs[i] = sshKeys[i].PrincipalID
+	}
+
+	keys.sshKeyOwners, err = v.principalInfoCache.Map(ctx, ownerIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find principals of ssh signing keys: %w", err)
+	}
+
+	return nil
+}
+
+func (k *signatureKeys) verify(signedData *git.SignedData, email string) *types.SignatureVerification {
+	if signedData == nil {
+		return nil
+	}
+
+	switch {
+	case IsGPGSignature(signedData.Signature):
+		return k.verifyGPG(signedData, email)
+	case IsSSHSignature(signedData.Signature):
+		return k.verifySSH(signedData, email)
+	default:
+		// other signatures (e.g. x509) aren't supported.
+		return &types.SignatureVerification{
+			Status: enum.GitSignatureStatusUnverified,
+			Type:   enum.GitSignatureTypeUnknown,
+		}
+	}
+}
+
+func (k *signatureKeys) verifyGPG(signedData *git.SignedData, email string) *types.SignatureVerification {
+	res := &types.SignatureVerification{
+		Status: enum.GitSignatureStatusUnknownKey,
+		Type:   enum.GitSignatureTypeGPG,
+		KeyID:  GPGSignatureKeyID(signedData.Signature),
+	}
+
+	principal, ok := k.principals[strings.ToLower(email)]
+	if !ok {
+		return res
+	}
+
+	_, err := VerifyGPGSignature(k.gpgKeyRings[principal.ID], signedData.Signature, signedData.SignedContent)
+	if errors.Is(err, ErrUnknownGPGSigner) {
+		return res
+	}
+
+	res.Signer = principal.ToPrincipalInfo()
+	res.Status = enum.GitSignatureStatusVerified
+	if err != nil {
+		res.Status = enum.GitSignatureStatusUnverified
+	}
+
+	return res
+}
+
+func (k *signatureKeys) verifySSH(signedData *git.SignedData, email string) *types.SignatureVerification {
+	res := &types.SignatureVerification{
+		Status: enum.GitSignatureStatusUnverified,
+		Type:   enum.GitSignatureTypeSSH,
+	}
+
+	publicKey, err := ParseSSHSignaturePublicKey(signedData.Signature)
+	if err != nil {
+		return res
+	}
+
+	res.KeyID = gossh.FingerprintSHA256(publicKey)
+
+	key, ok := k.sshKeys[res.KeyID]
+	if !ok {
+		res.Status = enum.GitSignatureStatusUnknownKey
+		return res
+	}
+
+	signer, ok := k.sshKeyOwners[key.PrincipalID]
+	if !ok {
+		res.Status = enum.GitSignatureStatusUnknownKey
+		return res
+	}
+
+	res.Signer = signer
+
+	if _, err = VerifySSHSignature(signedData.Signature, signedData.SignedContent); err != nil {
+		return res
+	}
+
+	if strings.EqualFold(signer.Email, email) {
+		res.Status = enum.GitSignatureStatusVerified
+	}
+
+	return res
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git/signing"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideVerifier,
+	ProvideSigner,
+	ProvideObjectSigner,
+)
+
+func ProvideVerifier(
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	publicKeyStore store.PublicKeyStore,
+	gpgKeyStore store.GPGKeyStore,
+) *Verifier {
+	return NewVerifier(principalStore, principalInfoCache, publicKeyStore, gpgKeyStore)
+}
+
+func ProvideSigner(
+	ctx context.Context,
+	config *types.Config,
+	signingKeyStore store.SigningKeyStore,
+	encrypter encrypt.Encrypter,
+) (*Signer, error) {
+	return NewSigner(ctx, config, signingKeyStore, encrypter)
+}
+
+// ProvideObjectSigner provides the signer used by the git layer; nil if signing is disabled.
+func ProvideObjectSigner(signer *Signer) signing.Signer {
+	if !signer.Enabled() {
+		return nil
+	}
+	return signer
+}
@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxNameLength        = 64
+	maxDescriptionLength = 1024
+)
+
+var (
+	errLabelNotFound  = usererror.NotFound("Label not found")
+	errLabelDuplicate = usererror.Conflict("A label with the same name already exists")
+)
+
+// Service manages label definitions of spaces and repositories.
+// Labels defined in a space are inherited by all its sub-spaces and repositories.
+type Service struct {
+	labelStore store.LabelStore
+	spaceStore store.SpaceStore
+}
+
+func NewService(
+	labelStore store.LabelStore,
+	spaceStore store.SpaceStore,
+) *Service {
+	return &Service{
+		labelStore: labelStore,
+		spaceStore: spaceStore,
+	}
+}
+
+// DefineInput holds the data of a new label.
+type DefineInput struct {
+	Name        string          `json:"name"`
+	Color       enum.LabelColor `json:"color"`
+	Description string          `json:"description"`
+}
+
+// Sanitize validates and sanitizes the define label input data.
+func (in *DefineInput) Sanitize() error {
+	var err error
+
+	in.Name, err = sanitizeName(in.Name)
+	if err != nil {
+		return err
+	}
+
+	in.Color, err = sanitizeColor(in.Color)
+	if err != nil {
+		return err
+	}
+
+	in.Description, err = sanitizeDescription(in.Description)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInput holds the label data to be updated. Fields that are not set are left unchanged.
+type UpdateInput struct {
+	Name        *string          `json:"name"`
+	Color       *enum.LabelColor `json:"color"`
+	Description *string          `json:"description"`
+}
+
+// Sanitize validates and sanitizes the update label input data.
+func (in *UpdateInput) Sanitize() error {
+	if in.Name != nil {
+		name, err := sanitizeName(*in.Name)
+		if err != nil {
+			return err
+		}
+		in.Name = &name
+	}
+
+	if in.Color != nil {
+		color, err := sanitizeColor(*in.Color)
+		if err != nil {
+			return err
+		}
+		in.Color = &color
+	}
+
+	if in.Description != nil {
+		description, err := sanitizeDescription(*in.Description)
+		if err != nil {
+			return err
+		}
+		in.Description = &description
+	}
+
+	return nil
+}
+
+func sanitizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", usererror.BadRequest("Label name can't be empty")
+	}
+	if len(name) > maxNameLength {
+		return "", usererror.BadRequestf("Label name can't be longer than %d characters", maxNameLength)
+	}
+	return name, nil
+}
+
+func sanitizeColor(color enum.LabelColor) (enum.LabelColor, error) {
+	color, ok := color.Sanitize()
+	if !ok {
+		return "", usererror.BadRequest("Label color is invalid")
+	}
+	return color, nil
+}
+
+func sanitizeDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if len(description) > maxDescriptionLength {
+		return "", usererror.BadRequestf("Label description can't be longer than %d characters",
+			maxDescriptionLength)
+	}
+	return description, nil
+}
+
+// Define creates a new label in a space or in a repository.
+// Exactly one of spaceID and repoID must be provided.
+func (s *Service) Define(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *DefineInput,
+) (*types.Label, error) {
+	now := time.Now().UnixMilli()
+	label := &types.Label{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Name:        in.Name,
+		Color:       in.Color,
+		Description: in.Description,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+		UpdatedBy:   principalID,
+	}
+
+	err := s.labelStore.Create(ctx, label)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errLabelDuplicate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	return label, nil
+}
+
+// Find returns the label with the provided ID if it's defined directly in the provided space or repository.
+func (s *Service) Find(ctx context.Context, spaceID, repoID *int64, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errLabelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if !equalID(label.SpaceID, spaceID) || !equalID(label.RepoID, repoID) {
+		return nil, errLabelNotFound
+	}
+
+	return label, nil
+}
+
+// Update updates a label defined in the provided space or repository.
+func (s *Service) Update(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	labelID int64,
+	in *UpdateInput,
+) (*types.Label, error) {
+	label, err := s.Find(ctx, spaceID, repoID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name != nil {
+		label.Name = *in.Name
+	}
+	if in.Color != nil {
+		label.Color = *in.Color
+	}
+	if in.Description != nil {
+		label.Description = *in.Description
+	}
+
+	label.Updated = time.Now().UnixMilli()
+	label.UpdatedBy = principalID
+
+	err = s.labelStore.Update(ctx, label)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errLabelDuplicate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return label, nil
+}
+
+// Delete deletes a label defined in the provided space or repository.
+// The label is removed from all pull requests it's assigned to.
+func (s *Service) Delete(ctx context.Context, spaceID, repoID *int64, labelID int64) error {
+	label, err := s.Find(ctx, spaceID, repoID, labelID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.labelStore.Delete(ctx, label.ID); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
+
+// ListSpaceLabels returns labels defined in the space.
+// If the filter requests inherited labels, labels defined in all ancestor spaces are included.
+func (s *Service) ListSpaceLabels(
+	ctx context.Context,
+	space *types.Space,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	spaceIDs := []int64{space.ID}
+	if filter.Inherited {
+		var err error
+		spaceIDs, err = s.spaceWithAncestorIDs(ctx, space.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := s.labelStore.List(ctx, spaceIDs, nil, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// ListRepoLabels returns labels defined in the repository.
+// If the filter requests inherited labels, labels defined in all parent spaces are included.
+func (s *Service) ListRepoLabels(
+	ctx context.Context,
+	repo *types.Repository,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	var spaceIDs []int64
+	if filter.Inherited {
+		var err error
+		spaceIDs, err = s.spaceWithAncestorIDs(ctx, repo.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := s.labelStore.List(ctx, spaceIDs, &repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// FindForRepo returns the label with the provided ID if it's available in the repository,
+// meaning it's defined either in the repository itself or in one of its parent spaces.
+func (s *Service) FindForRepo(ctx context.Context, repo *types.Repository, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errLabelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if label.RepoID != nil {
+		if *label.RepoID != repo.ID {
+			return nil, errLabelNotFound
+		}
+		return label, nil
+	}
+
+	spaceIDs, err := s.spaceWithAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spaceID := range spaceIDs {
+		if label.SpaceID != nil && *label.SpaceID == spaceID {
+			return label, nil
+		}
+	}
+
+	return nil, errLabelNotFound
+}
+
+// spaceWithAncestorIDs returns the ID of the space followed by the IDs of all its ancestor spaces.
+func (s *Service) spaceWithAncestorIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	var ids []int64
+	for spaceID > 0 {
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		ids = append(ids, space.ID)
+		spaceID = space.ParentID
+	}
+
+	return ids, nil
+}
+
+func equalID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
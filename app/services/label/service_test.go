@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefineInputSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      DefineInput
+		want    DefineInput
+		wantErr bool
+	}{
+		{
+			name: "trimmed",
+			in:   DefineInput{Name: " bug ", Color: enum.LabelColorRed, Description: " Something is broken "},
+			want: DefineInput{Name: "bug", Color: enum.LabelColorRed, Description: "Something is broken"},
+		},
+		{
+			name: "default color",
+			in:   DefineInput{Name: "bug"},
+			want: DefineInput{Name: "bug", Color: enum.LabelColorGray},
+		},
+		{
+			name:    "empty name",
+			in:      DefineInput{Name: "  "},
+			wantErr: true,
+		},
+		{
+			name:    "name too long",
+			in:      DefineInput{Name: strings.Repeat("a", maxNameLength+1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid color",
+			in:      DefineInput{Name: "bug", Color: "brown"},
+			wantErr: true,
+		},
+		{
+			name:    "description too long",
+			in:      DefineInput{Name: "bug", Description: strings.Repeat("a", maxDescriptionLength+1)},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.Sanitize()
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, in)
+		})
+	}
+}
+
+func TestUpdateInputSanitize(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	colorPtr := func(c enum.LabelColor) *enum.LabelColor { return &c }
+
+	tests := []struct {
+		name    string
+		in      UpdateInput
+		want    UpdateInput
+		wantErr bool
+	}{
+		{
+			name: "nothing to update",
+			in:   UpdateInput{},
+			want: UpdateInput{},
+		},
+		{
+			name: "trimmed",
+			in:   UpdateInput{Name: ptr(" bug "), Description: ptr(" ")},
+			want: UpdateInput{Name: ptr("bug"), Description: ptr("")},
+		},
+		{
+			name: "color",
+			in:   UpdateInput{Color: colorPtr(enum.LabelColorBlue)},
+			want: UpdateInput{Color: colorPtr(enum.LabelColorBlue)},
+		},
+		{
+			name:    "empty name",
+			in:      UpdateInput{Name: ptr("")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid color",
+			in:      UpdateInput{Color: colorPtr("brown")},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.Sanitize()
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, in)
+		})
+	}
+}
+
+type labelStoreMock struct {
+	store.LabelStore
+	labels map[int64]*types.Label
+}
+
+func (s labelStoreMock) Find(_ context.Context, id int64) (*types.Label, error) {
+	label, ok := s.labels[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return label, nil
+}
+
+type spaceStoreMock struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s spaceStoreMock) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+func TestFindForRepo(t *testing.T) {
+	id := func(id int64) *int64 { return &id }
+
+	// space 1 is the root space, space 2 is its child and space 3 is a sibling of space 2.
+	spaces := map[int64]*types.Space{
+		1: {ID: 1},
+		2: {ID: 2, ParentID: 1},
+		3: {ID: 3, ParentID: 1},
+	}
+	labels := map[int64]*types.Label{
+		10: {ID: 10, RepoID: id(100)},
+		11: {ID: 11, RepoID: id(101)},
+		20: {ID: 20, SpaceID: id(1)},
+		21: {ID: 21, SpaceID: id(2)},
+		22: {ID: 22, SpaceID: id(3)},
+	}
+
+	service := NewService(labelStoreMock{labels: labels}, spaceStoreMock{spaces: spaces})
+	repo := &types.Repository{ID: 100, ParentID: 2}
+
+	tests := []struct {
+		name    string
+		labelID int64
+		wantErr error
+	}{
+		{
+			name:    "repo label",
+			labelID: 10,
+		},
+		{
+			name:    "label of another repo",
+			labelID: 11,
+			wantErr: errLabelNotFound,
+		},
+		{
+			name:    "root space label",
+			labelID: 20,
+		},
+		{
+			name:    "parent space label",
+			labelID: 21,
+		},
+		{
+			name:    "sibling space label",
+			labelID: 22,
+			wantErr: errLabelNotFound,
+		},
+		{
+			name:    "unknown label",
+			labelID: 99,
+			wantErr: errLabelNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			label, err := service.FindForRepo(context.Background(), repo, test.labelID)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.labelID, label.ID)
+		})
+	}
+}
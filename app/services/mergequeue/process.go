@@ -0,0 +1,577 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeMergeQueue        = "gitness:mergequeue:process"
+	jobCronMergeQueue        = "* * * * *" // Every minute.
+	jobMaxDurationMergeQueue = 10 * time.Minute
+
+	// processTimeout is the max time processing of the merge queue of a single branch can take.
+	processTimeout = 2 * time.Minute
+)
+
+var _ job.Handler = (*processHandler)(nil)
+
+// processHandler is the background job handler that advances all merge queues.
+type processHandler struct {
+	*Service
+}
+
+func (h *processHandler) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	entries, err := h.mergeQueueStore.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	var processed int
+	for len(entries) > 0 {
+		n := queueLen(entries)
+
+		repoID, branch := entries[0].RepoID, entries[0].Branch
+		entries = entries[n:]
+
+		if err := h.processQueue(ctx, repoID, branch); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repoID).
+				Str("branch", branch).
+				Msg("failed to process merge queue")
+			continue
+		}
+
+		processed++
+	}
+
+	return fmt.Sprintf("processed %d merge queues", processed), nil
+}
+
+// processQueue advances the merge queue of a branch:
+//   - Entries of pull requests that aren't open anymore are dropped.
+//   - Entries of pull requests with new commits, with merge conflicts or failed required status checks are ejected.
+//   - Entries that are about to be merged are ejected if the pull request doesn't satisfy the protection rules
+//     anymore or if it depends on a pull request that isn't merged.
+//   - Speculative merge commits are (re)created for entries that don't have one on top of the preceding entry.
+//   - The target branch is fast-forwarded to the speculative merge commit of every entry at the front of the
+//     queue whose required status checks succeeded.
+//
+//nolint:gocognit // refactor if needed.
+func (s *Service) processQueue(ctx context.Context, repoID int64, branch string) error {
+	unlock, err := s.lockPullReqs(ctx, repoID, processTimeout+30*time.Second)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, processTimeout)
+	defer cancel()
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.ListForBranch(ctx, repoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	writeParams, err := s.createSystemRPCWriteParams(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Name:       branch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	targetDeleted := errors.AsStatus(err) == errors.StatusNotFound
+	if err != nil && !targetDeleted {
+		return fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	targetSHA := targetRef.SHA
+	baseSHA := targetSHA
+	mergeable := true // all preceding entries have been merged
+
+	for i := range entries {
+		entry := &entries[i]
+
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen {
+			// the pull request has been closed or merged outside of the merge queue.
+			if err = s.removeEntry(ctx, repo, entry); err != nil {
+				return err
+			}
+			continue
+		}
+
+		reason := ejectReason(targetDeleted, pr, entry)
+
+		if reason == "" && (entry.MergeSHA == "" || entry.BaseSHA != baseSHA) {
+			reason, err = s.createMergeCommit(ctx, writeParams, pr, entry, baseSHA)
+			if err != nil {
+				return err
+			}
+		}
+
+		var ready bool
+		if reason == "" {
+			ready, reason, err = s.verifyChecks(ctx, repo, protectionRules, pr, entry)
+			if err != nil {
+				return err
+			}
+		}
+
+		if reason == "" && ready && mergeable {
+			// the reviews or the parent pull requests might have changed since the entry was added.
+			reason, err = s.verifyRules(ctx, repo, protectionRules, pr, entry)
+			if err != nil {
+				return err
+			}
+		}
+
+		if reason != "" {
+			if err = s.eject(ctx, repo, pr, entry, reason); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// the following entries are merged on top of this one.
+		baseSHA = entry.MergeSHA
+
+		if !ready || !mergeable {
+			mergeable = false
+			continue
+		}
+
+		if err = s.merge(ctx, writeParams, repo, pr, entry, targetSHA); err != nil {
+			return err
+		}
+
+		targetSHA = entry.MergeSHA
+	}
+
+	return nil
+}
+
+// queueLen returns the number of the leading entries that belong to the same merge queue.
+// The entries are ordered by repository and branch, so each merge queue is a continuous block.
+func queueLen(entries []types.MergeQueueEntry) int {
+	if len(entries) == 0 {
+		return 0
+	}
+
+	n := 1
+	for n < len(entries) && entries[n].RepoID == entries[0].RepoID && entries[n].Branch == entries[0].Branch {
+		n++
+	}
+
+	return n
+}
+
+// ejectReason returns the reason why the entry must be ejected from the merge queue
+// before a speculative merge commit is created for it, or an empty string if there's none.
+func ejectReason(targetDeleted bool, pr *types.PullReq, entry *types.MergeQueueEntry) string {
+	switch {
+	case targetDeleted:
+		return "The target branch has been deleted."
+	case pr.SourceSHA != entry.SourceSHA:
+		return "New commits have been pushed to the source branch."
+	case pr.IsDraft:
+		return "The pull request has been marked as draft."
+	default:
+		return ""
+	}
+}
+
+// createMergeCommit creates the speculative merge commit of the entry on top of the provided base commit.
+// If the pull request can't be merged, the function returns the reason.
+func (s *Service) createMergeCommit(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA string,
+) (string, error) {
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal who added the pull request to merge queue: %w", err)
+	}
+
+	var author, committer *git.Identity
+
+	switch entry.Method {
+	case enum.MergeMethodMerge:
+		author = identityFromPrincipalInfo(*principal.ToPrincipalInfo())
+		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+	case enum.MergeMethodSquash:
+		author = identityFromPrincipalInfo(pr.Author)
+		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+	case enum.MergeMethodRebase:
+		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+		committer = identityFromPrincipalInfo(*principal.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		// Not used: the merge queue doesn't support the fast-forward merge method.
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   writeParams,
+		BaseBranch:    baseSHA,
+		HeadBranch:    entry.SourceSHA,
+		Title:         entry.Title,
+		Message:       entry.Message,
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		RefType:       gitenum.RefTypeRaw,
+		RefName:       refName(entry.PullReqNumber),
+		Method:        gitenum.MergeMethod(entry.Method),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create speculative merge commit: %w", err)
+	}
+
+	if reason := conflictReason(mergeOutput); reason != "" {
+		return reason, nil
+	}
+
+	entry.BaseSHA = mergeOutput.BaseSHA
+	entry.MergeBaseSHA = mergeOutput.MergeBaseSHA
+	entry.MergeSHA = mergeOutput.MergeSHA
+
+	if err = s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return "", fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	return "", nil
+}
+
+// conflictReason returns the reason why the speculative merge commit couldn't be created,
+// or an empty string if the merge succeeded.
+func conflictReason(mergeOutput git.MergeOutput) string {
+	if mergeOutput.MergeSHA != "" && len(mergeOutput.ConflictFiles) == 0 {
+		return ""
+	}
+
+	reason := "The pull request has merge conflicts with the target branch" +
+		" or with the pull requests ahead of it in the merge queue."
+	if len(mergeOutput.ConflictFiles) > 0 {
+		reason += " Conflicting files: " + strings.Join(mergeOutput.ConflictFiles, ", ")
+	}
+
+	return reason
+}
+
+// verifyChecks verifies the status checks, required by the protection rules, of the speculative merge commit.
+// It returns true if all required checks succeeded. If any of them failed, the function returns the reason.
+func (s *Service) verifyChecks(
+	ctx context.Context,
+	repo *types.Repository,
+	protectionRules protection.Protection,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (bool, string, error) {
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to find principal who added the pull request to merge queue: %w", err)
+	}
+
+	requiredChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:       principal,
+		IsRepoOwner: false,
+		Repo:        repo,
+		PullReq:     pr,
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	ready, reason := evaluateChecks(requiredChecks, checkResults, entry.MergeSHA)
+
+	return ready, reason, nil
+}
+
+// evaluateChecks returns true if all required status checks of the speculative merge commit succeeded.
+// If any of them failed, the function returns the reason.
+func evaluateChecks(
+	requiredChecks protection.RequiredChecksOutput,
+	checkResults []types.CheckResult,
+	mergeSHA string,
+) (bool, string) {
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	// in the merge queue the bypassable checks are required too - no one can bypass them on behalf of the queue.
+	ready := true
+	for _, identifiers := range []map[string]struct{}{
+		requiredChecks.RequiredIdentifiers,
+		requiredChecks.BypassableIdentifiers,
+	} {
+		for identifier := range identifiers {
+			status, ok := statuses[identifier]
+			switch {
+			case !ok || !status.IsCompleted():
+				ready = false
+			case status != enum.CheckStatusSuccess:
+				return false, fmt.Sprintf("The required status check %q failed on the merge queue commit %s.",
+					identifier, mergeSHA)
+			}
+		}
+	}
+
+	return ready, ""
+}
+
+// verifyRules verifies the dependencies and the protection rules of the pull request as the principal
+// who added it to the merge queue. If the pull request can't be merged, the function returns the reason.
+// The required status checks are verified with the results of the speculative merge commit,
+// so only the checks that have already been evaluated by verifyChecks are taken into account.
+func (s *Service) verifyRules(
+	ctx context.Context,
+	repo *types.Repository,
+	protectionRules protection.Protection,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (string, error) {
+	parentIDs, err := s.dependencyStore.ListParentIDs(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list parent pull requests: %w", err)
+	}
+
+	for _, parentID := range parentIDs {
+		parent, err := s.pullreqStore.Find(ctx, parentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent pull request: %w", err)
+		}
+
+		if parent.State != enum.PullReqStateMerged {
+			return fmt.Sprintf("The pull request depends on pull request #%d which must be merged first.",
+				parent.Number), nil
+		}
+	}
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal who added the pull request to merge queue: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, repo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return "", fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        principal,
+		AllowBypass:  false,
+		IsRepoOwner:  false,
+		TargetRepo:   repo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       entry.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		Commits: protection.NewCommitLister(func(ctx context.Context, _ string) ([]git.CommitSignedData, error) {
+			out, err := s.git.ListCommitSignatures(ctx, git.ListCommitSignaturesParams{
+				ReadParams: git.CreateReadParams(repo),
+				GitREF:     pr.SourceSHA,
+				Exclude:    []string{pr.MergeBaseSHA},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit signatures: %w", err)
+			}
+			return out.Commits, nil
+		}),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violationsReason(violations), nil
+}
+
+// violationsReason returns the reason why the pull request can't be merged because of the critical
+// protection rule violations, or an empty string if there are none.
+func violationsReason(violations []types.RuleViolations) string {
+	var messages []string
+	for i := range violations {
+		if !violations[i].IsCritical() {
+			continue
+		}
+		for _, violation := range violations[i].Violations {
+			messages = append(messages, violation.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return ""
+	}
+
+	return "The pull request doesn't satisfy the protection rules anymore: " + strings.Join(messages, "; ") + "."
+}
+
+// merge fast-forwards the target branch to the speculative merge commit of the entry
+// and marks the pull request as merged.
+func (s *Service) merge(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	targetSHA string,
+) error {
+	err := s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        entry.Branch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    entry.MergeSHA,
+		OldValue:    targetSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward target branch: %w", err)
+	}
+
+	if err = s.removeEntry(ctx, repo, entry); err != nil {
+		return err
+	}
+
+	mergedBy := entry.CreatedBy
+	mergeMethod := entry.Method
+	mergeSHA := entry.MergeSHA
+	mergeTargetSHA := entry.BaseSHA
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := time.Now().UnixMilli()
+		pr.Merged = &nowMilli
+		pr.MergedBy = &mergedBy
+		pr.MergeMethod = &mergeMethod
+
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.MergeTargetSHA = &mergeTargetSHA
+		pr.MergeBaseSHA = entry.MergeBaseSHA
+		pr.MergeSHA = &mergeSHA
+		pr.MergeConflicts = nil
+		pr.MergeNotFastForwardable = false
+		pr.AutoMergeMethod = nil
+		pr.AutoMergeBy = nil
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("successfully merged PR %d from the merge queue", pr.Number)
+
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			Number:       pr.Number,
+			PrincipalID:  mergedBy,
+		},
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	})
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
+
+func identityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
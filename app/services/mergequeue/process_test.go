@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestQueueLen(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []types.MergeQueueEntry
+		want    int
+	}{
+		{
+			name:    "empty",
+			entries: nil,
+			want:    0,
+		},
+		{
+			name: "single-queue",
+			entries: []types.MergeQueueEntry{
+				{RepoID: 1, Branch: "main"},
+				{RepoID: 1, Branch: "main"},
+			},
+			want: 2,
+		},
+		{
+			name: "different-branch",
+			entries: []types.MergeQueueEntry{
+				{RepoID: 1, Branch: "main"},
+				{RepoID: 1, Branch: "main"},
+				{RepoID: 1, Branch: "release"},
+			},
+			want: 2,
+		},
+		{
+			name: "different-repo",
+			entries: []types.MergeQueueEntry{
+				{RepoID: 1, Branch: "main"},
+				{RepoID: 2, Branch: "main"},
+			},
+			want: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := queueLen(test.entries); got != test.want {
+				t.Errorf("want=%d got=%d", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEjectReason(t *testing.T) {
+	tests := []struct {
+		name          string
+		targetDeleted bool
+		pr            types.PullReq
+		entry         types.MergeQueueEntry
+		want          string
+	}{
+		{
+			name:  "unchanged",
+			pr:    types.PullReq{SourceSHA: "aaa"},
+			entry: types.MergeQueueEntry{SourceSHA: "aaa"},
+			want:  "",
+		},
+		{
+			name:          "target-deleted",
+			targetDeleted: true,
+			pr:            types.PullReq{SourceSHA: "bbb", IsDraft: true},
+			entry:         types.MergeQueueEntry{SourceSHA: "aaa"},
+			want:          "The target branch has been deleted.",
+		},
+		{
+			name:  "new-commits",
+			pr:    types.PullReq{SourceSHA: "bbb", IsDraft: true},
+			entry: types.MergeQueueEntry{SourceSHA: "aaa"},
+			want:  "New commits have been pushed to the source branch.",
+		},
+		{
+			name:  "draft",
+			pr:    types.PullReq{SourceSHA: "aaa", IsDraft: true},
+			entry: types.MergeQueueEntry{SourceSHA: "aaa"},
+			want:  "The pull request has been marked as draft.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ejectReason(test.targetDeleted, &test.pr, &test.entry)
+			if got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestConflictReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		mergeOutput git.MergeOutput
+		want        string
+	}{
+		{
+			name:        "merged",
+			mergeOutput: git.MergeOutput{MergeSHA: "ccc"},
+			want:        "",
+		},
+		{
+			name:        "no-merge-commit",
+			mergeOutput: git.MergeOutput{},
+			want: "The pull request has merge conflicts with the target branch" +
+				" or with the pull requests ahead of it in the merge queue.",
+		},
+		{
+			name:        "conflict-files",
+			mergeOutput: git.MergeOutput{ConflictFiles: []string{"a.txt", "b.txt"}},
+			want: "The pull request has merge conflicts with the target branch" +
+				" or with the pull requests ahead of it in the merge queue." +
+				" Conflicting files: a.txt, b.txt",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := conflictReason(test.mergeOutput); got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestViolationsReason(t *testing.T) {
+	violations := func(state enum.RuleState, bypassed bool, messages ...string) types.RuleViolations {
+		v := types.RuleViolations{Rule: types.RuleInfo{State: state}, Bypassed: bypassed}
+		for _, message := range messages {
+			v.Add("code", message)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name       string
+		violations []types.RuleViolations
+		want       string
+	}{
+		{
+			name:       "none",
+			violations: nil,
+			want:       "",
+		},
+		{
+			name: "not-critical",
+			violations: []types.RuleViolations{
+				violations(enum.RuleStateMonitor, false, "Reviewer A requested changes"),
+				violations(enum.RuleStateActive, true, "Reviewer B requested changes"),
+			},
+			want: "",
+		},
+		{
+			name: "critical",
+			violations: []types.RuleViolations{
+				violations(enum.RuleStateMonitor, false, "Reviewer A requested changes"),
+				violations(enum.RuleStateActive, false, "Reviewer B requested changes", "Insufficient approvals"),
+			},
+			want: "The pull request doesn't satisfy the protection rules anymore:" +
+				" Reviewer B requested changes; Insufficient approvals.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := violationsReason(test.violations); got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluateChecks(t *testing.T) {
+	const mergeSHA = "ccc"
+
+	tests := []struct {
+		name       string
+		required   []string
+		bypassable []string
+		results    []types.CheckResult
+		wantReady  bool
+		wantReason string
+	}{
+		{
+			name:      "no-required-checks",
+			results:   []types.CheckResult{{Identifier: "lint", Status: enum.CheckStatusFailure}},
+			wantReady: true,
+		},
+		{
+			name:     "all-succeeded",
+			required: []string{"build"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "lint", Status: enum.CheckStatusFailure},
+			},
+			wantReady: true,
+		},
+		{
+			name:      "missing",
+			required:  []string{"build"},
+			wantReady: false,
+		},
+		{
+			name:      "running",
+			required:  []string{"build"},
+			results:   []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusRunning}},
+			wantReady: false,
+		},
+		{
+			name:       "bypassable-pending",
+			required:   []string{"build"},
+			bypassable: []string{"test"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusPending},
+			},
+			wantReady: false,
+		},
+		{
+			name:       "failed",
+			required:   []string{"build"},
+			results:    []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusFailure}},
+			wantReason: `The required status check "build" failed on the merge queue commit ccc.`,
+		},
+		{
+			name:       "bypassable-error",
+			bypassable: []string{"test"},
+			results:    []types.CheckResult{{Identifier: "test", Status: enum.CheckStatusError}},
+			wantReason: `The required status check "test" failed on the merge queue commit ccc.`,
+		},
+	}
+
+	toSet := func(identifiers []string) map[string]struct{} {
+		m := make(map[string]struct{}, len(identifiers))
+		for _, identifier := range identifiers {
+			m[identifier] = struct{}{}
+		}
+		return m
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requiredChecks := protection.RequiredChecksOutput{
+				RequiredIdentifiers:   toSet(test.required),
+				BypassableIdentifiers: toSet(test.bypassable),
+			}
+
+			ready, reason := evaluateChecks(requiredChecks, test.results, mergeSHA)
+			if ready != test.wantReady {
+				t.Errorf("ready: want=%t got=%t", test.wantReady, ready)
+			}
+			if reason != test.wantReason {
+				t.Errorf("reason: want=%q got=%q", test.wantReason, reason)
+			}
+		})
+	}
+}
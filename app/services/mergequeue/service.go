@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// refPrefix is the prefix of the git references pointing to the speculative merge commits.
+const refPrefix = "refs/merge-queue/"
+
+// Service maintains the merge queues of branches. For every queued pull request it creates a speculative
+// merge commit on top of the previous entry, waits for the required status checks of the commit
+// and fast-forwards the target branch to it in the order the pull requests were added to the queue.
+type Service struct {
+	urlProvider       url.Provider
+	git               git.Interface
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	checkStore        store.CheckStore
+	mergeQueueStore   store.MergeQueueStore
+	reviewerStore     store.PullReqReviewerStore
+	dependencyStore   store.PullReqDependencyStore
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	eventReporter     *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+	mtxManager        lock.MutexManager
+}
+
+func NewService(
+	urlProvider url.Provider,
+	git git.Interface,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	reviewerStore store.PullReqReviewerStore,
+	dependencyStore store.PullReqDependencyStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	mtxManager lock.MutexManager,
+) *Service {
+	return &Service{
+		urlProvider:       urlProvider,
+		git:               git,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		checkStore:        checkStore,
+		mergeQueueStore:   mergeQueueStore,
+		reviewerStore:     reviewerStore,
+		dependencyStore:   dependencyStore,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		eventReporter:     eventReporter,
+		sseStreamer:       sseStreamer,
+		mtxManager:        mtxManager,
+	}
+}
+
+// Enqueue adds the pull request to the end of the merge queue of its target branch.
+// The caller is expected to hold the pull request lock of the repository and to have verified the merge rules.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	title string,
+	message string,
+) (*types.MergeQueueEntry, error) {
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:        pr.TargetRepoID,
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		Branch:        pr.TargetBranch,
+		SourceSHA:     pr.SourceSHA,
+		Method:        method,
+		Title:         title,
+		Message:       message,
+		Created:       now,
+		Updated:       now,
+		CreatedBy:     principal.ID,
+	}
+
+	if err := s.mergeQueueStore.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to create merge queue entry: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action: enum.MergeQueueActionAdded,
+		SHA:    entry.SourceSHA,
+	})
+
+	return entry, nil
+}
+
+// Dequeue removes the pull request from the merge queue of its target branch.
+// The caller is expected to hold the pull request lock of the repository.
+func (s *Service) Dequeue(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) error {
+	if err := s.removeEntry(ctx, repo, entry); err != nil {
+		return err
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action: enum.MergeQueueActionRemoved,
+		SHA:    entry.SourceSHA,
+	})
+
+	return nil
+}
+
+// eject removes the pull request that can't be merged from the merge queue
+// and explains the reason in the pull request's activity.
+func (s *Service) eject(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	reason string,
+) error {
+	if err := s.removeEntry(ctx, repo, entry); err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repo.ID).
+		Int64("pullreq_number", pr.Number).
+		Msgf("pull request ejected from the merge queue: %s", reason)
+
+	s.writeActivity(ctx, pr, bootstrap.NewSystemServiceSession().Principal.ID,
+		&types.PullRequestActivityPayloadMergeQueue{
+			Action: enum.MergeQueueActionEjected,
+			Reason: reason,
+			SHA:    entry.SourceSHA,
+		})
+
+	return nil
+}
+
+// removeEntry deletes the merge queue entry and the reference to its speculative merge commit.
+func (s *Service) removeEntry(ctx context.Context, repo *types.Repository, entry *types.MergeQueueEntry) error {
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	if entry.MergeSHA == "" {
+		return nil
+	}
+
+	writeParams, err := s.createSystemRPCWriteParams(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        refName(entry.PullReqNumber),
+		Type:        gitenum.RefTypeRaw,
+		NewValue:    "", // when NewValue is empty will delete the ref.
+		OldValue:    "", // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue reference")
+	}
+
+	return nil
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) {
+	updatedPR, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update activity sequence of pull request %d", pr.Number)
+		return
+	}
+
+	if _, err = s.activityStore.CreateWithPayload(ctx, updatedPR, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request merge queue activity")
+	}
+}
+
+// lockPullReqs acquires the same lock the pull request merge operation uses for the repository.
+func (s *Service) lockPullReqs(ctx context.Context, repoID int64, expiry time.Duration) (func(), error) {
+	key := fmt.Sprintf("%d/pulls", repoID)
+
+	mutex, err := s.mtxManager.NewMutex(
+		key,
+		lock.WithNamespace("repo"),
+		lock.WithExpiry(expiry),
+		lock.WithTimeoutFactor(4/expiry.Seconds()), // 4s
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new mutex for pull requests in repo %d: %w", repoID, err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to lock mutex for pull requests in repo %d: %w", repoID, err)
+	}
+
+	unlockFn := func() {
+		// always unlock independent of whether source context got canceled or not
+		ctx, cancel := context.WithTimeout(
+			contextutil.WithNewValues(context.Background(), ctx),
+			30*time.Second,
+		)
+		defer cancel()
+
+		if err := mutex.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to unlock pull requests")
+		}
+	}
+
+	return unlockFn, nil
+}
+
+// createSystemRPCWriteParams creates base write parameters for write operations.
+func (s *Service) createSystemRPCWriteParams(
+	ctx context.Context,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+func refName(pullreqNumber int64) string {
+	return refPrefix + strconv.FormatInt(pullreqNumber, 10)
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	urlProvider url.Provider,
+	git git.Interface,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	reviewerStore store.PullReqReviewerStore,
+	dependencyStore store.PullReqDependencyStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	mtxManager lock.MutexManager,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service := NewService(
+		urlProvider,
+		git,
+		repoStore,
+		principalStore,
+		pullreqStore,
+		activityStore,
+		checkStore,
+		mergeQueueStore,
+		reviewerStore,
+		dependencyStore,
+		protectionManager,
+		codeOwners,
+		eventReporter,
+		sseStreamer,
+		mtxManager,
+	)
+
+	err := executor.Register(jobTypeMergeQueue, &processHandler{Service: service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register merge queue job handler: %w", err)
+	}
+
+	err = scheduler.AddRecurring(ctx, jobTypeMergeQueue, jobTypeMergeQueue, jobCronMergeQueue,
+		jobMaxDurationMergeQueue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule merge queue job: %w", err)
+	}
+
+	return service, nil
+}
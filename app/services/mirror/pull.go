@@ -0,0 +1,345 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePullMirror  = "repository_pull_mirror"
+	jobIDPrefixPull    = "pull-mirror-repo-"
+	refPrefixBranch    = "refs/heads/"
+	refPrefixTag       = "refs/tags/"
+	redactedCredential = "*****"
+)
+
+var _ job.Handler = (*pullMirrorHandler)(nil)
+
+// pullMirrorHandler is the background job handler that synchronizes a pull mirror with its remote repository.
+type pullMirrorHandler struct {
+	*Service
+}
+
+func pullMirrorJobID(repoID int64) string {
+	return jobIDPrefixPull + jobData(repoID)
+}
+
+// CreatePullMirror marks the repository as a mirror of the remote repository
+// and schedules its periodic synchronization.
+// NOTE: The initial content of the repository is expected to be imported separately.
+func (s *Service) CreatePullMirror(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	remoteURL string,
+	credentials types.MirrorCredentials,
+	cron string,
+) (*types.PullMirror, error) {
+	cron, err := s.SanitizeCron(cron)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedCredentials, err := s.encryptCredentials(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.PullMirror{
+		RepoID:        repo.ID,
+		Created:       now,
+		Updated:       now,
+		CreatedBy:     principal.ID,
+		RemoteURL:     remoteURL,
+		Credentials:   encryptedCredentials,
+		Cron:          cron,
+		LastSyncState: enum.MirrorSyncStatePending,
+	}
+
+	if err = s.pullMirrorStore.Create(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to create pull mirror: %w", err)
+	}
+
+	if err = s.schedulePullMirror(ctx, mirror); err != nil {
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+// UpdatePullMirror updates the remote URL, the credentials and the schedule of a pull mirror.
+// NOTE: The credentials are only updated if the remote URL or any of the credentials are provided.
+func (s *Service) UpdatePullMirror(
+	ctx context.Context,
+	mirror *types.PullMirror,
+	remoteURL *string,
+	credentials *types.MirrorCredentials,
+	cron *string,
+) error {
+	if remoteURL != nil {
+		mirror.RemoteURL = *remoteURL
+	}
+
+	if credentials != nil {
+		encryptedCredentials, err := s.encryptCredentials(*credentials)
+		if err != nil {
+			return err
+		}
+		mirror.Credentials = encryptedCredentials
+	}
+
+	if cron != nil {
+		sanitizedCron, err := s.SanitizeCron(*cron)
+		if err != nil {
+			return err
+		}
+		mirror.Cron = sanitizedCron
+	}
+
+	if err := s.pullMirrorStore.Update(ctx, mirror); err != nil {
+		return fmt.Errorf("failed to update pull mirror: %w", err)
+	}
+
+	return s.schedulePullMirror(ctx, mirror)
+}
+
+// DeletePullMirror stops the periodic synchronization of the pull mirror.
+func (s *Service) DeletePullMirror(ctx context.Context, repoID int64) error {
+	if err := s.scheduler.PurgeJobByUID(ctx, pullMirrorJobID(repoID)); err != nil {
+		return fmt.Errorf("failed to purge pull mirror job: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) schedulePullMirror(ctx context.Context, mirror *types.PullMirror) error {
+	err := s.scheduler.AddRecurringWithData(
+		ctx,
+		pullMirrorJobID(mirror.RepoID),
+		jobTypePullMirror,
+		mirror.Cron,
+		jobData(mirror.RepoID),
+		s.maxDuration,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pull mirror job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle synchronizes the pull mirror repository with its remote repository.
+func (h *pullMirrorHandler) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	repoID, err := repoIDFromJobData(data)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := h.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the repository got deleted - the job is purged together with the repository.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if !repo.IsMirror || repo.Importing {
+		return "", nil
+	}
+
+	mirror, err := h.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Logger()
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	refUpdates, errSync := h.syncPullMirror(ctx, &systemPrincipal, repo, mirror)
+
+	mirror.LastSync = time.Now().UnixMilli()
+	if errSync != nil {
+		mirror.LastSyncState = enum.MirrorSyncStateFailed
+		mirror.LastSyncError = errSync.Error()
+	} else {
+		mirror.LastSyncState = enum.MirrorSyncStateSuccess
+		mirror.LastSyncError = ""
+	}
+
+	if err = h.pullMirrorStore.UpdateSyncState(ctx, mirror); err != nil {
+		log.Warn().Err(err).Msg("failed to update pull mirror sync state")
+	}
+
+	if errSync != nil {
+		return "", fmt.Errorf("failed to sync pull mirror: %w", errSync)
+	}
+
+	for _, refUpdate := range refUpdates {
+		h.reportReferenceEvent(ctx, systemPrincipal.ID, repo, refUpdate)
+	}
+
+	log.Info().Msgf("synced pull mirror (%d reference updates)", len(refUpdates))
+
+	return "", nil
+}
+
+func (h *pullMirrorHandler) syncPullMirror(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	mirror *types.PullMirror,
+) ([]hook.ReferenceUpdate, error) {
+	credentials, err := h.decryptCredentials(mirror.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteURL, err := url.Parse(mirror.RemoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	if credentials.Username != "" || credentials.Password != "" {
+		remoteURL.User = url.UserPassword(credentials.Username, credentials.Password)
+	}
+
+	writeParams, err := h.createRPCWriteParams(ctx, principal, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	syncOut, err := h.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams:       writeParams,
+		Source:            remoteURL.String(),
+		CreateIfNotExists: false,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	if err != nil {
+		return nil, redactCredentials(err, credentials)
+	}
+
+	if syncOut.DefaultBranch != "" && syncOut.DefaultBranch != repo.DefaultBranch {
+		_, err = h.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = syncOut.DefaultBranch
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update default branch of the repository: %w", err)
+		}
+	}
+
+	return syncOut.RefUpdates, nil
+}
+
+func (h *pullMirrorHandler) reportReferenceEvent(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	refUpdate hook.ReferenceUpdate,
+) {
+	switch {
+	case strings.HasPrefix(refUpdate.Ref, refPrefixBranch) && refUpdate.Old == types.NilSHA:
+		h.gitReporter.BranchCreated(ctx, &gitevents.BranchCreatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			SHA:         refUpdate.New,
+		})
+	case strings.HasPrefix(refUpdate.Ref, refPrefixBranch) && refUpdate.New == types.NilSHA:
+		h.gitReporter.BranchDeleted(ctx, &gitevents.BranchDeletedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			SHA:         refUpdate.Old,
+		})
+	case strings.HasPrefix(refUpdate.Ref, refPrefixBranch):
+		result, err := h.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AncestorCommitSHA:   refUpdate.Old,
+			DescendantCommitSHA: refUpdate.New,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("ref", refUpdate.Ref).Msg("failed to check ancestor")
+		}
+		h.gitReporter.BranchUpdated(ctx, &gitevents.BranchUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			OldSHA:      refUpdate.Old,
+			NewSHA:      refUpdate.New,
+			Forced:      err != nil || !result.Ancestor,
+		})
+	case strings.HasPrefix(refUpdate.Ref, refPrefixTag) && refUpdate.Old == types.NilSHA:
+		h.gitReporter.TagCreated(ctx, &gitevents.TagCreatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			SHA:         refUpdate.New,
+		})
+	case strings.HasPrefix(refUpdate.Ref, refPrefixTag) && refUpdate.New == types.NilSHA:
+		h.gitReporter.TagDeleted(ctx, &gitevents.TagDeletedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			SHA:         refUpdate.Old,
+		})
+	case strings.HasPrefix(refUpdate.Ref, refPrefixTag):
+		h.gitReporter.TagUpdated(ctx, &gitevents.TagUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			OldSHA:      refUpdate.Old,
+			NewSHA:      refUpdate.New,
+			Forced:      true,
+		})
+	}
+}
+
+// redactCredentials removes the credentials from the error message, as git might include the remote URL.
+func redactCredentials(err error, credentials types.MirrorCredentials) error {
+	if credentials.Password == "" {
+		return err
+	}
+
+	msg := err.Error()
+	msg = strings.ReplaceAll(msg, url.UserPassword(credentials.Username, credentials.Password).String(),
+		url.User(credentials.Username).String()+":"+redactedCredential)
+	msg = strings.ReplaceAll(msg, credentials.Password, redactedCredential)
+
+	return errors.New(msg)
+}
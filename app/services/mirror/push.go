@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePushMirror = "repository_push_mirror"
+	jobIDPrefixPush   = "push-mirror-"
+)
+
+// DefaultPushMirrorRefs are the references pushed to a push mirror if no refs are specified.
+var DefaultPushMirrorRefs = []string{"refs/heads/*", "refs/tags/*"}
+
+var _ job.Handler = (*pushMirrorHandler)(nil)
+
+// pushMirrorHandler is the background job handler that pushes the references of a repository to a push mirror.
+type pushMirrorHandler struct {
+	*Service
+}
+
+// SanitizePushMirrorRefs validates the ref patterns of a push mirror and returns the default ones if none are provided.
+func SanitizePushMirrorRefs(refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return DefaultPushMirrorRefs, nil
+	}
+
+	sanitized := make([]string, len(refs))
+	for i, ref := range refs {
+		ref = strings.TrimSpace(ref)
+
+		if !strings.HasPrefix(ref, "refs/") ||
+			strings.Count(ref, "*") > 1 ||
+			strings.Contains(ref, "..") ||
+			strings.ContainsAny(ref, " :?[\\^~\t\n") {
+			return nil, usererror.BadRequestf("Invalid push mirror ref pattern %q.", ref)
+		}
+
+		sanitized[i] = ref
+	}
+
+	return sanitized, nil
+}
+
+// matchRef returns true if the reference matches any of the ref patterns of the push mirror.
+// NOTE: Same as in git refspecs, the wildcard matches any sequence of characters, including slashes.
+func matchRef(patterns []string, ref string) bool {
+	for _, pattern := range patterns {
+		prefix, suffix, hasWildcard := strings.Cut(pattern, "*")
+		if !hasWildcard && ref == pattern {
+			return true
+		}
+		if hasWildcard && len(ref) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(ref, prefix) && strings.HasSuffix(ref, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreatePushMirror adds a new push mirror to the repository and triggers the initial push.
+func (s *Service) CreatePushMirror(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	identifier string,
+	remoteURL string,
+	credentials types.MirrorCredentials,
+	refs []string,
+) (*types.PushMirror, error) {
+	refs, err := SanitizePushMirrorRefs(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedCredentials, err := s.encryptCredentials(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.PushMirror{
+		RepoID:      repo.ID,
+		Identifier:  identifier,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principal.ID,
+		RemoteURL:   remoteURL,
+		Credentials: encryptedCredentials,
+		Refs:        refs,
+	}
+
+	err = s.pushMirrorStore.Create(ctx, mirror)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A push mirror with the same identifier already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	s.triggerPushMirrorInitial(ctx, mirror)
+
+	return mirror, nil
+}
+
+// UpdatePushMirror updates the remote URL, the credentials and the refs of a push mirror.
+// NOTE: Only the provided values are updated.
+func (s *Service) UpdatePushMirror(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	remoteURL *string,
+	credentials *types.MirrorCredentials,
+	refs []string,
+) error {
+	if remoteURL != nil {
+		mirror.RemoteURL = *remoteURL
+	}
+
+	if credentials != nil {
+		encryptedCredentials, err := s.encryptCredentials(*credentials)
+		if err != nil {
+			return err
+		}
+		mirror.Credentials = encryptedCredentials
+	}
+
+	if refs != nil {
+		sanitizedRefs, err := SanitizePushMirrorRefs(refs)
+		if err != nil {
+			return err
+		}
+		mirror.Refs = sanitizedRefs
+	}
+
+	if err := s.pushMirrorStore.Update(ctx, mirror); err != nil {
+		return fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	s.triggerPushMirrorInitial(ctx, mirror)
+
+	return nil
+}
+
+// triggerPushMirrorInitial pushes all references to a new or updated push mirror.
+func (s *Service) triggerPushMirrorInitial(ctx context.Context, mirror *types.PushMirror) {
+	uid, err := job.UID()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to generate push mirror job uid")
+		return
+	}
+
+	if err = s.runPushMirrorJob(ctx, mirror, uid); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to trigger push to the push mirror")
+	}
+}
+
+func (s *Service) runPushMirrorJob(ctx context.Context, mirror *types.PushMirror, uid string) error {
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobIDPrefixPush + strconv.FormatInt(mirror.ID, 10) + "-" + uid,
+		Type:       jobTypePushMirror,
+		MaxRetries: s.pushMaxRetries,
+		Timeout:    s.maxDuration,
+		Data:       jobData(mirror.ID),
+	})
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.triggerPushMirrors(ctx, event.ID, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// triggerPushMirrors starts a push job for every push mirror of the repository that mirrors the changed reference.
+// The event ID is part of the job UID, which makes the operation idempotent in case the event is processed again.
+func (s *Service) triggerPushMirrors(ctx context.Context, eventID string, repoID int64, ref string) error {
+	mirrors, err := s.pushMirrorStore.List(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	for i := range mirrors {
+		mirror := &mirrors[i]
+		if !matchRef(mirror.Refs, ref) {
+			continue
+		}
+
+		err = s.runPushMirrorJob(ctx, mirror, eventID)
+		if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+			return fmt.Errorf("failed to start push mirror job for mirror %q: %w", mirror.Identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// Handle pushes the references of the repository to the push mirror.
+func (h *pushMirrorHandler) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	mirrorID, err := repoIDFromJobData(data)
+	if err != nil {
+		return "", err
+	}
+
+	mirror, err := h.pushMirrorStore.Find(ctx, mirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the push mirror got deleted in the meantime.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	repo, err := h.repoStore.Find(ctx, mirror.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	errPush := h.push(ctx, repo, mirror)
+
+	now := time.Now().UnixMilli()
+	if errPush != nil {
+		mirror.LastFailure = now
+		mirror.LastError = errPush.Error()
+	} else {
+		mirror.LastSuccess = now
+		mirror.LastError = ""
+	}
+
+	if err = h.pushMirrorStore.UpdateResult(ctx, mirror); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update push mirror result")
+	}
+
+	if errPush != nil {
+		return "", fmt.Errorf("failed to push to push mirror: %w", errPush)
+	}
+
+	return "", nil
+}
+
+func (h *pushMirrorHandler) push(ctx context.Context, repo *types.Repository, mirror *types.PushMirror) error {
+	credentials, err := h.decryptCredentials(mirror.Credentials)
+	if err != nil {
+		return err
+	}
+
+	remoteURL, err := url.Parse(mirror.RemoteURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	if credentials.Username != "" || credentials.Password != "" {
+		remoteURL.User = url.UserPassword(credentials.Username, credentials.Password)
+	}
+
+	refSpecs := make([]string, len(mirror.Refs))
+	for i, ref := range mirror.Refs {
+		refSpecs[i] = "+" + ref + ":" + ref
+	}
+
+	err = h.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.CreateReadParams(repo),
+		RemoteURL:  remoteURL.String(),
+		RefSpecs:   refSpecs,
+	})
+	if err != nil {
+		return redactCredentials(err, credentials)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizePushMirrorRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		refs    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			refs: nil,
+			want: DefaultPushMirrorRefs,
+		},
+		{
+			name: "trimmed",
+			refs: []string{" refs/heads/main ", "refs/tags/*"},
+			want: []string{"refs/heads/main", "refs/tags/*"},
+		},
+		{
+			name: "wildcard in the middle",
+			refs: []string{"refs/heads/release-*/stable"},
+			want: []string{"refs/heads/release-*/stable"},
+		},
+		{
+			name:    "not a full ref",
+			refs:    []string{"main"},
+			wantErr: true,
+		},
+		{
+			name:    "multiple wildcards",
+			refs:    []string{"refs/*/*"},
+			wantErr: true,
+		},
+		{
+			name:    "double dot",
+			refs:    []string{"refs/heads/../tags"},
+			wantErr: true,
+		},
+		{
+			name:    "refspec",
+			refs:    []string{"refs/heads/main:refs/heads/other"},
+			wantErr: true,
+		},
+		{
+			name:    "force refspec",
+			refs:    []string{"+refs/heads/main"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SanitizePushMirrorRefs(test.refs)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMatchRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		ref      string
+		want     bool
+	}{
+		{
+			name:     "exact match",
+			patterns: []string{"refs/heads/main"},
+			ref:      "refs/heads/main",
+			want:     true,
+		},
+		{
+			name:     "exact mismatch",
+			patterns: []string{"refs/heads/main"},
+			ref:      "refs/heads/main2",
+			want:     false,
+		},
+		{
+			name:     "trailing wildcard",
+			patterns: []string{"refs/heads/*"},
+			ref:      "refs/heads/feature/x",
+			want:     true,
+		},
+		{
+			name:     "wildcard in the middle",
+			patterns: []string{"refs/heads/release-*/stable"},
+			ref:      "refs/heads/release-1.0/stable",
+			want:     true,
+		},
+		{
+			name:     "wildcard in the middle mismatch",
+			patterns: []string{"refs/heads/release-*/stable"},
+			ref:      "refs/heads/release-1.0/beta",
+			want:     false,
+		},
+		{
+			name:     "prefix and suffix overlap",
+			patterns: []string{"refs/heads/a*a"},
+			ref:      "refs/heads/a",
+			want:     false,
+		},
+		{
+			name:     "any of the patterns",
+			patterns: DefaultPushMirrorRefs,
+			ref:      "refs/tags/v1.0",
+			want:     true,
+		},
+		{
+			name:     "none of the patterns",
+			patterns: DefaultPushMirrorRefs,
+			ref:      "refs/pullreq/1/head",
+			want:     false,
+		},
+		{
+			name:     "no patterns",
+			patterns: nil,
+			ref:      "refs/heads/main",
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, matchRef(test.patterns, test.ref))
+		})
+	}
+}
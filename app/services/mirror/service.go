@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// Service keeps the pull mirror repositories in sync with their remote repositories
+// and pushes the changes of repositories to their push mirrors.
+type Service struct {
+	defaultCron     string
+	maxDuration     time.Duration
+	pushMaxRetries  int
+	urlProvider     url.Provider
+	git             git.Interface
+	repoStore       store.RepoStore
+	pullMirrorStore store.PullMirrorStore
+	pushMirrorStore store.PushMirrorStore
+	encrypter       encrypt.Encrypter
+	scheduler       *job.Scheduler
+	gitReporter     *gitevents.Reporter
+}
+
+func NewService(
+	config *types.Config,
+	urlProvider url.Provider,
+	git git.Interface,
+	repoStore store.RepoStore,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	encrypter encrypt.Encrypter,
+	scheduler *job.Scheduler,
+	gitReporter *gitevents.Reporter,
+) *Service {
+	return &Service{
+		defaultCron:     config.Mirror.DefaultCron,
+		maxDuration:     config.Mirror.MaxDuration,
+		pushMaxRetries:  config.Mirror.PushMaxRetries,
+		urlProvider:     urlProvider,
+		git:             git,
+		repoStore:       repoStore,
+		pullMirrorStore: pullMirrorStore,
+		pushMirrorStore: pushMirrorStore,
+		encrypter:       encrypter,
+		scheduler:       scheduler,
+		gitReporter:     gitReporter,
+	}
+}
+
+// SanitizeCron validates the cron definition of a mirror and returns the default one if it's empty.
+func (s *Service) SanitizeCron(cron string) (string, error) {
+	cron = strings.TrimSpace(cron)
+	if cron == "" {
+		return s.defaultCron, nil
+	}
+
+	if _, err := cronexpr.Parse(cron); err != nil {
+		return "", usererror.BadRequestf("Invalid mirror schedule: %s", err)
+	}
+
+	return cron, nil
+}
+
+func (s *Service) encryptCredentials(credentials types.MirrorCredentials) ([]byte, error) {
+	if credentials.Username == "" && credentials.Password == "" {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mirror credentials: %w", err)
+	}
+
+	encrypted, err := s.encrypter.Encrypt(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt mirror credentials: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+func (s *Service) decryptCredentials(encrypted []byte) (types.MirrorCredentials, error) {
+	if len(encrypted) == 0 {
+		return types.MirrorCredentials{}, nil
+	}
+
+	data, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return types.MirrorCredentials{}, fmt.Errorf("failed to decrypt mirror credentials: %w", err)
+	}
+
+	var credentials types.MirrorCredentials
+	if err = json.Unmarshal([]byte(data), &credentials); err != nil {
+		return types.MirrorCredentials{}, fmt.Errorf("failed to unmarshal mirror credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (s *Service) createRPCWriteParams(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+func jobData(repoID int64) string {
+	return strconv.FormatInt(repoID, 10)
+}
+
+func repoIDFromJobData(data string) (int64, error) {
+	repoID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mirror job data %q: %w", data, err)
+	}
+
+	return repoID, nil
+}
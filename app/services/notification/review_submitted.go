@@ -29,6 +29,8 @@ type ReviewSubmittedPayload struct {
 	Author   *types.PrincipalInfo
 	Reviewer *types.PrincipalInfo
 	Decision enum.PullReqReviewDecision
+	// CommentCount is the number of review comments published with the review.
+	CommentCount int
 }
 
 func (s *Service) notifyReviewSubmitted(
@@ -96,5 +98,7 @@ func (s *Service) processReviewSubmittedEvent(
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
+
+		CommentCount: len(event.Payload.CommentIDs),
 	}, []*types.PrincipalInfo{authorPrincipal}, nil
 }
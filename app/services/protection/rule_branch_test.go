@@ -160,6 +160,56 @@ func TestBranch_MergeVerify(t *testing.T) {
 			},
 			expVs: []types.RuleViolations{},
 		},
+		{
+			name: "merge-methods-fast-forward",
+			branch: Branch{
+				Bypass: DefBypass{},
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{},
+					Comments:     DefComments{},
+					Merge: DefMerge{
+						StrategiesAllowed: []enum.MergeMethod{enum.MergeMethodFastForward},
+						DeleteBranch:      false,
+					},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor: user,
+			},
+			expOut: MergeVerifyOutput{
+				DeleteSourceBranch: false,
+				AllowedMethods:     []enum.MergeMethod{enum.MergeMethodFastForward},
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "merge-methods-fast-forward-only",
+			branch: Branch{
+				Bypass: DefBypass{},
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{},
+					Comments:     DefComments{},
+					Merge: DefMerge{
+						StrategiesAllowed: []enum.MergeMethod{enum.MergeMethodFastForward},
+						DeleteBranch:      false,
+					},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:  user,
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				DeleteSourceBranch: false,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Violations: []types.Violation{
+						{Code: codePullReqMergeStrategiesAllowed},
+					},
+				},
+			},
+		},
 		{
 			name: "definition-values",
 			branch: Branch{
@@ -16,6 +16,7 @@ package protection
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/types"
 )
@@ -33,6 +34,13 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+		// Commits lists the existing commits added to a branch, it's used only if a rule requires signed commits.
+		// If a rule requires signed commits and no lister is provided, the commits are treated as not trusted.
+		Commits CommitLister
+		// ServerCommits is set if the server creates new commits on the branch (e.g. web edits).
+		ServerCommits bool
+		// SigningKey is the key the commits created by the server are signed with, nil if they aren't signed.
+		SigningKey *types.SigningKey
 	}
 
 	RefType int
@@ -40,9 +48,10 @@ type (
 	RefAction int
 
 	DefLifecycle struct {
-		CreateForbidden bool `json:"create_forbidden,omitempty"`
-		DeleteForbidden bool `json:"delete_forbidden,omitempty"`
-		UpdateForbidden bool `json:"update_forbidden,omitempty"`
+		CreateForbidden bool             `json:"create_forbidden,omitempty"`
+		DeleteForbidden bool             `json:"delete_forbidden,omitempty"`
+		UpdateForbidden bool             `json:"update_forbidden,omitempty"`
+		SignedCommits   DefSignedCommits `json:"signed_commits"`
 	}
 )
 
@@ -68,9 +77,13 @@ const (
 	codeLifecycleCreate = "lifecycle.create"
 	codeLifecycleDelete = "lifecycle.delete"
 	codeLifecycleUpdate = "lifecycle.update"
+
+	codeLifecycleSignedCommitsRequire = "lifecycle.signed_commits.require"
+	codeLifecycleSignedCommitsUnknown = "lifecycle.signed_commits.unknown"
+	codeLifecycleSignedCommitsServer  = "lifecycle.signed_commits.server"
 )
 
-func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+func (v *DefLifecycle) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
 	switch in.RefAction {
@@ -91,6 +104,12 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 		}
 	}
 
+	if v.SignedCommits.Require && (in.RefAction == RefActionCreate || in.RefAction == RefActionUpdate) {
+		if err := v.verifySignedCommits(ctx, in, &violations); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(violations.Violations) > 0 {
 		return []types.RuleViolations{violations}, nil
 	}
@@ -98,6 +117,47 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 	return nil, nil
 }
 
-func (*DefLifecycle) Sanitize() error {
+func (v *DefLifecycle) verifySignedCommits(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+	violations *types.RuleViolations,
+) error {
+	for _, refName := range in.RefNames {
+		if in.ServerCommits && !v.SignedCommits.trustsSigningKey(in.SigningKey) {
+			violations.Addf(codeLifecycleSignedCommitsServer,
+				"Commits pushed to branch %q must be signed by a trusted key. "+
+					"Commits created by the server aren't signed by a trusted key.",
+				refName)
+		}
+
+		if in.Commits == nil {
+			violations.Addf(codeLifecycleSignedCommitsUnknown,
+				"Commits pushed to branch %q must be signed by a trusted key. "+
+					"The signatures of the commits couldn't be verified.",
+				refName)
+			continue
+		}
+
+		commits, err := in.Commits(ctx, refName)
+		if err != nil {
+			return fmt.Errorf("failed to list commits of branch %q: %w", refName, err)
+		}
+
+		if untrusted := v.SignedCommits.untrustedCommits(commits); len(untrusted) > 0 {
+			violations.Addf(codeLifecycleSignedCommitsRequire,
+				"Commits pushed to branch %q must be signed by a trusted key. "+
+					"Commits without a trusted signature: %s.",
+				refName, formatCommitList(untrusted))
+		}
+	}
+
+	return nil
+}
+
+func (v *DefLifecycle) Sanitize() error {
+	if err := v.SignedCommits.Sanitize(); err != nil {
+		return fmt.Errorf("signed commits: %w", err)
+	}
+
 	return nil
 }
@@ -45,6 +45,8 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+		// Commits lists the commits of the pull request, it's used only if a rule requires signed commits.
+		Commits CommitLister
 	}
 
 	MergeVerifyOutput struct {
@@ -91,11 +93,13 @@ const (
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+
+	codePullReqSignedCommitsRequire = "pullreq.signed_commits.require"
 )
 
 //nolint:gocognit // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -214,6 +218,21 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	// pullreq.signed_commits
+
+	if v.SignedCommits.Require && in.Commits != nil {
+		commits, err := in.Commits(ctx, in.PullReq.TargetBranch)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		if untrusted := v.SignedCommits.untrustedCommits(commits); len(untrusted) > 0 {
+			violations.Addf(codePullReqSignedCommitsRequire,
+				"All commits must be signed by a trusted key. Commits without a trusted signature: %s.",
+				formatCommitList(untrusted))
+		}
+	}
+
 	// pullreq.merge
 
 	if in.Method == "" {
@@ -362,10 +381,11 @@ func (v *DefPush) Sanitize() error {
 }
 
 type DefPullReq struct {
-	Approvals    DefApprovals    `json:"approvals"`
-	Comments     DefComments     `json:"comments"`
-	StatusChecks DefStatusChecks `json:"status_checks"`
-	Merge        DefMerge        `json:"merge"`
+	Approvals     DefApprovals     `json:"approvals"`
+	Comments      DefComments      `json:"comments"`
+	StatusChecks  DefStatusChecks  `json:"status_checks"`
+	Merge         DefMerge         `json:"merge"`
+	SignedCommits DefSignedCommits `json:"signed_commits"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -385,6 +405,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.SignedCommits.Sanitize(); err != nil {
+		return fmt.Errorf("signed commits: %w", err)
+	}
+
 	return nil
 }
 
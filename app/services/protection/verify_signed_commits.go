@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// CommitLister returns the commits that are about to be added to the branch with the provided name.
+type CommitLister func(ctx context.Context, branchName string) ([]git.CommitSignedData, error)
+
+// NoCommits is a CommitLister for ref changes that don't add any existing commits to the branch
+// (e.g. a web edit, where the only new commit is created by the server).
+func NoCommits(context.Context, string) ([]git.CommitSignedData, error) {
+	return nil, nil
+}
+
+// NewCommitLister returns a CommitLister that lists the commits using the provided function
+// and caches the result per branch, as the same commits are required for every matching rule.
+func NewCommitLister(
+	fn func(ctx context.Context, branchName string) ([]git.CommitSignedData, error),
+) CommitLister {
+	cache := map[string][]git.CommitSignedData{}
+	return func(ctx context.Context, branchName string) ([]git.CommitSignedData, error) {
+		if commits, ok := cache[branchName]; ok {
+			return commits, nil
+		}
+
+		commits, err := fn(ctx, branchName)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[branchName] = commits
+
+		return commits, nil
+	}
+}
+
+// maxReportedCommits is the maximum number of untrusted commits that are listed in a violation message.
+const maxReportedCommits = 5
+
+// DefSignedCommits defines whether commits must be signed by a trusted key.
+// The trusted keys are part of the definition: armored GPG public keys
+// and SSH public keys in the format of git's allowed signers file.
+type DefSignedCommits struct {
+	Require           bool     `json:"require,omitempty"`
+	GPGKeys           []string `json:"gpg_keys,omitempty"`
+	SSHAllowedSigners []string `json:"ssh_allowed_signers,omitempty"`
+}
+
+func (v *DefSignedCommits) Sanitize() error {
+	if len(v.GPGKeys)+len(v.SSHAllowedSigners) > maxElements {
+		return errors.New("too many trusted keys provided")
+	}
+
+	for i := range v.GPGKeys {
+		v.GPGKeys[i] = strings.TrimSpace(v.GPGKeys[i])
+		if _, err := gitsignature.ParseGPGKey(v.GPGKeys[i]); err != nil {
+			return fmt.Errorf("invalid gpg key at position %d: %w", i, err)
+		}
+	}
+
+	for i := range v.SSHAllowedSigners {
+		v.SSHAllowedSigners[i] = strings.TrimSpace(v.SSHAllowedSigners[i])
+		if _, err := parseSSHAllowedSigner(v.SSHAllowedSigners[i]); err != nil {
+			return fmt.Errorf("invalid ssh allowed signer at position %d: %w", i, err)
+		}
+	}
+
+	if v.Require && len(v.GPGKeys) == 0 && len(v.SSHAllowedSigners) == 0 {
+		return errors.New("require signed commits needs at least one trusted gpg key or ssh allowed signer")
+	}
+
+	return nil
+}
+
+// untrustedCommits returns SHAs of all commits that aren't signed by any of the trusted keys.
+func (v *DefSignedCommits) untrustedCommits(commits []git.CommitSignedData) []string {
+	keyring := gitsignature.ParseGPGKeyRing(v.GPGKeys...)
+
+	sshFingerprints := make(map[string]struct{}, len(v.SSHAllowedSigners))
+	for _, signer := range v.SSHAllowedSigners {
+		key, err := parseSSHAllowedSigner(signer)
+		if err != nil {
+			continue
+		}
+		sshFingerprints[gossh.FingerprintSHA256(key)] = struct{}{}
+	}
+
+	var untrusted []string
+	for _, commit := range commits {
+		if commit.SignedData == nil {
+			untrusted = append(untrusted, commit.SHA)
+			continue
+		}
+
+		signature := commit.SignedData.Signature
+		content := commit.SignedData.SignedContent
+
+		var trusted bool
+		switch {
+		case gitsignature.IsGPGSignature(signature):
+			_, err := gitsignature.VerifyGPGSignature(keyring, signature, content)
+			trusted = err == nil
+		case gitsignature.IsSSHSignature(signature):
+			key, err := gitsignature.VerifySSHSignature(signature, content)
+			if err == nil {
+				_, trusted = sshFingerprints[gossh.FingerprintSHA256(key)]
+			}
+		}
+
+		if !trusted {
+			untrusted = append(untrusted, commit.SHA)
+		}
+	}
+
+	return untrusted
+}
+
+// trustsSigningKey returns true if the provided signing key of the server is one of the trusted keys.
+func (v *DefSignedCommits) trustsSigningKey(key *types.SigningKey) bool {
+	if key == nil {
+		return false
+	}
+
+	switch key.Type {
+	case enum.GitSignatureTypeGPG:
+		for _, content := range v.GPGKeys {
+			gpgKey, err := gitsignature.ParseGPGKey(content)
+			if err == nil && strings.EqualFold(gpgKey.Fingerprint, key.Fingerprint) {
+				return true
+			}
+		}
+	case enum.GitSignatureTypeSSH:
+		for _, signer := range v.SSHAllowedSigners {
+			sshKey, err := parseSSHAllowedSigner(signer)
+			if err == nil && gossh.FingerprintSHA256(sshKey) == key.Fingerprint {
+				return true
+			}
+		}
+	case enum.GitSignatureTypeUnknown:
+	}
+
+	return false
+}
+
+// parseSSHAllowedSigner parses a single line of an allowed signers file
+// ("principals [options] keytype base64-key [comment]"). Plain public keys are accepted as well.
+func parseSSHAllowedSigner(line string) (gossh.PublicKey, error) {
+	// the principals field is skipped by the parser the same way as the options of an authorized key.
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(line))
+	if err == nil {
+		return key, nil
+	}
+
+	// principals followed by options: skip the principals and let the parser handle the options.
+	if _, rest, ok := strings.Cut(line, " "); ok {
+		key, _, _, _, err = gossh.ParseAuthorizedKey([]byte(strings.TrimSpace(rest)))
+		if err == nil {
+			return key, nil
+		}
+	}
+
+	return nil, errors.New("failed to parse the ssh public key")
+}
+
+// formatCommitList returns a shortened, human readable list of commit SHAs.
+func formatCommitList(shas []string) string {
+	const shortSHALength = 8
+
+	n := len(shas)
+	if n > maxReportedCommits {
+		n = maxReportedCommits
+	}
+
+	short := make([]string, n)
+	for i := 0; i < n; i++ {
+		short[i] = shas[i]
+		if len(short[i]) > shortSHALength {
+			short[i] = short[i][:shortSHALength]
+		}
+	}
+
+	list := strings.Join(short, ", ")
+	if len(shas) > n {
+		list += fmt.Sprintf(" and %d more", len(shas)-n)
+	}
+
+	return list
+}
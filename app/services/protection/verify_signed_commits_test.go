@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	testSSHAllowedSigner = "max@mail.com ssh-ed25519 " +
+		"AAAAC3NzaC1lZDI1NTE5AAAAINn46RDv+qi/s5wMvSsPBHJr8uECLRRcV+n35yC2GAPR"
+
+	testSSHSignedContent = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author max <max@mail.com> 1666401234 -0700\n" +
+		"committer max <max@mail.com> 1666401234 -0700\n" +
+		"\n" +
+		"some title\n"
+
+	testSSHSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAg2fjpEO/6qL+znAy9Kw8Ecmvy4Q
+ItFFxX6ffnILYYA9EAAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQC4LA9zMVHRD8TFGJTXYPUC0/0FEbYX7PH1sCG5AwEAXlyyeQlzf4F1l88tvpbPX4u
+8zCFtC0DYJUvVDaHXsSwk=
+-----END SSH SIGNATURE-----
+`
+)
+
+func TestDefSignedCommits_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefSignedCommits
+		expErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "require-with-ssh-signer",
+			def:  DefSignedCommits{Require: true, SSHAllowedSigners: []string{testSSHAllowedSigner}},
+		},
+		{
+			name:   "require-without-keys",
+			def:    DefSignedCommits{Require: true},
+			expErr: true,
+		},
+		{
+			name:   "invalid-ssh-signer",
+			def:    DefSignedCommits{SSHAllowedSigners: []string{"max@mail.com not-a-key"}},
+			expErr: true,
+		},
+		{
+			name:   "invalid-gpg-key",
+			def:    DefSignedCommits{GPGKeys: []string{"not-a-key"}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Errorf("error mismatch: expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestDefLifecycle_RefChangeVerify_SignedCommits(t *testing.T) {
+	const refName = "a"
+
+	signed := git.CommitSignedData{
+		SHA: "1111111111111111111111111111111111111111",
+		SignedData: &git.SignedData{
+			Signature:     []byte(testSSHSignature),
+			SignedContent: []byte(testSSHSignedContent),
+		},
+	}
+	tampered := git.CommitSignedData{
+		SHA: "2222222222222222222222222222222222222222",
+		SignedData: &git.SignedData{
+			Signature:     []byte(testSSHSignature),
+			SignedContent: []byte(testSSHSignedContent + "tampered"),
+		},
+	}
+	unsigned := git.CommitSignedData{
+		SHA: "3333333333333333333333333333333333333333",
+	}
+
+	def := DefLifecycle{
+		SignedCommits: DefSignedCommits{
+			Require:           true,
+			SSHAllowedSigners: []string{testSSHAllowedSigner},
+		},
+	}
+
+	trustedKey, err := parseSSHAllowedSigner(testSSHAllowedSigner)
+	if err != nil {
+		t.Fatalf("failed to parse ssh allowed signer: %s", err.Error())
+	}
+
+	trustedSigningKey := &types.SigningKey{
+		Type:        enum.GitSignatureTypeSSH,
+		Fingerprint: gossh.FingerprintSHA256(trustedKey),
+	}
+	otherSigningKey := &types.SigningKey{
+		Type:        enum.GitSignatureTypeSSH,
+		Fingerprint: "SHA256:other",
+	}
+
+	tests := []struct {
+		name          string
+		commits       []git.CommitSignedData
+		noLister      bool
+		serverCommits bool
+		signingKey    *types.SigningKey
+		expCodes      []string
+		expParams     [][]any
+	}{
+		{
+			name:    "trusted",
+			commits: []git.CommitSignedData{signed},
+		},
+		{
+			name:      "untrusted",
+			commits:   []git.CommitSignedData{signed, tampered, unsigned},
+			expCodes:  []string{"lifecycle.signed_commits.require"},
+			expParams: [][]any{{refName, "22222222, 33333333"}},
+		},
+		{
+			name:      "no-lister",
+			noLister:  true,
+			expCodes:  []string{"lifecycle.signed_commits.unknown"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:          "server-commits-trusted-key",
+			serverCommits: true,
+			signingKey:    trustedSigningKey,
+		},
+		{
+			name:          "server-commits-untrusted-key",
+			serverCommits: true,
+			signingKey:    otherSigningKey,
+			expCodes:      []string{"lifecycle.signed_commits.server"},
+			expParams:     [][]any{{refName}},
+		},
+		{
+			name:          "server-commits-unsigned",
+			serverCommits: true,
+			expCodes:      []string{"lifecycle.signed_commits.server"},
+			expParams:     [][]any{{refName}},
+		},
+		{
+			name:          "server-commits-and-untrusted",
+			commits:       []git.CommitSignedData{unsigned},
+			serverCommits: true,
+			expCodes:      []string{"lifecycle.signed_commits.server", "lifecycle.signed_commits.require"},
+			expParams:     [][]any{{refName}, {refName, "33333333"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			in := RefChangeVerifyInput{
+				RefNames:  []string{refName},
+				RefAction: RefActionUpdate,
+				RefType:   RefTypeBranch,
+				Commits: func(context.Context, string) ([]git.CommitSignedData, error) {
+					return test.commits, nil
+				},
+				ServerCommits: test.serverCommits,
+				SigningKey:    test.signingKey,
+			}
+			if test.noLister {
+				in.Commits = nil
+			}
+
+			violations, err := def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"crypto/rsa"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const minRSAKeyBits = 2048
+
+// ErrKeyInUse is returned if a public key is already registered (for any user or repository).
+var ErrKeyInUse = usererror.New(http.StatusConflict, "The public key is already in use")
+
+// ParseKey parses a public key in the authorized_keys format and rejects weak keys.
+func ParseKey(content string) (gossh.PublicKey, string, error) {
+	key, comment, options, rest, err := gossh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, "", usererror.BadRequestf("The public key is invalid: %s", err)
+	}
+
+	if len(options) > 0 || len(rest) > 0 {
+		return nil, "", usererror.BadRequest("The public key must be provided as a single key without options")
+	}
+
+	switch key.Type() {
+	case gossh.KeyAlgoDSA:
+		return nil, "", usererror.BadRequest("DSA keys are not supported")
+	case gossh.KeyAlgoRSA:
+		cryptoKey, ok := key.(gossh.CryptoPublicKey)
+		if !ok {
+			return nil, "", usererror.BadRequest("The RSA public key is invalid")
+		}
+		rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+		if !ok || rsaKey.N.BitLen() < minRSAKeyBits {
+			return nil, "", usererror.BadRequestf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+	}
+
+	return key, comment, nil
+}
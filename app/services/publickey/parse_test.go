@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed25519SSHKey, err := gossh.NewPublicKey(ed25519Key)
+	require.NoError(t, err)
+
+	weakRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakRSASSHKey, err := gossh.NewPublicKey(&weakRSAKey.PublicKey)
+	require.NoError(t, err)
+
+	ed25519Content := string(gossh.MarshalAuthorizedKey(ed25519SSHKey))
+
+	tests := []struct {
+		name        string
+		content     string
+		wantComment string
+		wantErr     bool
+	}{
+		{
+			name:        "ed25519 with comment",
+			content:     ed25519Content[:len(ed25519Content)-1] + " user@host",
+			wantComment: "user@host",
+		},
+		{
+			name:    "ed25519 without comment",
+			content: ed25519Content,
+		},
+		{
+			name:    "ed25519 with options",
+			content: "no-pty " + ed25519Content,
+			wantErr: true,
+		},
+		{
+			name:    "weak rsa",
+			content: string(gossh.MarshalAuthorizedKey(weakRSASSHKey)),
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			content: "ssh-ed25519 garbage",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, comment, err := ParseKey(test.content)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, gossh.FingerprintSHA256(ed25519SSHKey), gossh.FingerprintSHA256(key))
+			require.Equal(t, test.wantComment, comment)
+		})
+	}
+}
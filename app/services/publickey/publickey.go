@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var (
+	// ErrUnknownKey is returned if the provided public key isn't registered.
+	ErrUnknownKey = errors.New("public key is not known")
+
+	// ErrPrincipalBlocked is returned if the owner of the public key is blocked.
+	ErrPrincipalBlocked = errors.New("principal owning the public key is blocked")
+)
+
+// KeyOwner is the owner of a validated public key.
+// Exactly one of the fields is set - either the principal owning a user key, or the deploy key.
+type KeyOwner struct {
+	Principal *types.Principal
+	DeployKey *types.DeployKey
+}
+
+// Service is an abstraction of an entity responsible for validating public keys.
+type Service interface {
+	// ValidateKey returns the owner of the provided public key.
+	ValidateKey(ctx context.Context, publicKey gossh.PublicKey) (*KeyOwner, error)
+
+	// IsKeyInUse returns true if a key with the provided fingerprint is already registered for authentication,
+	// either as public key of a principal or as deploy key of a repository.
+	IsKeyInUse(ctx context.Context, fingerprint string) (bool, error)
+}
+
+var _ Service = LocalService{}
+
+// LocalService validates public keys against the keys registered in the database.
+type LocalService struct {
+	publicKeyStore store.PublicKeyStore
+	deployKeyStore store.DeployKeyStore
+	principalStore store.PrincipalStore
+}
+
+func NewLocalService(
+	publicKeyStore store.PublicKeyStore,
+	deployKeyStore store.DeployKeyStore,
+	principalStore store.PrincipalStore,
+) LocalService {
+	return LocalService{
+		publicKeyStore: publicKeyStore,
+		deployKeyStore: deployKeyStore,
+		principalStore: principalStore,
+	}
+}
+
+// ValidateKey returns the owner of the provided public key.
+func (s LocalService) ValidateKey(ctx context.Context, publicKey gossh.PublicKey) (*KeyOwner, error) {
+	fingerprint := gossh.FingerprintSHA256(publicKey)
+
+	existingKey, err := s.publicKeyStore.FindByFingerprint(ctx, fingerprint, enum.PublicKeyUsageAuth)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return s.validateDeployKey(ctx, fingerprint, publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	if err = matchKey(existingKey.Content, publicKey); err != nil {
+		return nil, err
+	}
+
+	principal, err := s.principalStore.Find(ctx, existingKey.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of public key: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, ErrPrincipalBlocked
+	}
+
+	// failing to track usage of the key shouldn't prevent the principal from authenticating.
+	if err = s.publicKeyStore.MarkAsUsed(ctx, existingKey.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to mark public key as used")
+	}
+
+	return &KeyOwner{Principal: principal}, nil
+}
+
+func (s LocalService) validateDeployKey(
+	ctx context.Context,
+	fingerprint string,
+	publicKey gossh.PublicKey,
+) (*KeyOwner, error) {
+	deployKey, err := s.deployKeyStore.FindByFingerprint(ctx, fingerprint)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrUnknownKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deploy key by fingerprint: %w", err)
+	}
+
+	if err = matchKey(deployKey.Content, publicKey); err != nil {
+		return nil, err
+	}
+
+	if err = s.deployKeyStore.MarkAsUsed(ctx, deployKey.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to mark deploy key as used")
+	}
+
+	return &KeyOwner{DeployKey: deployKey}, nil
+}
+
+// IsKeyInUse returns true if a key with the provided fingerprint is already registered for authentication,
+// either as public key of a principal or as deploy key of a repository.
+func (s LocalService) IsKeyInUse(ctx context.Context, fingerprint string) (bool, error) {
+	_, err := s.publicKeyStore.FindByFingerprint(ctx, fingerprint, enum.PublicKeyUsageAuth)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	_, err = s.deployKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, fmt.Errorf("failed to find deploy key by fingerprint: %w", err)
+	}
+
+	return false, nil
+}
+
+// matchKey protects against (highly unlikely) fingerprint collisions by comparing the whole key.
+func matchKey(storedContent string, publicKey gossh.PublicKey) error {
+	storedKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(storedContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse stored public key: %w", err)
+	}
+
+	if !bytes.Equal(storedKey.Marshal(), publicKey.Marshal()) {
+		return ErrUnknownKey
+	}
+
+	return nil
+}
@@ -57,9 +57,6 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
 		// First check if the merge base has changed
 
@@ -68,6 +65,19 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return fmt.Errorf("failed to get repo git info: %w", err)
 		}
 
+		// for pull requests from forks, the new commits must be made available in the target repository first.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+			if err != nil {
+				return fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+
+			err = s.fetchSourceObjects(ctx, writeParams, pr.SourceRepoID, pr.TargetRepoID, event.Payload.NewSHA)
+			if err != nil {
+				return err
+			}
+		}
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -103,6 +113,7 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
+			pr.MergeNotFastForwardable = false
 			pr.Stats.DiffStats.Commits = nil
 			pr.Stats.DiffStats.FilesChanged = nil
 
@@ -177,6 +188,7 @@ func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
 			pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
+			pr.MergeNotFastForwardable = false
 
 			return nil
 		})
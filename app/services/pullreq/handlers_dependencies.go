@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errPRRetargeted = errors.New("PR target branch has changed")
+
+// retargetChildrenOnMerged handles pull request Merged events.
+// Every open pull request that depends on the merged pull request and targets its source branch
+// is retargeted to the target branch of the merged pull request and its source branch is rebased on top of it.
+func (s *Service) retargetChildrenOnMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	childIDs, err := s.dependencyStore.ListChildIDs(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to list child pull requests: %w", err)
+	}
+
+	if len(childIDs) == 0 {
+		return nil
+	}
+
+	parent, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	for _, childID := range childIDs {
+		child, err := s.pullreqStore.Find(ctx, childID)
+		if err != nil {
+			return fmt.Errorf("failed to find child pull request: %w", err)
+		}
+
+		// the child pull request must still target the source branch of the merged pull request.
+		// it can't be retargeted if the merged pull request came from another repository (a fork).
+		if child.State != enum.PullReqStateOpen ||
+			child.TargetRepoID != parent.TargetRepoID ||
+			child.TargetBranch != parent.SourceBranch {
+			continue
+		}
+
+		if err = s.retargetChild(ctx, parent, child, event.Payload.SourceSHA); err != nil {
+			log.Ctx(ctx).Err(err).
+				Int64("pullreq_id", child.ID).
+				Msg("failed to retarget child pull request")
+		}
+	}
+
+	return nil
+}
+
+// retargetChild changes the target branch of the child pull request to the target branch of the merged
+// parent pull request. The commits of the child pull request that came after parentSourceSHA
+// are then rebased on top of the new target branch.
+func (s *Service) retargetChild(ctx context.Context,
+	parent, child *types.PullReq,
+	parentSourceSHA string,
+) error {
+	repo, err := s.repoGitInfoCache.Get(ctx, child.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	existing, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         1,
+		SourceRepoID: child.SourceRepoID,
+		SourceBranch: child.SourceBranch,
+		TargetRepoID: child.TargetRepoID,
+		TargetBranch: parent.TargetBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get list of open pull requests for the new target branch: %w", err)
+	}
+	if len(existing) > 0 {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_id", child.ID).
+			Msgf("skipping retarget, pull request #%d already exists for the new target branch", existing[0].Number)
+		return nil
+	}
+
+	mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       child.SourceSHA,
+		Ref2:       parent.TargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get merge base with the new target branch: %w", err)
+	}
+
+	oldTargetBranch := child.TargetBranch
+	oldMergeBaseSHA := child.MergeBaseSHA
+
+	child, err = s.pullreqStore.UpdateOptLock(ctx, child, func(pr *types.PullReq) error {
+		// to avoid racing conditions
+		if pr.State != enum.PullReqStateOpen {
+			return errPRNotOpen
+		}
+		if pr.TargetBranch != oldTargetBranch {
+			return errPRRetargeted
+		}
+
+		pr.ActivitySeq++
+		pr.Edited = time.Now().UnixMilli()
+		pr.TargetBranch = parent.TargetBranch
+		pr.MergeBaseSHA = mergeBaseInfo.MergeBaseSHA
+
+		// reset merge-check fields for new run
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		pr.MergeTargetSHA = nil
+		pr.MergeSHA = nil
+		pr.MergeConflicts = nil
+		pr.MergeNotFastForwardable = false
+		pr.Stats.DiffStats.Commits = nil
+		pr.Stats.DiffStats.FilesChanged = nil
+
+		return nil
+	})
+	if errors.Is(err, errPRNotOpen) || errors.Is(err, errPRRetargeted) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update target branch: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadTargetBranchChange{
+		Old:          oldTargetBranch,
+		New:          child.TargetBranch,
+		ParentNumber: parent.Number,
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, child, bootstrap.NewSystemServiceSession().Principal.ID, payload)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after target branch change")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    child.ID,
+			SourceRepoID: child.SourceRepoID,
+			TargetRepoID: child.TargetRepoID,
+			PrincipalID:  bootstrap.NewSystemServiceSession().Principal.ID,
+			Number:       child.Number,
+		},
+		SourceSHA:       child.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: child.TargetBranch,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: child.MergeBaseSHA,
+	})
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, child); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	// source branches in other repositories (forks) aren't rebased automatically.
+	if child.SourceRepoID != child.TargetRepoID {
+		return nil
+	}
+
+	return s.rebaseChild(ctx, repo, child, parentSourceSHA)
+}
+
+// rebaseChild rebases the source branch of the retargeted child pull request on top of its new target branch.
+// The source branch update is then handled like any other push to the branch.
+func (s *Service) rebaseChild(ctx context.Context,
+	repo *types.RepositoryGitInfo,
+	child *types.PullReq,
+	parentSourceSHA string,
+) error {
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	// rebase only the commits of the child pull request, the merged commits of the parent are already in the target.
+	var mergeBaseSHA string
+	if parentSourceSHA != "" {
+		ancestorOut, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AncestorCommitSHA:   parentSourceSHA,
+			DescendantCommitSHA: child.SourceSHA,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check if parent source commit is an ancestor: %w", err)
+		}
+		if ancestorOut.Ancestor {
+			mergeBaseSHA = parentSourceSHA
+		}
+	}
+
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      child.TargetBranch,
+		HeadBranch:      child.SourceBranch,
+		HeadExpectedSHA: child.SourceSHA,
+		MergeBaseSHA:    mergeBaseSHA,
+		RefType:         gitenum.RefTypeBranch,
+		RefName:         child.SourceBranch,
+		Method:          gitenum.MergeMethodRebase,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rebase source branch: %w", err)
+	}
+
+	if mergeOutput.MergeSHA != "" && len(mergeOutput.ConflictFiles) == 0 {
+		return nil
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("pullreq_id", child.ID).
+		Strs("conflicts", mergeOutput.ConflictFiles).
+		Msg("source branch of the retargeted pull request can't be rebased automatically")
+
+	// let the author know that the source branch needs to be rebased manually.
+	sourceSHA := child.SourceSHA
+	child, err = s.pullreqStore.UpdateOptLock(ctx, child, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+
+		if pr.SourceSHA != sourceSHA {
+			// the source branch has been updated in the meantime, the merge check runs for the new commit.
+			return nil
+		}
+
+		pr.MergeCheckStatus = enum.MergeCheckStatusConflict
+		pr.MergeSHA = nil
+		pr.MergeConflicts = mergeOutput.ConflictFiles
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request after rebase conflict: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadRebaseConflict{
+		TargetBranch:  child.TargetBranch,
+		ConflictFiles: mergeOutput.ConflictFiles,
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, child, bootstrap.NewSystemServiceSession().Principal.ID, payload)
+	if err != nil {
+		return fmt.Errorf("failed to write pull request activity after rebase conflict: %w", err)
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, child); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
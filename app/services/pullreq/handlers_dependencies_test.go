@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type dependencyStoreMock struct {
+	store.PullReqDependencyStore
+	children []int64
+}
+
+func (s dependencyStoreMock) ListChildIDs(context.Context, int64) ([]int64, error) {
+	return s.children, nil
+}
+
+type pullReqStoreMock struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s pullReqStoreMock) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return pr, nil
+}
+
+// repoGitInfoCacheMock counts the lookups, which happen once for every child pull request that gets retargeted.
+// It always fails, so the retargeting doesn't go any further.
+type repoGitInfoCacheMock struct {
+	store.RepoGitInfoCache
+	lookups int
+}
+
+func (c *repoGitInfoCacheMock) Get(context.Context, int64) (*types.RepositoryGitInfo, error) {
+	c.lookups++
+	return nil, errors.New("not available")
+}
+
+func TestRetargetChildrenOnMerged(t *testing.T) {
+	parent := &types.PullReq{
+		ID:           1,
+		State:        enum.PullReqStateMerged,
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+	}
+
+	tests := []struct {
+		name           string
+		child          types.PullReq
+		wantRetargeted int
+	}{
+		{
+			name:           "stacked",
+			child:          types.PullReq{ID: 2, State: enum.PullReqStateOpen, TargetRepoID: 1, TargetBranch: "feature"},
+			wantRetargeted: 1,
+		},
+		{
+			name:  "closed",
+			child: types.PullReq{ID: 2, State: enum.PullReqStateClosed, TargetRepoID: 1, TargetBranch: "feature"},
+		},
+		{
+			name:  "already retargeted",
+			child: types.PullReq{ID: 2, State: enum.PullReqStateOpen, TargetRepoID: 1, TargetBranch: "main"},
+		},
+		{
+			name:  "other repository",
+			child: types.PullReq{ID: 2, State: enum.PullReqStateOpen, TargetRepoID: 2, TargetBranch: "feature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			child := test.child
+			cache := &repoGitInfoCacheMock{}
+			s := &Service{
+				repoGitInfoCache: cache,
+				pullreqStore:     pullReqStoreMock{prs: map[int64]*types.PullReq{1: parent, 2: &child}},
+				dependencyStore:  dependencyStoreMock{children: []int64{2}},
+			}
+
+			err := s.retargetChildrenOnMerged(context.Background(), &events.Event[*pullreqevents.MergedPayload]{
+				Payload: &pullreqevents.MergedPayload{Base: pullreqevents.Base{PullReqID: 1}},
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if cache.lookups != test.wantRetargeted {
+				t.Errorf("retargeted: want=%d got=%d", test.wantRetargeted, cache.lookups)
+			}
+		})
+	}
+}
@@ -65,6 +65,20 @@ func (s *Service) mergeCheckOnBranchUpdate(ctx context.Context,
 	)
 }
 
+// mergeCheckOnTargetBranchChange handles pull request Target Branch Changed events.
+// It updates the PR merge ref, the merge check status and the merge base for the new target branch.
+func (s *Service) mergeCheckOnTargetBranchChange(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.updateMergeData(
+		ctx,
+		event.Payload.TargetRepoID,
+		event.Payload.Number,
+		"",
+		event.Payload.SourceSHA,
+	)
+}
+
 // mergeCheckOnReopen handles pull request StateChanged events.
 // It updates the PR head git ref to point to the source branch commit SHA.
 func (s *Service) mergeCheckOnReopen(ctx context.Context,
@@ -83,14 +97,14 @@ func (s *Service) mergeCheckOnReopen(ctx context.Context,
 func (s *Service) mergeCheckOnClosed(ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 // mergeCheckOnMerged deletes the merge ref.
 func (s *Service) mergeCheckOnMerged(ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64) error {
@@ -104,7 +118,6 @@ func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64)
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(prNum)),
@@ -235,6 +248,7 @@ func (s *Service) updateMergeDataInner(
 			pr.MergeSHA = &mergeOutput.MergeSHA
 			pr.MergeConflicts = nil
 		}
+		pr.MergeNotFastForwardable = !mergeOutput.FastForwardable
 		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
 
 		return nil
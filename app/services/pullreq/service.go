@@ -45,6 +45,7 @@ type Service struct {
 	codeCommentView     store.CodeCommentView
 	codeCommentMigrator *codecomments.Migrator
 	fileViewStore       store.PullReqFileViewStore
+	dependencyStore     store.PullReqDependencyStore
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
 
@@ -68,6 +69,7 @@ func New(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	dependencyStore store.PullReqDependencyStore,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
@@ -83,6 +85,7 @@ func New(ctx context.Context,
 		urlProvider:         urlProvider,
 		codeCommentMigrator: codeCommentMigrator,
 		fileViewStore:       fileViewStore,
+		dependencyStore:     dependencyStore,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
@@ -156,6 +159,27 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// stacked pull requests maintenance
+
+	const groupPullReqDependencies = "gitness:pullreq:dependencies"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqDependencies, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterMerged(service.retargetChildrenOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	const groupPullReqCounters = "gitness:pullreq:counters"
 	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqCounters, config.InstanceID,
 		func(r *pullreqevents.Reader) error {
@@ -192,6 +216,7 @@ func New(ctx context.Context,
 
 			_ = r.RegisterCreated(service.mergeCheckOnCreated)
 			_ = r.RegisterBranchUpdated(service.mergeCheckOnBranchUpdate)
+			_ = r.RegisterTargetBranchChanged(service.mergeCheckOnTargetBranchChange)
 			_ = r.RegisterReopened(service.mergeCheckOnReopen)
 			_ = r.RegisterClosed(service.mergeCheckOnClosed)
 			_ = r.RegisterMerged(service.mergeCheckOnMerged)
@@ -48,11 +48,12 @@ func ProvideService(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	dependencyStore store.PullReqDependencyStore,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
 ) (*Service, error) {
 	return New(ctx, config, gitReaderFactory, pullReqEvFactory, pullReqEvReporter, git,
 		repoGitInfoCache, repoStore, pullreqStore, activityStore,
-		codeCommentView, codeCommentMigrator, fileViewStore, pubsub, urlProvider, sseStreamer)
+		codeCommentView, codeCommentMigrator, fileViewStore, dependencyStore, pubsub, urlProvider, sseStreamer)
 }
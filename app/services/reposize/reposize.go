@@ -31,13 +31,14 @@ import (
 const jobType = "repo-size-calculator"
 
 type Calculator struct {
-	enabled    bool
-	cron       string
-	maxDur     time.Duration
-	numWorkers int
-	git        git.Interface
-	repoStore  store.RepoStore
-	scheduler  *job.Scheduler
+	enabled        bool
+	cron           string
+	maxDur         time.Duration
+	numWorkers     int
+	git            git.Interface
+	repoStore      store.RepoStore
+	lfsObjectStore store.LFSObjectStore
+	scheduler      *job.Scheduler
 }
 
 func (c *Calculator) Register(ctx context.Context) error {
@@ -103,16 +104,25 @@ func worker(ctx context.Context, c *Calculator, wg *sync.WaitGroup, taskCh <-cha
 			log.Error().Msgf("failed to get repo size: %s", err.Error())
 			continue
 		}
-		if sizeOut.Size == sizeInfo.Size {
+
+		// lfs objects are stored outside of the git repository, but count towards the repo size.
+		lfsSize, err := c.lfsObjectStore.GetSizeInKBByRepoID(ctx, sizeInfo.ID)
+		if err != nil {
+			log.Error().Msgf("failed to get lfs objects size: %s", err.Error())
+			continue
+		}
+
+		size := sizeOut.Size + lfsSize
+		if size == sizeInfo.Size {
 			log.Debug().Msg("repo size not changed")
 			continue
 		}
 
-		if err := c.repoStore.UpdateSize(ctx, sizeInfo.ID, sizeOut.Size); err != nil {
+		if err := c.repoStore.UpdateSize(ctx, sizeInfo.ID, size); err != nil {
 			log.Error().Msgf("failed to update repo size: %s", err.Error())
 			continue
 		}
 
-		log.Debug().Msgf("new repo size: %d", sizeOut.Size)
+		log.Debug().Msgf("new repo size: %d", size)
 	}
 }
@@ -31,17 +31,19 @@ func ProvideCalculator(
 	config *types.Config,
 	git git.Interface,
 	repoStore store.RepoStore,
+	lfsObjectStore store.LFSObjectStore,
 	scheduler *job.Scheduler,
 	executor *job.Executor,
 ) (*Calculator, error) {
 	job := &Calculator{
-		enabled:    config.RepoSize.Enabled,
-		cron:       config.RepoSize.CRON,
-		maxDur:     config.RepoSize.MaxDuration,
-		numWorkers: config.RepoSize.NumWorkers,
-		git:        git,
-		repoStore:  repoStore,
-		scheduler:  scheduler,
+		enabled:        config.RepoSize.Enabled,
+		cron:           config.RepoSize.CRON,
+		maxDur:         config.RepoSize.MaxDuration,
+		numWorkers:     config.RepoSize.NumWorkers,
+		git:            git,
+		repoStore:      repoStore,
+		lfsObjectStore: lfsObjectStore,
+		scheduler:      scheduler,
 	}
 
 	err := executor.Register(jobType, job)
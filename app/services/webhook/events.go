@@ -131,6 +131,22 @@ func (s *Service) findPullReqForEvent(ctx context.Context, prID int64) (*types.P
 	return pr, nil
 }
 
+// findLabelForEvent finds the label for the provided labelID.
+func (s *Service) findLabelForEvent(ctx context.Context, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of label being deleted by now
+		return nil, events.NewDiscardEventErrorf("label with id '%d' doesn't exist anymore", labelID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, fmt.Errorf("failed to get label for id '%d': %w", labelID, err)
+	}
+
+	return label, nil
+}
+
 // findPrincipalForEvent finds the principal for the provided principalID.
 func (s *Service) findPrincipalForEvent(ctx context.Context, principalID int64) (*types.Principal, error) {
 	principal, err := s.principalStore.Find(ctx, principalID)
@@ -360,3 +360,140 @@ func (s *Service) handleEventPullReqComment(
 			}, nil
 		})
 }
+
+// PullReqLabelPayload describes the body of the pullreq label assigned and unassigned triggers.
+type PullReqLabelPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqLabelSegment
+}
+
+func (s *Service) handleEventPullReqLabelAssigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	return s.triggerForEventWithPullReqLabel(ctx, enum.WebhookTriggerPullReqLabelAssigned,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID, event.Payload.LabelID)
+}
+
+func (s *Service) handleEventPullReqLabelUnassigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelUnassignedPayload],
+) error {
+	return s.triggerForEventWithPullReqLabel(ctx, enum.WebhookTriggerPullReqLabelUnassigned,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID, event.Payload.LabelID)
+}
+
+func (s *Service) triggerForEventWithPullReqLabel(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	principalID int64,
+	prID int64,
+	labelID int64,
+) error {
+	return s.triggerForEventWithPullReq(ctx, trigger,
+		eventID, principalID, prID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			label, err := s.findLabelForEvent(ctx, labelID)
+			if err != nil {
+				return nil, err
+			}
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			return &PullReqLabelPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqLabelSegment: PullReqLabelSegment{
+					LabelInfo: labelInfoFrom(label),
+				},
+			}, nil
+		})
+}
+
+// PullReqReviewPayload describes the body of the pullreq review submitted trigger.
+type PullReqReviewPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqReviewSegment
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReviewSubmitted,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			comments := make([]CommentInfo, 0, len(event.Payload.CommentIDs))
+			for _, commentID := range event.Payload.CommentIDs {
+				activity, err := s.activityStore.Find(ctx, commentID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w", commentID, err)
+				}
+				if activity.Deleted != nil {
+					continue
+				}
+				comments = append(comments, CommentInfo{
+					Text:     activity.Text,
+					ID:       activity.ID,
+					ParentID: activity.ParentID,
+				})
+			}
+
+			return &PullReqReviewPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqReviewSegment: PullReqReviewSegment{
+					ReviewInfo: ReviewInfo{
+						Decision: event.Payload.Decision,
+						Comments: comments,
+					},
+				},
+			}, nil
+		})
+}
@@ -85,6 +85,7 @@ type Service struct {
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
 	encrypter             encrypt.Encrypter
+	labelStore            store.LabelStore
 
 	secureHTTPClient   *http.Client
 	insecureHTTPClient *http.Client
@@ -109,6 +110,7 @@ func NewService(
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	labelStore store.LabelStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -123,6 +125,7 @@ func NewService(
 		principalStore:        principalStore,
 		git:                   git,
 		encrypter:             encrypter,
+		labelStore:            labelStore,
 
 		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
 		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
@@ -175,6 +178,9 @@ func NewService(
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterLabelAssigned(service.handleEventPullReqLabelAssigned)
+			_ = r.RegisterLabelUnassigned(service.handleEventPullReqLabelUnassigned)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
 
 			return nil
 		})
@@ -79,6 +79,16 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// PullReqLabelSegment contains details for all pull req label related payloads for webhooks.
+type PullReqLabelSegment struct {
+	LabelInfo LabelInfo `json:"label"`
+}
+
+// PullReqReviewSegment contains details for all pull req review related payloads for webhooks.
+type PullReqReviewSegment struct {
+	ReviewInfo ReviewInfo `json:"review"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -269,3 +279,24 @@ type CommentInfo struct {
 	ParentID *int64 `json:"parent_id,omitempty"`
 	Text     string `json:"text"`
 }
+
+// ReviewInfo describes the pull request review related info for a webhook payload.
+type ReviewInfo struct {
+	Decision enum.PullReqReviewDecision `json:"decision"`
+	Comments []CommentInfo              `json:"comments"`
+}
+
+// LabelInfo describes the label related info for a webhook payload.
+type LabelInfo struct {
+	ID    int64           `json:"id"`
+	Name  string          `json:"name"`
+	Color enum.LabelColor `json:"color"`
+}
+
+func labelInfoFrom(label *types.Label) LabelInfo {
+	return LabelInfo{
+		ID:    label.ID,
+		Name:  label.Name,
+		Color: label.Color,
+	}
+}
@@ -46,8 +46,9 @@ func ProvideService(ctx context.Context,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	labelStore store.LabelStore,
 ) (*Service, error) {
 	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
 		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+		urlProvider, principalStore, git, encrypter, labelStore)
 }
@@ -42,6 +42,9 @@ type (
 		// FindByEmail finds the principal by email.
 		FindByEmail(ctx context.Context, email string) (*types.Principal, error)
 
+		// FindManyByEmail returns all principals found for the provided emails.
+		FindManyByEmail(ctx context.Context, emails []string) ([]*types.Principal, error)
+
 		/*
 		 * USER RELATED OPERATIONS.
 		 */
@@ -133,6 +136,184 @@ type (
 		FindMany(ctx context.Context, ids []int64) ([]*types.PrincipalInfo, error)
 	}
 
+	// PublicKeyStore defines the public key data storage.
+	PublicKeyStore interface {
+		// FindByIdentifier finds the public key of a principal by its identifier.
+		FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.PublicKey, error)
+
+		// FindByFingerprint finds the public key with the provided usage by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string, usage enum.PublicKeyUsage) (*types.PublicKey, error)
+
+		// ListByFingerprints returns the public keys with the provided usage for the provided fingerprints.
+		ListByFingerprints(
+			ctx context.Context,
+			fingerprints []string,
+			usage enum.PublicKeyUsage,
+		) ([]types.PublicKey, error)
+
+		// Create saves a new public key.
+		Create(ctx context.Context, publicKey *types.PublicKey) error
+
+		// DeleteByIdentifier deletes the public key of a principal by its identifier.
+		DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error
+
+		// MarkAsUsed updates the last used timestamp of the public key.
+		MarkAsUsed(ctx context.Context, id int64) error
+
+		// Count returns the number of public keys of a principal.
+		Count(ctx context.Context, principalID int64, filter types.ListQueryFilter) (int64, error)
+
+		// List returns the public keys of a principal.
+		List(ctx context.Context, principalID int64, filter types.ListQueryFilter) ([]types.PublicKey, error)
+	}
+
+	// GPGKeyStore defines the gpg key data storage.
+	GPGKeyStore interface {
+		// FindByFingerprint finds the gpg key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.GPGKey, error)
+
+		// Create saves a new gpg key.
+		Create(ctx context.Context, key *types.GPGKey) error
+
+		// DeleteByKeyID deletes the gpg key of a principal by its key id.
+		DeleteByKeyID(ctx context.Context, principalID int64, keyID string) error
+
+		// List returns all gpg keys of a principal.
+		List(ctx context.Context, principalID int64) ([]types.GPGKey, error)
+
+		// ListByPrincipalIDs returns all gpg keys of the provided principals.
+		ListByPrincipalIDs(ctx context.Context, principalIDs []int64) ([]types.GPGKey, error)
+	}
+
+	// SigningKeyStore defines the data storage of the keys used by the server to sign git objects.
+	SigningKeyStore interface {
+		// FindByType finds the signing key of the provided type.
+		FindByType(ctx context.Context, keyType enum.GitSignatureType) (*types.SigningKey, error)
+
+		// Create saves a new signing key.
+		Create(ctx context.Context, key *types.SigningKey) error
+	}
+
+	// DeployKeyStore defines the deploy key data storage.
+	DeployKeyStore interface {
+		// Find finds the deploy key by id.
+		Find(ctx context.Context, id int64) (*types.DeployKey, error)
+
+		// FindByIdentifier finds the deploy key of a repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.DeployKey, error)
+
+		// FindByFingerprint finds the deploy key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.DeployKey, error)
+
+		// Create saves a new deploy key.
+		Create(ctx context.Context, deployKey *types.DeployKey) error
+
+		// DeleteByIdentifier deletes the deploy key of a repository by its identifier.
+		DeleteByIdentifier(ctx context.Context, repoID int64, identifier string) error
+
+		// MarkAsUsed updates the last used timestamp of the deploy key.
+		MarkAsUsed(ctx context.Context, id int64) error
+
+		// Count returns the number of deploy keys of a repository.
+		Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error)
+
+		// List returns the deploy keys of a repository.
+		List(ctx context.Context, repoID int64, filter types.ListQueryFilter) ([]types.DeployKey, error)
+	}
+
+	// LFSObjectStore defines the git lfs object storage.
+	LFSObjectStore interface {
+		// Find finds the lfs object of a repository by its oid.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds the lfs objects of a repository with the provided oids.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]types.LFSObject, error)
+
+		// Create saves a new lfs object.
+		Create(ctx context.Context, obj *types.LFSObject) error
+
+		// GetSizeInKBByRepoID returns the total size of all lfs objects of a repository in KiB.
+		GetSizeInKBByRepoID(ctx context.Context, repoID int64) (int64, error)
+	}
+
+	// LFSLockStore defines the git lfs lock storage.
+	LFSLockStore interface {
+		// Find finds the lfs lock of a repository by its id.
+		Find(ctx context.Context, repoID int64, id int64) (*types.LFSLock, error)
+
+		// Create saves a new lfs lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes the lfs lock of a repository by its id.
+		Delete(ctx context.Context, repoID int64, id int64) error
+
+		// List returns the lfs locks of a repository.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]types.LFSLock, error)
+	}
+
+	// PullMirrorStore defines the data storage of the pull mirror repositories.
+	PullMirrorStore interface {
+		// Find finds the pull mirror configuration of a repository.
+		Find(ctx context.Context, repoID int64) (*types.PullMirror, error)
+
+		// Create saves a new pull mirror configuration.
+		Create(ctx context.Context, mirror *types.PullMirror) error
+
+		// Update updates the remote URL, the credentials and the schedule of a pull mirror.
+		Update(ctx context.Context, mirror *types.PullMirror) error
+
+		// UpdateSyncState updates the outcome of the latest synchronization of a pull mirror.
+		UpdateSyncState(ctx context.Context, mirror *types.PullMirror) error
+	}
+
+	// PushMirrorStore defines the data storage of the push mirrors of repositories.
+	PushMirrorStore interface {
+		// Find finds the push mirror by id.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// FindByIdentifier finds the push mirror of a repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error)
+
+		// Create saves a new push mirror.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates the remote URL, the credentials and the refs of a push mirror.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateResult updates the outcome of the latest push to a push mirror.
+		UpdateResult(ctx context.Context, mirror *types.PushMirror) error
+
+		// Delete deletes the push mirror by id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all push mirrors of a repository.
+		List(ctx context.Context, repoID int64) ([]types.PushMirror, error)
+	}
+
+	// MergeQueueStore defines the merge queue entry storage.
+	MergeQueueStore interface {
+		// Find finds the merge queue entry by id.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a new entry to the end of the merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the speculative merge commit of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the entry from the merge queue.
+		Delete(ctx context.Context, id int64) error
+
+		// ListForBranch returns the merge queue of a branch in the order the entries were added.
+		ListForBranch(ctx context.Context, repoID int64, branch string) ([]types.MergeQueueEntry, error)
+
+		// List returns the entries of all merge queues ordered by repository, branch and queue position.
+		List(ctx context.Context) ([]types.MergeQueueEntry, error)
+	}
+
 	// SpacePathStore defines the path data storage for spaces.
 	SpacePathStore interface {
 		// InsertSegment inserts a space path segment to the table.
@@ -237,6 +418,15 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// CountForks counts the active forks of a repo. With "DeletedBeforeOrAt" filter, counts deleted forks.
+		CountForks(ctx context.Context, repoID int64, opts *types.RepoFilter) (int64, error)
+
+		// ListForks returns the active forks of a repo. With "DeletedBeforeOrAt" filter, lists deleted forks.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
+		// ListForksWithDeleted returns all forks of a repo, including the deleted ones.
+		ListForksWithDeleted(ctx context.Context, repoID int64) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -319,6 +509,10 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// ListAutoMerge returns open pull requests in the target repository
+		// with auto-merge enabled whose source branch points to the provided commit SHA.
+		ListAutoMerge(ctx context.Context, targetRepoID int64, sourceSHA string) ([]*types.PullReq, error)
 	}
 
 	PullReqActivityStore interface {
@@ -351,6 +545,9 @@ type (
 		// List returns a list of pull request activities in a pull request (a timeline).
 		List(ctx context.Context, prID int64, opts *types.PullReqActivityFilter) ([]*types.PullReqActivity, error)
 
+		// ListPending returns the pending review comments of the principal in a pull request.
+		ListPending(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqActivity, error)
+
 		// ListAuthorIDs returns a list of pull request activity author ids in a thread (order).
 		ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error)
 	}
@@ -380,6 +577,67 @@ type (
 		Create(ctx context.Context, v *types.PullReqReview) error
 	}
 
+	// PullReqDependencyStore defines the pull request dependency storage.
+	PullReqDependencyStore interface {
+		// Create creates a new dependency of a pull request on a parent pull request.
+		Create(ctx context.Context, v *types.PullReqDependency) error
+
+		// Delete deletes the dependency of a pull request on a parent pull request.
+		Delete(ctx context.Context, prID, parentID int64) error
+
+		// ListParentIDs returns IDs of all pull requests the pull request depends on.
+		ListParentIDs(ctx context.Context, prID int64) ([]int64, error)
+
+		// ListChildIDs returns IDs of all pull requests that depend on the pull request.
+		ListChildIDs(ctx context.Context, prID int64) ([]int64, error)
+	}
+
+	// PullReqLabelStore defines the pull request label assignment storage.
+	PullReqLabelStore interface {
+		// Assign assigns a label to a pull request.
+		Assign(ctx context.Context, v *types.PullReqLabel) error
+
+		// Unassign removes a label from a pull request.
+		Unassign(ctx context.Context, prID, labelID int64) error
+
+		// ListLabels returns all labels assigned to a pull request.
+		ListLabels(ctx context.Context, prID int64) ([]*types.Label, error)
+	}
+
+	// PullReqTextVersionStore defines the storage of the versions of pull request comments,
+	// titles and descriptions.
+	PullReqTextVersionStore interface {
+		// Create stores a new version of a pull request comment text or of a pull request title and description.
+		Create(ctx context.Context, v *types.PullReqTextVersion) error
+
+		// Count returns the number of versions of a pull request comment (activityID is set)
+		// or of a pull request title and description (activityID is nil).
+		Count(ctx context.Context, prID int64, activityID *int64) (int64, error)
+
+		// List returns the versions of a pull request comment (activityID is set)
+		// or of a pull request title and description (activityID is nil), the latest version first.
+		List(ctx context.Context,
+			prID int64, activityID *int64, opts types.Pagination) ([]*types.PullReqTextVersion, error)
+	}
+
+	// PullReqReactionStore defines the pull request reaction storage.
+	PullReqReactionStore interface {
+		// Create adds a new reaction to a pull request description or comment.
+		Create(ctx context.Context, v *types.PullReqReaction) error
+
+		// Delete removes a reaction of a principal from a pull request description (activityID is nil) or comment.
+		Delete(ctx context.Context,
+			prID int64, activityID *int64, principalID int64, reactionType enum.ReactionType) error
+
+		// Summary returns the aggregated reactions to a pull request description (activityID is nil) or comment.
+		Summary(ctx context.Context,
+			prID int64, activityID *int64, principalID int64) ([]types.ReactionSummary, error)
+
+		// SummaryPerActivity returns the aggregated reactions to all comments of a pull request.
+		SummaryPerActivity(ctx context.Context,
+			prID int64, principalID int64) (map[int64][]types.ReactionSummary, error)
+	}
+
 	// PullReqReviewerStore defines the pull request reviewer storage.
 	PullReqReviewerStore interface {
 		// Find returns the pull request reviewer or an error if it doesn't exist.
@@ -443,6 +701,27 @@ type (
 		ListAllRepoRules(ctx context.Context, repoID int64) ([]types.RuleInfoInternal, error)
 	}
 
+	// LabelStore defines the label storage.
+	LabelStore interface {
+		// Find finds the label by ID.
+		Find(ctx context.Context, id int64) (*types.Label, error)
+
+		// FindByName finds the label by parent ID and name.
+		FindByName(ctx context.Context, spaceID, repoID *int64, name string) (*types.Label, error)
+
+		// Create creates a new label.
+		Create(ctx context.Context, label *types.Label) error
+
+		// Update updates an existing label.
+		Update(ctx context.Context, label *types.Label) error
+
+		// Delete deletes the label by ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns labels defined in any of the provided spaces or in the provided repository.
+		List(ctx context.Context, spaceIDs []int64, repoID *int64, filter *types.LabelFilter) ([]*types.Label, error)
+	}
+
 	// WebhookStore defines the webhook data storage.
 	WebhookStore interface {
 		// Find finds the webhook by id.
@@ -0,0 +1,317 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.DeployKeyStore = (*DeployKeyStore)(nil)
+
+// NewDeployKeyStore returns a new DeployKeyStore.
+func NewDeployKeyStore(db *sqlx.DB) *DeployKeyStore {
+	return &DeployKeyStore{
+		db: db,
+	}
+}
+
+// DeployKeyStore implements a store.DeployKeyStore backed by a relational database.
+type DeployKeyStore struct {
+	db *sqlx.DB
+}
+
+type deployKey struct {
+	ID         int64    `db:"deploy_key_id"`
+	RepoID     int64    `db:"deploy_key_repo_id"`
+	Identifier string   `db:"deploy_key_identifier"`
+	Created    int64    `db:"deploy_key_created"`
+	CreatedBy  int64    `db:"deploy_key_created_by"`
+	LastUsed   null.Int `db:"deploy_key_last_used"`
+	ReadOnly   bool     `db:"deploy_key_read_only"`
+
+	Fingerprint string `db:"deploy_key_fingerprint"`
+	Content     string `db:"deploy_key_content"`
+	Comment     string `db:"deploy_key_comment"`
+	Type        string `db:"deploy_key_type"`
+}
+
+const (
+	deployKeyColumns = `
+		 deploy_key_id
+		,deploy_key_repo_id
+		,deploy_key_identifier
+		,deploy_key_created
+		,deploy_key_created_by
+		,deploy_key_last_used
+		,deploy_key_read_only
+		,deploy_key_fingerprint
+		,deploy_key_content
+		,deploy_key_comment
+		,deploy_key_type`
+
+	deployKeySelectBase = `
+	SELECT` + deployKeyColumns + `
+	FROM deploy_keys`
+)
+
+// Find finds the deploy key by id.
+func (s *DeployKeyStore) Find(ctx context.Context, id int64) (*types.DeployKey, error) {
+	sqlQuery := deployKeySelectBase + `
+	WHERE deploy_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &deployKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key")
+	}
+
+	return mapToDeployKey(dst), nil
+}
+
+// FindByIdentifier finds the deploy key of a repository by its identifier.
+func (s *DeployKeyStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.DeployKey, error) {
+	sqlQuery := deployKeySelectBase + `
+	WHERE deploy_key_repo_id = $1 AND LOWER(deploy_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &deployKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key by identifier")
+	}
+
+	return mapToDeployKey(dst), nil
+}
+
+// FindByFingerprint finds the deploy key by its fingerprint.
+func (s *DeployKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.DeployKey, error) {
+	sqlQuery := deployKeySelectBase + `
+	WHERE deploy_key_fingerprint = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &deployKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, fingerprint); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key by fingerprint")
+	}
+
+	return mapToDeployKey(dst), nil
+}
+
+// Create saves a new deploy key.
+func (s *DeployKeyStore) Create(ctx context.Context, deployKey *types.DeployKey) error {
+	const sqlQuery = `
+		INSERT INTO deploy_keys (
+			 deploy_key_repo_id
+			,deploy_key_identifier
+			,deploy_key_created
+			,deploy_key_created_by
+			,deploy_key_last_used
+			,deploy_key_read_only
+			,deploy_key_fingerprint
+			,deploy_key_content
+			,deploy_key_comment
+			,deploy_key_type
+		) values (
+			 :deploy_key_repo_id
+			,:deploy_key_identifier
+			,:deploy_key_created
+			,:deploy_key_created_by
+			,:deploy_key_last_used
+			,:deploy_key_read_only
+			,:deploy_key_fingerprint
+			,:deploy_key_content
+			,:deploy_key_comment
+			,:deploy_key_type
+		) RETURNING deploy_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbDeployKey := mapToInternalDeployKey(deployKey)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbDeployKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind deploy key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&deployKey.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert deploy key query failed")
+	}
+
+	return nil
+}
+
+// DeleteByIdentifier deletes the deploy key of a repository by its identifier.
+func (s *DeployKeyStore) DeleteByIdentifier(ctx context.Context, repoID int64, identifier string) error {
+	const sqlQuery = `
+		DELETE FROM deploy_keys
+		WHERE deploy_key_repo_id = $1 AND LOWER(deploy_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, strings.ToLower(identifier))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete deploy key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted deploy keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAsUsed updates the last used timestamp of the deploy key.
+func (s *DeployKeyStore) MarkAsUsed(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		UPDATE deploy_keys
+		SET deploy_key_last_used = $1
+		WHERE deploy_key_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark deploy key as used")
+	}
+
+	return nil
+}
+
+// Count returns the number of deploy keys of a repository.
+func (s *DeployKeyStore) Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("deploy_keys").
+		Where("deploy_key_repo_id = ?", repoID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count deploy keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count deploy keys query")
+	}
+
+	return count, nil
+}
+
+// List returns the deploy keys of a repository.
+func (s *DeployKeyStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter types.ListQueryFilter,
+) ([]types.DeployKey, error) {
+	stmt := database.Builder.
+		Select(deployKeyColumns).
+		From("deploy_keys").
+		Where("deploy_key_repo_id = ?", repoID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("deploy_key_created DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list deploy keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]deployKey, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list deploy keys query")
+	}
+
+	return mapToDeployKeys(dst), nil
+}
+
+func (*DeployKeyStore) applyQuery(stmt squirrel.SelectBuilder, query string) squirrel.SelectBuilder {
+	if query == "" {
+		return stmt
+	}
+
+	return stmt.Where("LOWER(deploy_key_identifier) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+}
+
+func mapToInternalDeployKey(in *types.DeployKey) deployKey {
+	return deployKey{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		CreatedBy:   in.CreatedBy,
+		LastUsed:    null.IntFromPtr(in.LastUsed),
+		ReadOnly:    in.ReadOnly,
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+		Comment:     in.Comment,
+		Type:        in.Type,
+	}
+}
+
+func mapToDeployKey(in *deployKey) *types.DeployKey {
+	return &types.DeployKey{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		CreatedBy:   in.CreatedBy,
+		LastUsed:    in.LastUsed.Ptr(),
+		ReadOnly:    in.ReadOnly,
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+		Comment:     in.Comment,
+		Type:        in.Type,
+	}
+}
+
+func mapToDeployKeys(deployKeys []deployKey) []types.DeployKey {
+	res := make([]types.DeployKey, len(deployKeys))
+	for i := range deployKeys {
+		res[i] = *mapToDeployKey(&deployKeys[i])
+	}
+	return res
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.GPGKeyStore = (*GPGKeyStore)(nil)
+
+// NewGPGKeyStore returns a new GPGKeyStore.
+func NewGPGKeyStore(db *sqlx.DB) *GPGKeyStore {
+	return &GPGKeyStore{
+		db: db,
+	}
+}
+
+// GPGKeyStore implements a store.GPGKeyStore backed by a relational database.
+type GPGKeyStore struct {
+	db *sqlx.DB
+}
+
+type gpgKey struct {
+	ID          int64  `db:"gpg_key_id"`
+	PrincipalID int64  `db:"gpg_key_principal_id"`
+	Created     int64  `db:"gpg_key_created"`
+	KeyID       string `db:"gpg_key_key_id"`
+	Fingerprint string `db:"gpg_key_fingerprint"`
+	Emails      string `db:"gpg_key_emails"`
+	Content     string `db:"gpg_key_content"`
+}
+
+const (
+	gpgKeyColumns = `
+		 gpg_key_id
+		,gpg_key_principal_id
+		,gpg_key_created
+		,gpg_key_key_id
+		,gpg_key_fingerprint
+		,gpg_key_emails
+		,gpg_key_content`
+
+	gpgKeySelectBase = `
+	SELECT` + gpgKeyColumns + `
+	FROM gpg_keys`
+)
+
+// FindByFingerprint finds the gpg key by its fingerprint.
+func (s *GPGKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.GPGKey, error) {
+	sqlQuery := gpgKeySelectBase + `
+	WHERE gpg_key_fingerprint = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &gpgKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToUpper(fingerprint)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find gpg key by fingerprint")
+	}
+
+	return mapToGPGKey(dst)
+}
+
+// Create saves a new gpg key.
+func (s *GPGKeyStore) Create(ctx context.Context, key *types.GPGKey) error {
+	const sqlQuery = `
+		INSERT INTO gpg_keys (
+			 gpg_key_principal_id
+			,gpg_key_created
+			,gpg_key_key_id
+			,gpg_key_fingerprint
+			,gpg_key_emails
+			,gpg_key_content
+		) values (
+			 :gpg_key_principal_id
+			,:gpg_key_created
+			,:gpg_key_key_id
+			,:gpg_key_fingerprint
+			,:gpg_key_emails
+			,:gpg_key_content
+		) RETURNING gpg_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbGPGKey, err := mapToInternalGPGKey(key)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbGPGKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind gpg key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert gpg key query failed")
+	}
+
+	return nil
+}
+
+// DeleteByKeyID deletes the gpg key of a principal by its key id.
+func (s *GPGKeyStore) DeleteByKeyID(ctx context.Context, principalID int64, keyID string) error {
+	const sqlQuery = `
+		DELETE FROM gpg_keys
+		WHERE gpg_key_principal_id = $1 AND gpg_key_key_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, strings.ToUpper(keyID))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete gpg key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted gpg keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all gpg keys of a principal.
+func (s *GPGKeyStore) List(ctx context.Context, principalID int64) ([]types.GPGKey, error) {
+	sqlQuery := gpgKeySelectBase + `
+	WHERE gpg_key_principal_id = $1
+	ORDER BY gpg_key_created DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]gpgKey, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list gpg keys query")
+	}
+
+	return mapToGPGKeys(dst)
+}
+
+// ListByPrincipalIDs returns all gpg keys of the provided principals.
+func (s *GPGKeyStore) ListByPrincipalIDs(ctx context.Context, principalIDs []int64) ([]types.GPGKey, error) {
+	stmt := database.Builder.
+		Select(gpgKeyColumns).
+		From("gpg_keys").
+		Where(squirrel.Eq{"gpg_key_principal_id": principalIDs}).
+		OrderBy("gpg_key_created DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list gpg keys by principal ids query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]gpgKey, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list gpg keys by principal ids query")
+	}
+
+	return mapToGPGKeys(dst)
+}
+
+func mapToInternalGPGKey(in *types.GPGKey) (gpgKey, error) {
+	emails, err := json.Marshal(in.Emails)
+	if err != nil {
+		return gpgKey{}, fmt.Errorf("failed to marshal gpg key emails: %w", err)
+	}
+
+	return gpgKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		KeyID:       strings.ToUpper(in.KeyID),
+		Fingerprint: strings.ToUpper(in.Fingerprint),
+		Emails:      string(emails),
+		Content:     in.Content,
+	}, nil
+}
+
+func mapToGPGKey(in *gpgKey) (*types.GPGKey, error) {
+	var emails []string
+	if err := json.Unmarshal([]byte(in.Emails), &emails); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gpg key emails: %w", err)
+	}
+
+	return &types.GPGKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		KeyID:       in.KeyID,
+		Fingerprint: in.Fingerprint,
+		Emails:      emails,
+		Content:     in.Content,
+	}, nil
+}
+
+func mapToGPGKeys(gpgKeys []gpgKey) ([]types.GPGKey, error) {
+	res := make([]types.GPGKey, len(gpgKeys))
+	for i := range gpgKeys {
+		key, err := mapToGPGKey(&gpgKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = *key
+	}
+	return res, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSLockStore = (*LFSLockStore)(nil)
+
+// NewLFSLockStore returns a new LFSLockStore.
+func NewLFSLockStore(db *sqlx.DB) *LFSLockStore {
+	return &LFSLockStore{
+		db: db,
+	}
+}
+
+// LFSLockStore implements a store.LFSLockStore backed by a relational database.
+type LFSLockStore struct {
+	db *sqlx.DB
+}
+
+type lfsLock struct {
+	ID        int64  `db:"lfs_lock_id"`
+	RepoID    int64  `db:"lfs_lock_repo_id"`
+	Path      string `db:"lfs_lock_path"`
+	Ref       string `db:"lfs_lock_ref"`
+	Created   int64  `db:"lfs_lock_created"`
+	CreatedBy int64  `db:"lfs_lock_created_by"`
+}
+
+const (
+	lfsLockColumns = `
+		 lfs_lock_id
+		,lfs_lock_repo_id
+		,lfs_lock_path
+		,lfs_lock_ref
+		,lfs_lock_created
+		,lfs_lock_created_by`
+
+	lfsLockSelectBase = `
+	SELECT` + lfsLockColumns + `
+	FROM lfs_locks`
+)
+
+// Find finds the lfs lock of a repository by its id.
+func (s *LFSLockStore) Find(ctx context.Context, repoID int64, id int64) (*types.LFSLock, error) {
+	sqlQuery := lfsLockSelectBase + `
+	WHERE lfs_lock_repo_id = $1 AND lfs_lock_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find lfs lock")
+	}
+
+	return mapToLFSLock(dst), nil
+}
+
+// Create saves a new lfs lock.
+func (s *LFSLockStore) Create(ctx context.Context, lock *types.LFSLock) error {
+	const sqlQuery = `
+		INSERT INTO lfs_locks (
+			 lfs_lock_repo_id
+			,lfs_lock_path
+			,lfs_lock_ref
+			,lfs_lock_created
+			,lfs_lock_created_by
+		) values (
+			 :lfs_lock_repo_id
+			,:lfs_lock_path
+			,:lfs_lock_ref
+			,:lfs_lock_created
+			,:lfs_lock_created_by
+		) RETURNING lfs_lock_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLFSLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind lfs lock")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&lock.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert lfs lock query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the lfs lock of a repository by its id.
+func (s *LFSLockStore) Delete(ctx context.Context, repoID int64, id int64) error {
+	const sqlQuery = `
+		DELETE FROM lfs_locks
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete lfs lock query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted lfs locks")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the lfs locks of a repository.
+func (s *LFSLockStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID)
+
+	if filter.ID != 0 {
+		stmt = stmt.Where("lfs_lock_id = ?", filter.ID)
+	}
+
+	if filter.Path != "" {
+		stmt = stmt.Where("lfs_lock_path = ?", filter.Path)
+	}
+
+	if filter.Ref != "" {
+		stmt = stmt.Where("lfs_lock_ref = ?", filter.Ref)
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("lfs_lock_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list lfs locks query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]lfsLock, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list lfs locks query")
+	}
+
+	return mapToLFSLocks(dst), nil
+}
+
+func mapToInternalLFSLock(in *types.LFSLock) *lfsLock {
+	return &lfsLock{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Path:      in.Path,
+		Ref:       in.Ref,
+		Created:   in.Created,
+		CreatedBy: in.CreatedBy,
+	}
+}
+
+func mapToLFSLock(in *lfsLock) *types.LFSLock {
+	return &types.LFSLock{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Path:      in.Path,
+		Ref:       in.Ref,
+		Created:   in.Created,
+		CreatedBy: in.CreatedBy,
+	}
+}
+
+func mapToLFSLocks(locks []lfsLock) []types.LFSLock {
+	res := make([]types.LFSLock, len(locks))
+	for i := range locks {
+		res[i] = *mapToLFSLock(&locks[i])
+	}
+	return res
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSObjectStore = (*LFSObjectStore)(nil)
+
+// NewLFSObjectStore returns a new LFSObjectStore.
+func NewLFSObjectStore(db *sqlx.DB) *LFSObjectStore {
+	return &LFSObjectStore{
+		db: db,
+	}
+}
+
+// LFSObjectStore implements a store.LFSObjectStore backed by a relational database.
+type LFSObjectStore struct {
+	db *sqlx.DB
+}
+
+type lfsObject struct {
+	ID        int64  `db:"lfs_object_id"`
+	RepoID    int64  `db:"lfs_object_repo_id"`
+	OID       string `db:"lfs_object_oid"`
+	Size      int64  `db:"lfs_object_size"`
+	Created   int64  `db:"lfs_object_created"`
+	CreatedBy int64  `db:"lfs_object_created_by"`
+}
+
+const (
+	lfsObjectColumns = `
+		 lfs_object_id
+		,lfs_object_repo_id
+		,lfs_object_oid
+		,lfs_object_size
+		,lfs_object_created
+		,lfs_object_created_by`
+
+	lfsObjectSelectBase = `
+	SELECT` + lfsObjectColumns + `
+	FROM lfs_objects`
+)
+
+// Find finds the lfs object of a repository by its oid.
+func (s *LFSObjectStore) Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error) {
+	sqlQuery := lfsObjectSelectBase + `
+	WHERE lfs_object_repo_id = $1 AND lfs_object_oid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsObject{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, oid); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find lfs object")
+	}
+
+	return mapToLFSObject(dst), nil
+}
+
+// FindMany finds the lfs objects of a repository with the provided oids.
+func (s *LFSObjectStore) FindMany(ctx context.Context, repoID int64, oids []string) ([]types.LFSObject, error) {
+	stmt := database.Builder.
+		Select(lfsObjectColumns).
+		From("lfs_objects").
+		Where("lfs_object_repo_id = ?", repoID).
+		Where(squirrel.Eq{"lfs_object_oid": oids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find many lfs objects query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]lfsObject, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing find many lfs objects query")
+	}
+
+	return mapToLFSObjects(dst), nil
+}
+
+// Create saves a new lfs object.
+func (s *LFSObjectStore) Create(ctx context.Context, obj *types.LFSObject) error {
+	const sqlQuery = `
+		INSERT INTO lfs_objects (
+			 lfs_object_repo_id
+			,lfs_object_oid
+			,lfs_object_size
+			,lfs_object_created
+			,lfs_object_created_by
+		) values (
+			 :lfs_object_repo_id
+			,:lfs_object_oid
+			,:lfs_object_size
+			,:lfs_object_created
+			,:lfs_object_created_by
+		) RETURNING lfs_object_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLFSObject(obj))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind lfs object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&obj.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert lfs object query failed")
+	}
+
+	return nil
+}
+
+// GetSizeInKBByRepoID returns the total size of all lfs objects of a repository in KiB.
+func (s *LFSObjectStore) GetSizeInKBByRepoID(ctx context.Context, repoID int64) (int64, error) {
+	const sqlQuery = `
+		SELECT COALESCE(SUM(lfs_object_size), 0)
+		FROM lfs_objects
+		WHERE lfs_object_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var size int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID).Scan(&size); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get lfs objects size")
+	}
+
+	return size / 1024, nil
+}
+
+func mapToInternalLFSObject(in *types.LFSObject) *lfsObject {
+	return &lfsObject{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		OID:       in.OID,
+		Size:      in.Size,
+		Created:   in.Created,
+		CreatedBy: in.CreatedBy,
+	}
+}
+
+func mapToLFSObject(in *lfsObject) *types.LFSObject {
+	return &types.LFSObject{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		OID:       in.OID,
+		Size:      in.Size,
+		Created:   in.Created,
+		CreatedBy: in.CreatedBy,
+	}
+}
+
+func mapToLFSObjects(objs []lfsObject) []types.LFSObject {
+	res := make([]types.LFSObject, len(objs))
+	for i := range objs {
+		res[i] = *mapToLFSObject(&objs[i])
+	}
+	return res
+}
@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64            `db:"merge_queue_entry_id"`
+	RepoID        int64            `db:"merge_queue_entry_repo_id"`
+	PullReqID     int64            `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64            `db:"merge_queue_entry_pullreq_number"`
+	Branch        string           `db:"merge_queue_entry_branch"`
+	SourceSHA     string           `db:"merge_queue_entry_source_sha"`
+	Method        enum.MergeMethod `db:"merge_queue_entry_method"`
+	Title         string           `db:"merge_queue_entry_title"`
+	Message       string           `db:"merge_queue_entry_message"`
+	BaseSHA       string           `db:"merge_queue_entry_base_sha"`
+	MergeBaseSHA  string           `db:"merge_queue_entry_merge_base_sha"`
+	MergeSHA      string           `db:"merge_queue_entry_merge_sha"`
+	Created       int64            `db:"merge_queue_entry_created"`
+	Updated       int64            `db:"merge_queue_entry_updated"`
+	CreatedBy     int64            `db:"merge_queue_entry_created_by"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_pullreq_number
+		,merge_queue_entry_branch
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_created_by`
+
+	mergeQueueEntrySelectBase = `
+	SELECT` + mergeQueueEntryColumns + `
+	FROM merge_queue_entries`
+)
+
+// Find finds the merge queue entry by id.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullreqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry by pull request id")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// Create adds a new entry to the end of the merge queue.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_pullreq_number
+			,merge_queue_entry_branch
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+			,merge_queue_entry_created_by
+		) values (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_pullreq_number
+			,:merge_queue_entry_branch
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+			,:merge_queue_entry_created_by
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the speculative merge commit of a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_updated = :merge_queue_entry_updated
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha = :merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+		WHERE merge_queue_entry_id = :merge_queue_entry_id`
+
+	entry.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the entry from the merge queue.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListForBranch returns the merge queue of a branch in the order the entries were added.
+func (s *MergeQueueStore) ListForBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_branch = $2
+	ORDER BY merge_queue_entry_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]mergeQueueEntry, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list merge queue entries query")
+	}
+
+	return mapToMergeQueueEntries(dst), nil
+}
+
+// List returns the entries of all merge queues ordered by repository, branch and queue position.
+func (s *MergeQueueStore) List(ctx context.Context) ([]types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	ORDER BY merge_queue_entry_repo_id, merge_queue_entry_branch, merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]mergeQueueEntry, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list all merge queue entries query")
+	}
+
+	return mapToMergeQueueEntries(dst), nil
+}
+
+func mapToInternalMergeQueueEntry(in *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		PullReqID:     in.PullReqID,
+		PullReqNumber: in.PullReqNumber,
+		Branch:        in.Branch,
+		SourceSHA:     in.SourceSHA,
+		Method:        in.Method,
+		Title:         in.Title,
+		Message:       in.Message,
+		BaseSHA:       in.BaseSHA,
+		MergeBaseSHA:  in.MergeBaseSHA,
+		MergeSHA:      in.MergeSHA,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		CreatedBy:     in.CreatedBy,
+	}
+}
+
+func mapToMergeQueueEntry(in *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		PullReqID:     in.PullReqID,
+		PullReqNumber: in.PullReqNumber,
+		Branch:        in.Branch,
+		SourceSHA:     in.SourceSHA,
+		Method:        in.Method,
+		Title:         in.Title,
+		Message:       in.Message,
+		BaseSHA:       in.BaseSHA,
+		MergeBaseSHA:  in.MergeBaseSHA,
+		MergeSHA:      in.MergeSHA,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		CreatedBy:     in.CreatedBy,
+	}
+}
+
+func mapToMergeQueueEntries(entries []mergeQueueEntry) []types.MergeQueueEntry {
+	res := make([]types.MergeQueueEntry, len(entries))
+	for i := range entries {
+		res[i] = *mapToMergeQueueEntry(&entries[i])
+	}
+	return res
+}
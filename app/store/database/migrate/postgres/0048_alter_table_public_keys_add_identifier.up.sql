@@ -0,0 +1,13 @@
+ALTER TABLE public_keys
+    ADD COLUMN public_key_identifier TEXT NOT NULL DEFAULT '',
+    ADD COLUMN public_key_last_used BIGINT;
+
+-- existing keys get a unique identifier derived from their id.
+UPDATE public_keys SET public_key_identifier = 'key-' || public_key_id;
+
+-- drop index that's covered by the new unique index.
+DROP INDEX public_keys_principal_id;
+
+-- create explicit unique index with case insensitivity
+CREATE UNIQUE INDEX public_keys_principal_id_identifier
+    ON public_keys(public_key_principal_id, LOWER(public_key_identifier));
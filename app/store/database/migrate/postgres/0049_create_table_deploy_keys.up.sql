@@ -0,0 +1,27 @@
+CREATE TABLE deploy_keys (
+ deploy_key_id SERIAL PRIMARY KEY
+,deploy_key_repo_id INTEGER NOT NULL
+,deploy_key_identifier TEXT NOT NULL
+,deploy_key_created BIGINT NOT NULL
+,deploy_key_created_by INTEGER NOT NULL
+,deploy_key_last_used BIGINT
+,deploy_key_read_only BOOLEAN NOT NULL
+,deploy_key_fingerprint TEXT NOT NULL
+,deploy_key_content TEXT NOT NULL
+,deploy_key_comment TEXT NOT NULL
+,deploy_key_type TEXT NOT NULL
+,CONSTRAINT fk_deploy_key_repo_id FOREIGN KEY (deploy_key_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_deploy_key_created_by FOREIGN KEY (deploy_key_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX deploy_keys_fingerprint
+    ON deploy_keys(deploy_key_fingerprint);
+
+CREATE UNIQUE INDEX deploy_keys_repo_id_identifier
+    ON deploy_keys(deploy_key_repo_id, LOWER(deploy_key_identifier));
@@ -0,0 +1,9 @@
+DELETE FROM public_keys WHERE public_key_usage <> 'auth';
+
+DROP INDEX public_keys_fingerprint_usage;
+
+CREATE UNIQUE INDEX public_keys_fingerprint
+    ON public_keys(public_key_fingerprint);
+
+ALTER TABLE public_keys
+    DROP COLUMN public_key_usage;
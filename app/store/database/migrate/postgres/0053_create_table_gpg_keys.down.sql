@@ -0,0 +1 @@
+DROP TABLE gpg_keys;
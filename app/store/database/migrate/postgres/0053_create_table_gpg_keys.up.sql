@@ -0,0 +1,19 @@
+CREATE TABLE gpg_keys (
+ gpg_key_id SERIAL PRIMARY KEY
+,gpg_key_principal_id INTEGER NOT NULL
+,gpg_key_created BIGINT NOT NULL
+,gpg_key_key_id TEXT NOT NULL
+,gpg_key_fingerprint TEXT NOT NULL
+,gpg_key_emails TEXT NOT NULL
+,gpg_key_content TEXT NOT NULL
+,CONSTRAINT fk_gpg_key_principal_id FOREIGN KEY (gpg_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX gpg_keys_fingerprint
+    ON gpg_keys(gpg_key_fingerprint);
+
+CREATE INDEX gpg_keys_principal_id
+    ON gpg_keys(gpg_key_principal_id);
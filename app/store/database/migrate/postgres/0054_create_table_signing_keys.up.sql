@@ -0,0 +1,11 @@
+CREATE TABLE signing_keys (
+ signing_key_id SERIAL PRIMARY KEY
+,signing_key_type TEXT NOT NULL
+,signing_key_created BIGINT NOT NULL
+,signing_key_fingerprint TEXT NOT NULL
+,signing_key_public_key TEXT NOT NULL
+,signing_key_private_key BYTEA NOT NULL
+);
+
+CREATE UNIQUE INDEX signing_keys_type
+    ON signing_keys(signing_key_type);
@@ -0,0 +1,4 @@
+DROP TABLE pull_mirrors;
+
+ALTER TABLE repositories
+    DROP COLUMN repo_is_mirror;
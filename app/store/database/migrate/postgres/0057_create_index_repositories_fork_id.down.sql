@@ -0,0 +1 @@
+DROP INDEX repositories_fork_id;
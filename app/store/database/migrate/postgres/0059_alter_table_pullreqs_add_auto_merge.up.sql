@@ -0,0 +1,3 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_auto_merge_method TEXT,
+    ADD COLUMN pullreq_auto_merge_by INTEGER;
@@ -0,0 +1,6 @@
+ALTER TABLE pullreq_activities
+    ADD COLUMN pullreq_activity_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX pullreq_activities_pending_pullreq_id_created_by
+    ON pullreq_activities(pullreq_activity_pullreq_id, pullreq_activity_created_by)
+    WHERE pullreq_activity_pending;
@@ -0,0 +1,29 @@
+CREATE TABLE pullreq_text_versions (
+ pullreq_text_version_id SERIAL PRIMARY KEY
+,pullreq_text_version_pullreq_id INTEGER NOT NULL
+,pullreq_text_version_activity_id INTEGER
+,pullreq_text_version_title TEXT NOT NULL
+,pullreq_text_version_text TEXT NOT NULL
+,pullreq_text_version_created BIGINT NOT NULL
+,pullreq_text_version_created_by INTEGER
+,CONSTRAINT fk_pullreq_text_version_pullreq_id FOREIGN KEY (pullreq_text_version_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_text_version_activity_id FOREIGN KEY (pullreq_text_version_activity_id)
+    REFERENCES pullreq_activities (pullreq_activity_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_text_version_created_by FOREIGN KEY (pullreq_text_version_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX pullreq_text_versions_pullreq_id
+    ON pullreq_text_versions(pullreq_text_version_pullreq_id)
+    WHERE pullreq_text_version_activity_id IS NULL;
+
+CREATE INDEX pullreq_text_versions_activity_id
+    ON pullreq_text_versions(pullreq_text_version_activity_id)
+    WHERE pullreq_text_version_activity_id IS NOT NULL;
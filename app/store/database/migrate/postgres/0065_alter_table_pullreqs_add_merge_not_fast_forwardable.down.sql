@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs
+    DROP COLUMN pullreq_merge_not_fast_forwardable;
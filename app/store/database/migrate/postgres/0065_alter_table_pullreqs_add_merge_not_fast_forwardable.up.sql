@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_merge_not_fast_forwardable BOOLEAN NOT NULL DEFAULT FALSE;
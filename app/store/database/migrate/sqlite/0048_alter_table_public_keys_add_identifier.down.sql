@@ -0,0 +1,7 @@
+DROP INDEX public_keys_principal_id_identifier;
+
+CREATE INDEX public_keys_principal_id
+    ON public_keys(public_key_principal_id);
+
+ALTER TABLE public_keys DROP COLUMN public_key_identifier;
+ALTER TABLE public_keys DROP COLUMN public_key_last_used;
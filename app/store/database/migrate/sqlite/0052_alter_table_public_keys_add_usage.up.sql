@@ -0,0 +1,7 @@
+ALTER TABLE public_keys ADD COLUMN public_key_usage TEXT NOT NULL DEFAULT 'auth';
+
+-- the same key can be registered once for authentication and once for commit signing.
+DROP INDEX public_keys_fingerprint;
+
+CREATE UNIQUE INDEX public_keys_fingerprint_usage
+    ON public_keys(public_key_fingerprint, public_key_usage);
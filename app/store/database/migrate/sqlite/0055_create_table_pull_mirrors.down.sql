@@ -0,0 +1,3 @@
+DROP TABLE pull_mirrors;
+
+ALTER TABLE repositories DROP COLUMN repo_is_mirror;
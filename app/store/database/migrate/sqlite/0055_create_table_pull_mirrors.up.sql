@@ -0,0 +1,22 @@
+ALTER TABLE repositories ADD COLUMN repo_is_mirror BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE pull_mirrors (
+ pull_mirror_repo_id INTEGER PRIMARY KEY
+,pull_mirror_created BIGINT NOT NULL
+,pull_mirror_updated BIGINT NOT NULL
+,pull_mirror_created_by INTEGER NOT NULL
+,pull_mirror_remote_url TEXT NOT NULL
+,pull_mirror_credentials BLOB
+,pull_mirror_cron TEXT NOT NULL
+,pull_mirror_last_sync BIGINT
+,pull_mirror_last_sync_state TEXT NOT NULL
+,pull_mirror_last_sync_error TEXT NOT NULL
+,CONSTRAINT fk_pull_mirror_repo_id FOREIGN KEY (pull_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pull_mirror_created_by FOREIGN KEY (pull_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
@@ -0,0 +1 @@
+DROP TABLE merge_queue_entries;
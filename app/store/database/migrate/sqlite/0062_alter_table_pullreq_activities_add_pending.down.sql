@@ -0,0 +1,3 @@
+DROP INDEX pullreq_activities_pending_pullreq_id_created_by;
+
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_pending;
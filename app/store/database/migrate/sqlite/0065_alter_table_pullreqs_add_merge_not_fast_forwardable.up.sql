@@ -0,0 +1 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_merge_not_fast_forwardable BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,328 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{
+		db: db,
+	}
+}
+
+// PublicKeyStore implements a store.PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+type publicKey struct {
+	ID          int64    `db:"public_key_id"`
+	PrincipalID int64    `db:"public_key_principal_id"`
+	Identifier  string   `db:"public_key_identifier"`
+	Created     int64    `db:"public_key_created"`
+	LastUsed    null.Int `db:"public_key_last_used"`
+
+	Fingerprint string `db:"public_key_fingerprint"`
+	Content     string `db:"public_key_content"`
+	Comment     string `db:"public_key_comment"`
+	Type        string `db:"public_key_type"`
+	Usage       string `db:"public_key_usage"`
+}
+
+const (
+	publicKeyColumns = `
+		 public_key_id
+		,public_key_principal_id
+		,public_key_identifier
+		,public_key_created
+		,public_key_last_used
+		,public_key_fingerprint
+		,public_key_content
+		,public_key_comment
+		,public_key_type
+		,public_key_usage`
+
+	publicKeySelectBase = `
+	SELECT` + publicKeyColumns + `
+	FROM public_keys`
+)
+
+// FindByIdentifier finds the public key of a principal by its identifier.
+func (s *PublicKeyStore) FindByIdentifier(
+	ctx context.Context,
+	principalID int64,
+	identifier string,
+) (*types.PublicKey, error) {
+	sqlQuery := publicKeySelectBase + `
+	WHERE public_key_principal_id = $1 AND LOWER(public_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find public key by identifier")
+	}
+
+	return mapToPublicKey(dst), nil
+}
+
+// FindByFingerprint finds the public key with the provided usage by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(
+	ctx context.Context,
+	fingerprint string,
+	usage enum.PublicKeyUsage,
+) (*types.PublicKey, error) {
+	sqlQuery := publicKeySelectBase + `
+	WHERE public_key_fingerprint = $1 AND public_key_usage = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, fingerprint, string(usage)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find public key by fingerprint")
+	}
+
+	return mapToPublicKey(dst), nil
+}
+
+// ListByFingerprints returns the public keys with the provided usage for the provided fingerprints.
+func (s *PublicKeyStore) ListByFingerprints(
+	ctx context.Context,
+	fingerprints []string,
+	usage enum.PublicKeyUsage,
+) ([]types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where(squirrel.Eq{"public_key_fingerprint": fingerprints}).
+		Where("public_key_usage = ?", string(usage))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list public keys by fingerprints query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]publicKey, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list public keys by fingerprints query")
+	}
+
+	return mapToPublicKeys(dst), nil
+}
+
+// Create saves a new public key.
+func (s *PublicKeyStore) Create(ctx context.Context, publicKey *types.PublicKey) error {
+	const sqlQuery = `
+		INSERT INTO public_keys (
+			 public_key_principal_id
+			,public_key_identifier
+			,public_key_created
+			,public_key_last_used
+			,public_key_fingerprint
+			,public_key_content
+			,public_key_comment
+			,public_key_type
+			,public_key_usage
+		) values (
+			 :public_key_principal_id
+			,:public_key_identifier
+			,:public_key_created
+			,:public_key_last_used
+			,:public_key_fingerprint
+			,:public_key_content
+			,:public_key_comment
+			,:public_key_type
+			,:public_key_usage
+		) RETURNING public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbPublicKey := mapToInternalPublicKey(publicKey)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbPublicKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind public key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&publicKey.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert public key query failed")
+	}
+
+	return nil
+}
+
+// DeleteByIdentifier deletes the public key of a principal by its identifier.
+func (s *PublicKeyStore) DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error {
+	const sqlQuery = `
+		DELETE FROM public_keys
+		WHERE public_key_principal_id = $1 AND LOWER(public_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, strings.ToLower(identifier))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete public key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted public keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAsUsed updates the last used timestamp of the public key.
+func (s *PublicKeyStore) MarkAsUsed(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		UPDATE public_keys
+		SET public_key_last_used = $1
+		WHERE public_key_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark public key as used")
+	}
+
+	return nil
+}
+
+// Count returns the number of public keys of a principal.
+func (s *PublicKeyStore) Count(ctx context.Context, principalID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count public keys query")
+	}
+
+	return count, nil
+}
+
+// List returns the public keys of a principal.
+func (s *PublicKeyStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter types.ListQueryFilter,
+) ([]types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("public_key_created DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]publicKey, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list public keys query")
+	}
+
+	return mapToPublicKeys(dst), nil
+}
+
+func (*PublicKeyStore) applyQuery(stmt squirrel.SelectBuilder, query string) squirrel.SelectBuilder {
+	if query == "" {
+		return stmt
+	}
+
+	return stmt.Where("LOWER(public_key_identifier) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+}
+
+func mapToInternalPublicKey(in *types.PublicKey) publicKey {
+	return publicKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		LastUsed:    null.IntFromPtr(in.LastUsed),
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+		Comment:     in.Comment,
+		Type:        in.Type,
+		Usage:       string(in.Usage),
+	}
+}
+
+func mapToPublicKey(in *publicKey) *types.PublicKey {
+	return &types.PublicKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		LastUsed:    in.LastUsed.Ptr(),
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+		Comment:     in.Comment,
+		Type:        in.Type,
+		Usage:       enum.PublicKeyUsage(in.Usage),
+	}
+}
+
+func mapToPublicKeys(publicKeys []publicKey) []types.PublicKey {
+	res := make([]types.PublicKey, len(publicKeys))
+	for i := range publicKeys {
+		res[i] = *mapToPublicKey(&publicKeys[i])
+	}
+	return res
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullMirrorStore = (*PullMirrorStore)(nil)
+
+// NewPullMirrorStore returns a new PullMirrorStore.
+func NewPullMirrorStore(db *sqlx.DB) *PullMirrorStore {
+	return &PullMirrorStore{
+		db: db,
+	}
+}
+
+// PullMirrorStore implements a store.PullMirrorStore backed by a relational database.
+type PullMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pullMirror struct {
+	RepoID      int64  `db:"pull_mirror_repo_id"`
+	Created     int64  `db:"pull_mirror_created"`
+	Updated     int64  `db:"pull_mirror_updated"`
+	CreatedBy   int64  `db:"pull_mirror_created_by"`
+	RemoteURL   string `db:"pull_mirror_remote_url"`
+	Credentials []byte `db:"pull_mirror_credentials"`
+	Cron        string `db:"pull_mirror_cron"`
+
+	LastSync      null.Int             `db:"pull_mirror_last_sync"`
+	LastSyncState enum.MirrorSyncState `db:"pull_mirror_last_sync_state"`
+	LastSyncError string               `db:"pull_mirror_last_sync_error"`
+}
+
+const (
+	pullMirrorColumns = `
+		 pull_mirror_repo_id
+		,pull_mirror_created
+		,pull_mirror_updated
+		,pull_mirror_created_by
+		,pull_mirror_remote_url
+		,pull_mirror_credentials
+		,pull_mirror_cron
+		,pull_mirror_last_sync
+		,pull_mirror_last_sync_state
+		,pull_mirror_last_sync_error`
+
+	pullMirrorSelectBase = `
+	SELECT` + pullMirrorColumns + `
+	FROM pull_mirrors`
+)
+
+// Find finds the pull mirror configuration of a repository.
+func (s *PullMirrorStore) Find(ctx context.Context, repoID int64) (*types.PullMirror, error) {
+	sqlQuery := pullMirrorSelectBase + `
+	WHERE pull_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull mirror")
+	}
+
+	return mapToPullMirror(dst), nil
+}
+
+// Create saves a new pull mirror configuration.
+func (s *PullMirrorStore) Create(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		INSERT INTO pull_mirrors (` + pullMirrorColumns + `
+		) values (
+			 :pull_mirror_repo_id
+			,:pull_mirror_created
+			,:pull_mirror_updated
+			,:pull_mirror_created_by
+			,:pull_mirror_remote_url
+			,:pull_mirror_credentials
+			,:pull_mirror_cron
+			,:pull_mirror_last_sync
+			,:pull_mirror_last_sync_state
+			,:pull_mirror_last_sync_error
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert pull mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the remote URL, the credentials and the schedule of a pull mirror.
+func (s *PullMirrorStore) Update(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_updated = :pull_mirror_updated
+			,pull_mirror_remote_url = :pull_mirror_remote_url
+			,pull_mirror_credentials = :pull_mirror_credentials
+			,pull_mirror_cron = :pull_mirror_cron
+		WHERE pull_mirror_repo_id = :pull_mirror_repo_id`
+
+	mirror.Updated = time.Now().UnixMilli()
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateSyncState updates the outcome of the latest synchronization of a pull mirror.
+func (s *PullMirrorStore) UpdateSyncState(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_last_sync = :pull_mirror_last_sync
+			,pull_mirror_last_sync_state = :pull_mirror_last_sync_state
+			,pull_mirror_last_sync_error = :pull_mirror_last_sync_error
+		WHERE pull_mirror_repo_id = :pull_mirror_repo_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *PullMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.PullMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update pull mirror query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated pull mirrors")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapToInternalPullMirror(in *types.PullMirror) *pullMirror {
+	return &pullMirror{
+		RepoID:        in.RepoID,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		CreatedBy:     in.CreatedBy,
+		RemoteURL:     in.RemoteURL,
+		Credentials:   in.Credentials,
+		Cron:          in.Cron,
+		LastSync:      null.NewInt(in.LastSync, in.LastSync != 0),
+		LastSyncState: in.LastSyncState,
+		LastSyncError: in.LastSyncError,
+	}
+}
+
+func mapToPullMirror(in *pullMirror) *types.PullMirror {
+	return &types.PullMirror{
+		RepoID:        in.RepoID,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		CreatedBy:     in.CreatedBy,
+		RemoteURL:     in.RemoteURL,
+		Credentials:   in.Credentials,
+		Cron:          in.Cron,
+		LastSync:      in.LastSync.Int64,
+		LastSyncState: in.LastSyncState,
+		LastSyncError: in.LastSyncError,
+	}
+}
@@ -90,6 +90,11 @@ type pullReq struct {
 	MergeSHA         null.String           `db:"pullreq_merge_sha"`
 	MergeConflicts   null.String           `db:"pullreq_merge_conflicts"`
 
+	MergeNotFastForwardable bool `db:"pullreq_merge_not_fast_forwardable"`
+
+	AutoMergeMethod null.String `db:"pullreq_auto_merge_method"`
+	AutoMergeBy     null.Int    `db:"pullreq_auto_merge_by"`
+
 	CommitCount null.Int `db:"pullreq_commit_count"`
 	FileCount   null.Int `db:"pullreq_file_count"`
 }
@@ -123,6 +128,9 @@ const (
 		,pullreq_merge_base_sha
 		,pullreq_merge_sha
 		,pullreq_merge_conflicts
+		,pullreq_merge_not_fast_forwardable
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_by
 		,pullreq_commit_count
 		,pullreq_file_count`
 
@@ -214,6 +222,9 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_base_sha
 		,pullreq_merge_sha
 		,pullreq_merge_conflicts
+		,pullreq_merge_not_fast_forwardable
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_by
 		,pullreq_commit_count
 		,pullreq_file_count
 	) values (
@@ -243,6 +254,9 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merge_base_sha
 		,:pullreq_merge_sha
 		,:pullreq_merge_conflicts
+		,:pullreq_merge_not_fast_forwardable
+		,:pullreq_auto_merge_method
+		,:pullreq_auto_merge_by
 		,:pullreq_commit_count
 		,:pullreq_file_count
 	) RETURNING pullreq_id`
@@ -285,6 +299,9 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_base_sha = :pullreq_merge_base_sha
 		,pullreq_merge_sha = :pullreq_merge_sha
 		,pullreq_merge_conflicts = :pullreq_merge_conflicts
+		,pullreq_merge_not_fast_forwardable = :pullreq_merge_not_fast_forwardable
+		,pullreq_auto_merge_method = :pullreq_auto_merge_method
+		,pullreq_auto_merge_by = :pullreq_auto_merge_by
 		,pullreq_commit_count = :pullreq_commit_count 
 		,pullreq_file_count = :pullreq_file_count
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
@@ -369,6 +386,7 @@ func (s *PullReqStore) UpdateMergeCheckStatus(
 	SET
 		 pullreq_updated = $1
 		,pullreq_merge_check_status = $2
+		,pullreq_merge_not_fast_forwardable = FALSE
 		,pullreq_version = pullreq_version + 1
 		,pullreq_commit_count = NULL
 		,pullreq_file_count = NULL
@@ -438,6 +456,12 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	// a pull request must have all requested labels assigned
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where("EXISTS (SELECT 1 FROM pullreq_labels"+
+			" WHERE pullreq_label_pullreq_id = pullreq_id AND pullreq_label_label_id = ?)", labelID)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -490,6 +514,12 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	// a pull request must have all requested labels assigned
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where("EXISTS (SELECT 1 FROM pullreq_labels"+
+			" WHERE pullreq_label_pullreq_id = pullreq_id AND pullreq_label_label_id = ?)", labelID)
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -520,6 +550,39 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 	return result, nil
 }
 
+// ListAutoMerge returns open pull requests in the target repository
+// that have auto-merge enabled and whose source branch points to the provided commit SHA.
+func (s *PullReqStore) ListAutoMerge(ctx context.Context, targetRepoID int64, sourceSHA string) ([]*types.PullReq, error) {
+	stmt := database.Builder.
+		Select(pullReqColumns).
+		From("pullreqs").
+		Where("pullreq_target_repo_id = ?", targetRepoID).
+		Where("pullreq_source_sha = ?", sourceSHA).
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		Where("pullreq_auto_merge_method IS NOT NULL").
+		OrderBy("pullreq_number ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*pullReq, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing auto-merge list query")
+	}
+
+	result, err := s.mapSlicePullReq(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func mapPullReq(pr *pullReq) *types.PullReq {
 	var mergeConflicts []string
 	if pr.MergeConflicts.Valid {
@@ -527,35 +590,38 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 	}
 
 	return &types.PullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         pr.MergedBy.Ptr(),
-		Merged:           pr.Merged.Ptr(),
-		MergeMethod:      (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   pr.MergeTargetSHA.Ptr(),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         pr.MergeSHA.Ptr(),
-		MergeConflicts:   mergeConflicts,
-		Author:           types.PrincipalInfo{},
-		Merger:           nil,
+		ID:                      pr.ID,
+		Version:                 pr.Version,
+		Number:                  pr.Number,
+		CreatedBy:               pr.CreatedBy,
+		Created:                 pr.Created,
+		Updated:                 pr.Updated,
+		Edited:                  pr.Edited,
+		State:                   pr.State,
+		IsDraft:                 pr.IsDraft,
+		CommentCount:            pr.CommentCount,
+		UnresolvedCount:         pr.UnresolvedCount,
+		Title:                   pr.Title,
+		Description:             pr.Description,
+		SourceRepoID:            pr.SourceRepoID,
+		SourceBranch:            pr.SourceBranch,
+		SourceSHA:               pr.SourceSHA,
+		TargetRepoID:            pr.TargetRepoID,
+		TargetBranch:            pr.TargetBranch,
+		ActivitySeq:             pr.ActivitySeq,
+		MergedBy:                pr.MergedBy.Ptr(),
+		Merged:                  pr.Merged.Ptr(),
+		MergeMethod:             (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
+		MergeCheckStatus:        pr.MergeCheckStatus,
+		MergeTargetSHA:          pr.MergeTargetSHA.Ptr(),
+		MergeBaseSHA:            pr.MergeBaseSHA,
+		MergeSHA:                pr.MergeSHA.Ptr(),
+		MergeConflicts:          mergeConflicts,
+		MergeNotFastForwardable: pr.MergeNotFastForwardable,
+		AutoMergeMethod:         (*enum.MergeMethod)(pr.AutoMergeMethod.Ptr()),
+		AutoMergeBy:             pr.AutoMergeBy.Ptr(),
+		Author:                  types.PrincipalInfo{},
+		Merger:                  nil,
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
@@ -570,35 +636,38 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 func mapInternalPullReq(pr *types.PullReq) *pullReq {
 	mergeConflicts := strings.Join(pr.MergeConflicts, "\n")
 	m := &pullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         null.IntFromPtr(pr.MergedBy),
-		Merged:           null.IntFromPtr(pr.Merged),
-		MergeMethod:      null.StringFromPtr((*string)(pr.MergeMethod)),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   null.StringFromPtr(pr.MergeTargetSHA),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         null.StringFromPtr(pr.MergeSHA),
-		MergeConflicts:   null.NewString(mergeConflicts, mergeConflicts != ""),
-		CommitCount:      null.IntFromPtr(pr.Stats.Commits),
-		FileCount:        null.IntFromPtr(pr.Stats.FilesChanged),
+		ID:                      pr.ID,
+		Version:                 pr.Version,
+		Number:                  pr.Number,
+		CreatedBy:               pr.CreatedBy,
+		Created:                 pr.Created,
+		Updated:                 pr.Updated,
+		Edited:                  pr.Edited,
+		State:                   pr.State,
+		IsDraft:                 pr.IsDraft,
+		CommentCount:            pr.CommentCount,
+		UnresolvedCount:         pr.UnresolvedCount,
+		Title:                   pr.Title,
+		Description:             pr.Description,
+		SourceRepoID:            pr.SourceRepoID,
+		SourceBranch:            pr.SourceBranch,
+		SourceSHA:               pr.SourceSHA,
+		TargetRepoID:            pr.TargetRepoID,
+		TargetBranch:            pr.TargetBranch,
+		ActivitySeq:             pr.ActivitySeq,
+		MergedBy:                null.IntFromPtr(pr.MergedBy),
+		Merged:                  null.IntFromPtr(pr.Merged),
+		MergeMethod:             null.StringFromPtr((*string)(pr.MergeMethod)),
+		MergeCheckStatus:        pr.MergeCheckStatus,
+		MergeTargetSHA:          null.StringFromPtr(pr.MergeTargetSHA),
+		MergeBaseSHA:            pr.MergeBaseSHA,
+		MergeSHA:                null.StringFromPtr(pr.MergeSHA),
+		MergeConflicts:          null.NewString(mergeConflicts, mergeConflicts != ""),
+		MergeNotFastForwardable: pr.MergeNotFastForwardable,
+		AutoMergeMethod:         null.StringFromPtr((*string)(pr.AutoMergeMethod)),
+		AutoMergeBy:             null.IntFromPtr(pr.AutoMergeBy),
+		CommitCount:             null.IntFromPtr(pr.Stats.Commits),
+		FileCount:               null.IntFromPtr(pr.Stats.FilesChanged),
 	}
 
 	return m
@@ -83,6 +83,8 @@ type pullReqActivity struct {
 	ResolvedBy null.Int `db:"pullreq_activity_resolved_by"`
 	Resolved   null.Int `db:"pullreq_activity_resolved"`
 
+	Pending bool `db:"pullreq_activity_pending"`
+
 	Outdated                null.Bool   `db:"pullreq_activity_outdated"`
 	CodeCommentMergeBaseSHA null.String `db:"pullreq_activity_code_comment_merge_base_sha"`
 	CodeCommentSourceSHA    null.String `db:"pullreq_activity_code_comment_source_sha"`
@@ -115,6 +117,7 @@ const (
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_pending
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -167,6 +170,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata
 		,pullreq_activity_resolved_by
 		,pullreq_activity_resolved
+		,pullreq_activity_pending
 		,pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha
@@ -195,6 +199,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_metadata
 		,:pullreq_activity_resolved_by
 		,:pullreq_activity_resolved
+		,:pullreq_activity_pending
 		,:pullreq_activity_outdated
 		,:pullreq_activity_code_comment_merge_base_sha
 		,:pullreq_activity_code_comment_source_sha
@@ -264,6 +269,8 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_metadata = :pullreq_activity_metadata
 		,pullreq_activity_resolved_by = :pullreq_activity_resolved_by
 		,pullreq_activity_resolved = :pullreq_activity_resolved
+		,pullreq_activity_order = :pullreq_activity_order
+		,pullreq_activity_pending = :pullreq_activity_pending
 		,pullreq_activity_outdated = :pullreq_activity_outdated
 		,pullreq_activity_code_comment_merge_base_sha = :pullreq_activity_code_comment_merge_base_sha
 		,pullreq_activity_code_comment_source_sha = :pullreq_activity_code_comment_source_sha
@@ -344,6 +351,8 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID)
 
+	stmt = applyPendingFilter(opts.PendingAuthorID, stmt)
+
 	if len(opts.Types) == 1 {
 		stmt = stmt.Where("pullreq_activity_type = ?", opts.Types[0])
 	} else if len(opts.Types) > 1 {
@@ -415,13 +424,44 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 	return result, nil
 }
 
+// ListPending returns the pending review comments of a principal in a PR.
+func (s *PullReqActivityStore) ListPending(ctx context.Context,
+	prID int64,
+	principalID int64,
+) ([]*types.PullReqActivity, error) {
+	stmt := database.Builder.
+		Select(pullreqActivityColumns).
+		From("pullreq_activities").
+		Where("pullreq_activity_pullreq_id = ?", prID).
+		Where("pullreq_activity_created_by = ?", principalID).
+		Where("pullreq_activity_pending = TRUE").
+		Where("pullreq_activity_deleted IS NULL").
+		OrderBy("pullreq_activity_created asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pending pull request activity query to sql")
+	}
+
+	dst := make([]*pullReqActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pending pull request activity list query")
+	}
+
+	return s.mapSlicePullReqActivity(ctx, dst)
+}
+
 // ListAuthorIDs returns a list of pull request activity author ids in a thread for a PR.
 func (s *PullReqActivityStore) ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error) {
 	stmt := database.Builder.
 		Select("DISTINCT pullreq_activity_created_by").
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID).
-		Where("pullreq_activity_order = ?", order)
+		Where("pullreq_activity_order = ?", order).
+		Where("pullreq_activity_pending = FALSE")
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -447,6 +487,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		Where("pullreq_activity_sub_order = 0").
 		Where("pullreq_activity_resolved IS NULL").
 		Where("pullreq_activity_deleted IS NULL").
+		Where("pullreq_activity_pending = FALSE").
 		Where("pullreq_activity_kind <> ?", enum.PullReqActivityKindSystem)
 
 	sql, args, err := stmt.ToSql()
@@ -487,6 +528,7 @@ func mapPullReqActivity(act *pullReqActivity) *types.PullReqActivity {
 		Metadata:   make(map[string]interface{}),
 		ResolvedBy: act.ResolvedBy.Ptr(),
 		Resolved:   act.Resolved.Ptr(),
+		Pending:    act.Pending,
 		Author:     types.PrincipalInfo{},
 		Resolver:   nil,
 	}
@@ -530,6 +572,7 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) *pullReqActivity {
 		Metadata:   nil,
 		ResolvedBy: null.IntFromPtr(act.ResolvedBy),
 		Resolved:   null.IntFromPtr(act.Resolved),
+		Pending:    act.Pending,
 	}
 	if act.IsValidCodeComment() {
 		m.Outdated = null.BoolFrom(act.CodeComment.Outdated)
@@ -612,6 +655,8 @@ func applyFilter(
 	filter *types.PullReqActivityFilter,
 	stmt squirrel.SelectBuilder,
 ) squirrel.SelectBuilder {
+	stmt = applyPendingFilter(filter.PendingAuthorID, stmt)
+
 	if len(filter.Types) == 1 {
 		stmt = stmt.Where("pullreq_activity_type = ?", filter.Types[0])
 	} else if len(filter.Types) > 1 {
@@ -638,3 +683,12 @@ func applyFilter(
 
 	return stmt
 }
+
+// applyPendingFilter excludes pending review comments, except the ones of the provided principal.
+func applyPendingFilter(pendingAuthorID int64, stmt squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if pendingAuthorID == 0 {
+		return stmt.Where("pullreq_activity_pending = FALSE")
+	}
+
+	return stmt.Where("(pullreq_activity_pending = FALSE OR pullreq_activity_created_by = ?)", pendingAuthorID)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqDependencyStore = (*PullReqDependencyStore)(nil)
+
+// maxPullReqDependencies is a memory safety limit for the number of dependencies returned.
+const maxPullReqDependencies = 100
+
+// NewPullReqDependencyStore returns a new PullReqDependencyStore.
+func NewPullReqDependencyStore(db *sqlx.DB) *PullReqDependencyStore {
+	return &PullReqDependencyStore{
+		db: db,
+	}
+}
+
+// PullReqDependencyStore implements store.PullReqDependencyStore backed by a relational database.
+type PullReqDependencyStore struct {
+	db *sqlx.DB
+}
+
+// pullReqDependency is used to fetch pull request dependency data from the database.
+type pullReqDependency struct {
+	PullReqID int64 `db:"pullreq_dependency_pullreq_id"`
+	ParentID  int64 `db:"pullreq_dependency_parent_id"`
+	Created   int64 `db:"pullreq_dependency_created"`
+	CreatedBy int64 `db:"pullreq_dependency_created_by"`
+}
+
+// Create creates a new dependency of a pull request on a parent pull request.
+func (s *PullReqDependencyStore) Create(ctx context.Context, v *types.PullReqDependency) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_dependencies (
+		 pullreq_dependency_pullreq_id
+		,pullreq_dependency_parent_id
+		,pullreq_dependency_created
+		,pullreq_dependency_created_by
+	) values (
+		 :pullreq_dependency_pullreq_id
+		,:pullreq_dependency_parent_id
+		,:pullreq_dependency_created
+		,:pullreq_dependency_created_by
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqDependency{
+		PullReqID: v.PullReqID,
+		ParentID:  v.ParentID,
+		Created:   v.Created,
+		CreatedBy: v.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request dependency object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pull request dependency")
+	}
+
+	return nil
+}
+
+// Delete deletes the dependency of a pull request on a parent pull request.
+func (s *PullReqDependencyStore) Delete(ctx context.Context, prID, parentID int64) error {
+	const sqlQuery = `
+	DELETE FROM pullreq_dependencies
+	WHERE pullreq_dependency_pullreq_id = $1 AND
+	      pullreq_dependency_parent_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, prID, parentID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request dependency")
+	}
+
+	return nil
+}
+
+// ListParentIDs returns IDs of all pull requests the pull request depends on.
+func (s *PullReqDependencyStore) ListParentIDs(ctx context.Context, prID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT pullreq_dependency_parent_id
+	FROM pullreq_dependencies
+	WHERE pullreq_dependency_pullreq_id = $1
+	ORDER BY pullreq_dependency_created ASC
+	LIMIT $2`
+
+	return s.listIDs(ctx, sqlQuery, prID)
+}
+
+// ListChildIDs returns IDs of all pull requests that depend on the pull request.
+func (s *PullReqDependencyStore) ListChildIDs(ctx context.Context, prID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT pullreq_dependency_pullreq_id
+	FROM pullreq_dependencies
+	WHERE pullreq_dependency_parent_id = $1
+	ORDER BY pullreq_dependency_created ASC
+	LIMIT $2`
+
+	return s.listIDs(ctx, sqlQuery, prID)
+}
+
+func (s *PullReqDependencyStore) listIDs(ctx context.Context, sqlQuery string, prID int64) ([]int64, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err := db.SelectContext(ctx, &ids, sqlQuery, prID, maxPullReqDependencies); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request dependencies")
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqLabelStore = (*PullReqLabelStore)(nil)
+
+// maxPullReqLabels is a memory safety limit for the number of labels returned for a pull request.
+const maxPullReqLabels = 100
+
+// NewPullReqLabelStore returns a new PullReqLabelStore.
+func NewPullReqLabelStore(db *sqlx.DB) *PullReqLabelStore {
+	return &PullReqLabelStore{
+		db: db,
+	}
+}
+
+// PullReqLabelStore implements store.PullReqLabelStore backed by a relational database.
+type PullReqLabelStore struct {
+	db *sqlx.DB
+}
+
+// pullReqLabel is used to fetch pull request label assignment data from the database.
+type pullReqLabel struct {
+	PullReqID int64 `db:"pullreq_label_pullreq_id"`
+	LabelID   int64 `db:"pullreq_label_label_id"`
+	Created   int64 `db:"pullreq_label_created"`
+	CreatedBy int64 `db:"pullreq_label_created_by"`
+}
+
+// Assign assigns a label to a pull request.
+func (s *PullReqLabelStore) Assign(ctx context.Context, v *types.PullReqLabel) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_labels (
+		 pullreq_label_pullreq_id
+		,pullreq_label_label_id
+		,pullreq_label_created
+		,pullreq_label_created_by
+	) values (
+		 :pullreq_label_pullreq_id
+		,:pullreq_label_label_id
+		,:pullreq_label_created
+		,:pullreq_label_created_by
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqLabel{
+		PullReqID: v.PullReqID,
+		LabelID:   v.LabelID,
+		Created:   v.Created,
+		CreatedBy: v.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request label object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pull request label")
+	}
+
+	return nil
+}
+
+// Unassign removes a label from a pull request.
+func (s *PullReqLabelStore) Unassign(ctx context.Context, prID, labelID int64) error {
+	const sqlQuery = `
+	DELETE FROM pullreq_labels
+	WHERE pullreq_label_pullreq_id = $1 AND
+	      pullreq_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, labelID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request label")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pull request label rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListLabels returns all labels assigned to a pull request.
+func (s *PullReqLabelStore) ListLabels(ctx context.Context, prID int64) ([]*types.Label, error) {
+	const sqlQuery = labelSelectBase + `
+	INNER JOIN pullreq_labels ON pullreq_label_label_id = label_id
+	WHERE pullreq_label_pullreq_id = $1
+	ORDER BY LOWER(label_name) ASC, label_id ASC
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*label, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, prID, maxPullReqLabels); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request labels")
+	}
+
+	return mapLabels(dst), nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqReactionStore = (*PullReqReactionStore)(nil)
+
+// NewPullReqReactionStore returns a new PullReqReactionStore.
+func NewPullReqReactionStore(db *sqlx.DB) *PullReqReactionStore {
+	return &PullReqReactionStore{
+		db: db,
+	}
+}
+
+// PullReqReactionStore implements store.PullReqReactionStore backed by a relational database.
+type PullReqReactionStore struct {
+	db *sqlx.DB
+}
+
+// pullReqReaction is used to write pull request reaction data to the database.
+type pullReqReaction struct {
+	PullReqID   int64             `db:"pullreq_reaction_pullreq_id"`
+	ActivityID  null.Int          `db:"pullreq_reaction_activity_id"`
+	PrincipalID int64             `db:"pullreq_reaction_principal_id"`
+	Type        enum.ReactionType `db:"pullreq_reaction_type"`
+	Created     int64             `db:"pullreq_reaction_created"`
+}
+
+// reactionSummary is used to fetch aggregated pull request reaction data from the database.
+type reactionSummary struct {
+	ActivityID null.Int          `db:"pullreq_reaction_activity_id"`
+	Type       enum.ReactionType `db:"pullreq_reaction_type"`
+	Count      int               `db:"reaction_count"`
+	Reacted    int               `db:"reaction_reacted"`
+}
+
+// Create adds a new reaction to a pull request description or comment.
+func (s *PullReqReactionStore) Create(ctx context.Context, v *types.PullReqReaction) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_reactions (
+		 pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_principal_id
+		,pullreq_reaction_type
+		,pullreq_reaction_created
+	) values (
+		 :pullreq_reaction_pullreq_id
+		,:pullreq_reaction_activity_id
+		,:pullreq_reaction_principal_id
+		,:pullreq_reaction_type
+		,:pullreq_reaction_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqReaction{
+		PullReqID:   v.PullReqID,
+		ActivityID:  null.IntFromPtr(v.ActivityID),
+		PrincipalID: v.PrincipalID,
+		Type:        v.Type,
+		Created:     v.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request reaction object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pull request reaction")
+	}
+
+	return nil
+}
+
+// Delete removes a reaction of a principal from a pull request description or comment.
+func (s *PullReqReactionStore) Delete(
+	ctx context.Context,
+	prID int64,
+	activityID *int64,
+	principalID int64,
+	reactionType enum.ReactionType,
+) error {
+	stmt := database.Builder.
+		Delete("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", prID).
+		Where("pullreq_reaction_principal_id = ?", principalID).
+		Where("pullreq_reaction_type = ?", reactionType)
+
+	if activityID == nil {
+		stmt = stmt.Where("pullreq_reaction_activity_id IS NULL")
+	} else {
+		stmt = stmt.Where("pullreq_reaction_activity_id = ?", *activityID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert delete pull request reaction query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request reaction")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pull request reaction rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Summary returns the aggregated reactions to a pull request description (if activityID is nil) or comment.
+// The Reacted flag of the result is set for reaction types the principal has used.
+func (s *PullReqReactionStore) Summary(
+	ctx context.Context,
+	prID int64,
+	activityID *int64,
+	principalID int64,
+) ([]types.ReactionSummary, error) {
+	stmt := summarySelect(prID, principalID)
+
+	if activityID == nil {
+		stmt = stmt.Where("pullreq_reaction_activity_id IS NULL")
+	} else {
+		stmt = stmt.Where("pullreq_reaction_activity_id = ?", *activityID)
+	}
+
+	dst, err := s.listSummary(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.ReactionSummary, len(dst))
+	for i, v := range dst {
+		result[i] = mapReactionSummary(v)
+	}
+
+	return result, nil
+}
+
+// SummaryPerActivity returns the aggregated reactions to all comments of a pull request, mapped by the comment ID.
+// The Reacted flag of the result is set for reaction types the principal has used.
+func (s *PullReqReactionStore) SummaryPerActivity(
+	ctx context.Context,
+	prID int64,
+	principalID int64,
+) (map[int64][]types.ReactionSummary, error) {
+	stmt := summarySelect(prID, principalID).
+		Where("pullreq_reaction_activity_id IS NOT NULL")
+
+	dst, err := s.listSummary(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]types.ReactionSummary)
+	for _, v := range dst {
+		result[v.ActivityID.Int64] = append(result[v.ActivityID.Int64], mapReactionSummary(v))
+	}
+
+	return result, nil
+}
+
+func summarySelect(prID int64, principalID int64) squirrel.SelectBuilder {
+	return database.Builder.
+		Select("pullreq_reaction_activity_id",
+			"pullreq_reaction_type",
+			"COUNT(*) AS reaction_count").
+		Column("SUM(CASE WHEN pullreq_reaction_principal_id = ? THEN 1 ELSE 0 END) AS reaction_reacted",
+			principalID).
+		From("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", prID).
+		GroupBy("pullreq_reaction_activity_id", "pullreq_reaction_type").
+		OrderBy("MIN(pullreq_reaction_created) ASC")
+}
+
+func (s *PullReqReactionStore) listSummary(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*reactionSummary, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pull request reaction summary query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*reactionSummary, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request reaction summary")
+	}
+
+	return dst, nil
+}
+
+func mapReactionSummary(v *reactionSummary) types.ReactionSummary {
+	return types.ReactionSummary{
+		Type:    v.Type,
+		Count:   v.Count,
+		Reacted: v.Reacted > 0,
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_PullReqReactions(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullreqStore := database.NewPullReqStore(db, nil)
+	activityStore := database.NewPullReqActivityStore(db, nil)
+	reactionStore := database.NewPullReqReactionStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	const otherUserID = 2
+	if err := principalStore.CreateUser(ctx, &types.User{ID: otherUserID, UID: "user_2"}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	pr := createPullReq(ctx, t, pullreqStore, 1, 1)
+	comment := createComment(ctx, t, activityStore, pr, 1)
+
+	add := func(activityID *int64, principalID int64, reaction enum.ReactionType, created int64) error {
+		return reactionStore.Create(ctx, &types.PullReqReaction{
+			PullReqID:   pr.ID,
+			ActivityID:  activityID,
+			PrincipalID: principalID,
+			Type:        reaction,
+			Created:     created,
+		})
+	}
+
+	reactions := []struct {
+		activityID  *int64
+		principalID int64
+		reaction    enum.ReactionType
+	}{
+		{activityID: nil, principalID: userID, reaction: enum.ReactionTypeHeart},
+		{activityID: nil, principalID: otherUserID, reaction: enum.ReactionTypeHeart},
+		{activityID: nil, principalID: otherUserID, reaction: enum.ReactionTypeRocket},
+		{activityID: &comment.ID, principalID: userID, reaction: enum.ReactionTypeHeart},
+		{activityID: &comment.ID, principalID: otherUserID, reaction: enum.ReactionTypeEyes},
+	}
+	for i, r := range reactions {
+		if err := add(r.activityID, r.principalID, r.reaction, int64(i)); err != nil {
+			t.Fatalf("failed to add reaction %d: %v", i, err)
+		}
+	}
+
+	// the same reaction of the same principal can be added only once, both to the description and to comments.
+	if err := add(nil, userID, enum.ReactionTypeHeart, 10); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("duplicate description reaction: err = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+	if err := add(&comment.ID, userID, enum.ReactionTypeHeart, 10); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("duplicate comment reaction: err = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	summary, err := reactionStore.Summary(ctx, pr.ID, nil, userID)
+	if err != nil {
+		t.Fatalf("failed to get reaction summary %v", err)
+	}
+	wantSummary := []types.ReactionSummary{
+		{Type: enum.ReactionTypeHeart, Count: 2, Reacted: true},
+		{Type: enum.ReactionTypeRocket, Count: 1, Reacted: false},
+	}
+	if !equalReactionSummary(summary, wantSummary) {
+		t.Errorf("Summary() = %v, want %v", summary, wantSummary)
+	}
+
+	perActivity, err := reactionStore.SummaryPerActivity(ctx, pr.ID, otherUserID)
+	if err != nil {
+		t.Fatalf("failed to get reaction summary per comment %v", err)
+	}
+	wantPerActivity := []types.ReactionSummary{
+		{Type: enum.ReactionTypeHeart, Count: 1, Reacted: false},
+		{Type: enum.ReactionTypeEyes, Count: 1, Reacted: true},
+	}
+	if len(perActivity) != 1 || !equalReactionSummary(perActivity[comment.ID], wantPerActivity) {
+		t.Errorf("SummaryPerActivity() = %v, want %v for comment %d", perActivity, wantPerActivity, comment.ID)
+	}
+
+	if err = reactionStore.Delete(ctx, pr.ID, nil, userID, enum.ReactionTypeHeart); err != nil {
+		t.Fatalf("failed to delete reaction %v", err)
+	}
+	err = reactionStore.Delete(ctx, pr.ID, nil, userID, enum.ReactionTypeHeart)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("deleting missing reaction: err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	// the reaction to the comment isn't affected by deleting the same reaction to the description.
+	perActivity, err = reactionStore.SummaryPerActivity(ctx, pr.ID, userID)
+	if err != nil {
+		t.Fatalf("failed to get reaction summary per comment %v", err)
+	}
+	if commentSummary := perActivity[comment.ID]; len(commentSummary) != 2 || !commentSummary[0].Reacted {
+		t.Errorf("SummaryPerActivity() = %v, want the heart reaction of user %d", commentSummary, userID)
+	}
+}
+
+func equalReactionSummary(a, b []types.ReactionSummary) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func createPullReq(
+	ctx context.Context,
+	t *testing.T,
+	pullreqStore *database.PullReqStore,
+	repoID int64,
+	number int64,
+) *types.PullReq {
+	t.Helper()
+
+	pr := &types.PullReq{
+		Number:       number,
+		CreatedBy:    userID,
+		State:        enum.PullReqStateOpen,
+		Title:        "title",
+		SourceRepoID: repoID,
+		SourceBranch: "feature",
+		TargetRepoID: repoID,
+		TargetBranch: "main",
+	}
+	if err := pullreqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request %v", err)
+	}
+
+	return pr
+}
+
+func createComment(
+	ctx context.Context,
+	t *testing.T,
+	activityStore *database.PullReqActivityStore,
+	pr *types.PullReq,
+	order int64,
+) *types.PullReqActivity {
+	t.Helper()
+
+	comment := &types.PullReqActivity{
+		CreatedBy:  userID,
+		RepoID:     pr.TargetRepoID,
+		PullReqID:  pr.ID,
+		Order:      order,
+		Type:       enum.PullReqActivityTypeComment,
+		Kind:       enum.PullReqActivityKindComment,
+		Text:       "comment",
+		PayloadRaw: json.RawMessage("{}"),
+	}
+	if err := activityStore.Create(ctx, comment); err != nil {
+		t.Fatalf("failed to create comment %v", err)
+	}
+
+	return comment
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqTextVersionStore = (*PullReqTextVersionStore)(nil)
+
+// NewPullReqTextVersionStore returns a new PullReqTextVersionStore.
+func NewPullReqTextVersionStore(db *sqlx.DB,
+	pCache store.PrincipalInfoCache) *PullReqTextVersionStore {
+	return &PullReqTextVersionStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// PullReqTextVersionStore implements store.PullReqTextVersionStore backed by a relational database.
+type PullReqTextVersionStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// pullReqTextVersion is used to fetch pull request text version data from the database.
+type pullReqTextVersion struct {
+	ID         int64    `db:"pullreq_text_version_id"`
+	PullReqID  int64    `db:"pullreq_text_version_pullreq_id"`
+	ActivityID null.Int `db:"pullreq_text_version_activity_id"`
+	Title      string   `db:"pullreq_text_version_title"`
+	Text       string   `db:"pullreq_text_version_text"`
+	Created    int64    `db:"pullreq_text_version_created"`
+	CreatedBy  null.Int `db:"pullreq_text_version_created_by"`
+}
+
+const (
+	pullReqTextVersionColumns = `
+		 pullreq_text_version_id
+		,pullreq_text_version_pullreq_id
+		,pullreq_text_version_activity_id
+		,pullreq_text_version_title
+		,pullreq_text_version_text
+		,pullreq_text_version_created
+		,pullreq_text_version_created_by`
+)
+
+// Create stores a new version of a pull request comment text or of a pull request title and description.
+func (s *PullReqTextVersionStore) Create(ctx context.Context, v *types.PullReqTextVersion) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_text_versions (
+		 pullreq_text_version_pullreq_id
+		,pullreq_text_version_activity_id
+		,pullreq_text_version_title
+		,pullreq_text_version_text
+		,pullreq_text_version_created
+		,pullreq_text_version_created_by
+	) values (
+		 :pullreq_text_version_pullreq_id
+		,:pullreq_text_version_activity_id
+		,:pullreq_text_version_title
+		,:pullreq_text_version_text
+		,:pullreq_text_version_created
+		,:pullreq_text_version_created_by
+	) RETURNING pullreq_text_version_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPullReqTextVersion(v))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request text version object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&v.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pull request text version")
+	}
+
+	return nil
+}
+
+// Count returns the number of versions of a pull request comment text
+// or of a pull request title and description (if activityID is nil).
+func (s *PullReqTextVersionStore) Count(ctx context.Context, prID int64, activityID *int64) (int64, error) {
+	stmt := applyTextVersionTarget(database.Builder.
+		Select("count(*)").
+		From("pullreq_text_versions"), prID, activityID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count pull request text versions query")
+	}
+
+	return count, nil
+}
+
+// List returns the versions of a pull request comment text
+// or of a pull request title and description (if activityID is nil), the latest version first.
+func (s *PullReqTextVersionStore) List(
+	ctx context.Context,
+	prID int64,
+	activityID *int64,
+	opts types.Pagination,
+) ([]*types.PullReqTextVersion, error) {
+	stmt := applyTextVersionTarget(database.Builder.
+		Select(pullReqTextVersionColumns).
+		From("pullreq_text_versions"), prID, activityID).
+		OrderBy("pullreq_text_version_created DESC", "pullreq_text_version_id DESC").
+		Limit(database.Limit(opts.Size)).
+		Offset(database.Offset(opts.Page, opts.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pull request text version list query to sql")
+	}
+
+	dst := make([]*pullReqTextVersion, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request text version list query")
+	}
+
+	return s.mapSlicePullReqTextVersion(ctx, dst)
+}
+
+func applyTextVersionTarget(stmt squirrel.SelectBuilder, prID int64, activityID *int64) squirrel.SelectBuilder {
+	stmt = stmt.Where("pullreq_text_version_pullreq_id = ?", prID)
+
+	if activityID == nil {
+		return stmt.Where("pullreq_text_version_activity_id IS NULL")
+	}
+
+	return stmt.Where("pullreq_text_version_activity_id = ?", *activityID)
+}
+
+func mapPullReqTextVersion(v *pullReqTextVersion) *types.PullReqTextVersion {
+	return &types.PullReqTextVersion{
+		ID:         v.ID,
+		PullReqID:  v.PullReqID,
+		ActivityID: v.ActivityID.Ptr(),
+		Title:      v.Title,
+		Text:       v.Text,
+		Created:    v.Created,
+		CreatedBy:  v.CreatedBy.Ptr(),
+	}
+}
+
+func mapInternalPullReqTextVersion(v *types.PullReqTextVersion) *pullReqTextVersion {
+	return &pullReqTextVersion{
+		ID:         v.ID,
+		PullReqID:  v.PullReqID,
+		ActivityID: null.IntFromPtr(v.ActivityID),
+		Title:      v.Title,
+		Text:       v.Text,
+		Created:    v.Created,
+		CreatedBy:  null.IntFromPtr(v.CreatedBy),
+	}
+}
+
+func (s *PullReqTextVersionStore) mapSlicePullReqTextVersion(
+	ctx context.Context,
+	versions []*pullReqTextVersion,
+) ([]*types.PullReqTextVersion, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		if v.CreatedBy.Valid {
+			ids = append(ids, v.CreatedBy.Int64)
+		}
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PR principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.PullReqTextVersion, len(versions))
+	for i, v := range versions {
+		m[i] = mapPullReqTextVersion(v)
+		if !v.CreatedBy.Valid {
+			continue
+		}
+		if author, ok := infoMap[v.CreatedBy.Int64]; ok {
+			m[i].Author = author
+		}
+	}
+
+	return m, nil
+}
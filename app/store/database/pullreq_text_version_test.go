@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestDatabase_PullReqTextVersions(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullreqStore := database.NewPullReqStore(db, nil)
+	activityStore := database.NewPullReqActivityStore(db, nil)
+	textVersionStore := database.NewPullReqTextVersionStore(db,
+		cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pr := createPullReq(ctx, t, pullreqStore, 1, 1)
+	comment := createComment(ctx, t, activityStore, pr, 1)
+
+	author := userID
+	versions := []*types.PullReqTextVersion{
+		// the original description, its author and creation time are unknown.
+		{PullReqID: pr.ID, Title: "title", Text: "v1"},
+		{PullReqID: pr.ID, Title: "title", Text: "v2", Created: 2, CreatedBy: &author},
+		{PullReqID: pr.ID, Title: "new title", Text: "v3", Created: 3, CreatedBy: &author},
+		{PullReqID: pr.ID, ActivityID: &comment.ID, Text: "c1", Created: 1, CreatedBy: &author},
+		{PullReqID: pr.ID, ActivityID: &comment.ID, Text: "c2", Created: 4, CreatedBy: &author},
+	}
+	for _, v := range versions {
+		if err := textVersionStore.Create(ctx, v); err != nil {
+			t.Fatalf("failed to create text version %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		activityID *int64
+		opts       types.Pagination
+		wantCount  int64
+		wantTexts  []string
+	}{
+		{
+			name:       "description",
+			activityID: nil,
+			opts:       types.Pagination{Page: 1, Size: 10},
+			wantCount:  3,
+			wantTexts:  []string{"v3", "v2", "v1"},
+		},
+		{
+			name:       "description second page",
+			activityID: nil,
+			opts:       types.Pagination{Page: 2, Size: 2},
+			wantCount:  3,
+			wantTexts:  []string{"v1"},
+		},
+		{
+			name:       "comment",
+			activityID: &comment.ID,
+			opts:       types.Pagination{Page: 1, Size: 10},
+			wantCount:  2,
+			wantTexts:  []string{"c2", "c1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := textVersionStore.Count(ctx, pr.ID, test.activityID)
+			if err != nil {
+				t.Fatalf("failed to count text versions %v", err)
+			}
+			if count != test.wantCount {
+				t.Errorf("Count() = %v, want %v", count, test.wantCount)
+			}
+
+			list, err := textVersionStore.List(ctx, pr.ID, test.activityID, test.opts)
+			if err != nil {
+				t.Fatalf("failed to list text versions %v", err)
+			}
+
+			texts := make([]string, len(list))
+			for i, v := range list {
+				texts[i] = v.Text
+
+				// only the versions with a known author are attributed.
+				if (v.CreatedBy == nil) != (v.Author == nil) {
+					t.Errorf("version %q: created by %v, author %v", v.Text, v.CreatedBy, v.Author)
+				}
+			}
+
+			if !slices.Equal(texts, test.wantTexts) {
+				t.Errorf("List() = %v, want %v", texts, test.wantTexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.PushMirrorStore = (*PushMirrorStore)(nil)
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) *PushMirrorStore {
+	return &PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements a store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pushMirror struct {
+	ID          int64              `db:"push_mirror_id"`
+	RepoID      int64              `db:"push_mirror_repo_id"`
+	Identifier  string             `db:"push_mirror_identifier"`
+	Created     int64              `db:"push_mirror_created"`
+	Updated     int64              `db:"push_mirror_updated"`
+	CreatedBy   int64              `db:"push_mirror_created_by"`
+	RemoteURL   string             `db:"push_mirror_remote_url"`
+	Credentials []byte             `db:"push_mirror_credentials"`
+	Refs        sqlxtypes.JSONText `db:"push_mirror_refs"`
+
+	LastSuccess null.Int `db:"push_mirror_last_success"`
+	LastFailure null.Int `db:"push_mirror_last_failure"`
+	LastError   string   `db:"push_mirror_last_error"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_created
+		,push_mirror_updated
+		,push_mirror_created_by
+		,push_mirror_remote_url
+		,push_mirror_credentials
+		,push_mirror_refs
+		,push_mirror_last_success
+		,push_mirror_last_failure
+		,push_mirror_last_error`
+
+	pushMirrorSelectBase = `
+	SELECT` + pushMirrorColumns + `
+	FROM push_mirrors`
+)
+
+// Find finds the push mirror by id.
+func (s *PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	sqlQuery := pushMirrorSelectBase + `
+	WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror")
+	}
+
+	return mapToPushMirror(dst)
+}
+
+// FindByIdentifier finds the push mirror of a repository by its identifier.
+func (s *PushMirrorStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.PushMirror, error) {
+	sqlQuery := pushMirrorSelectBase + `
+	WHERE push_mirror_repo_id = $1 AND LOWER(push_mirror_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror by identifier")
+	}
+
+	return mapToPushMirror(dst)
+}
+
+// Create saves a new push mirror.
+func (s *PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		INSERT INTO push_mirrors (
+			 push_mirror_repo_id
+			,push_mirror_identifier
+			,push_mirror_created
+			,push_mirror_updated
+			,push_mirror_created_by
+			,push_mirror_remote_url
+			,push_mirror_credentials
+			,push_mirror_refs
+			,push_mirror_last_success
+			,push_mirror_last_failure
+			,push_mirror_last_error
+		) values (
+			 :push_mirror_repo_id
+			,:push_mirror_identifier
+			,:push_mirror_created
+			,:push_mirror_updated
+			,:push_mirror_created_by
+			,:push_mirror_remote_url
+			,:push_mirror_credentials
+			,:push_mirror_refs
+			,:push_mirror_last_success
+			,:push_mirror_last_failure
+			,:push_mirror_last_error
+		) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror, err := mapToInternalPushMirror(mirror)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the remote URL, the credentials and the refs of a push mirror.
+func (s *PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_updated = :push_mirror_updated
+			,push_mirror_remote_url = :push_mirror_remote_url
+			,push_mirror_credentials = :push_mirror_credentials
+			,push_mirror_refs = :push_mirror_refs
+		WHERE push_mirror_id = :push_mirror_id`
+
+	mirror.Updated = time.Now().UnixMilli()
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateResult updates the outcome of the latest push to a push mirror.
+func (s *PushMirrorStore) UpdateResult(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_last_success = :push_mirror_last_success
+			,push_mirror_last_failure = :push_mirror_last_failure
+			,push_mirror_last_error = :push_mirror_last_error
+		WHERE push_mirror_id = :push_mirror_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *PushMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.PushMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror, err := mapToInternalPushMirror(mirror)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update push mirror query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated push mirrors")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the push mirror by id.
+func (s *PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM push_mirrors
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete push mirror query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted push mirrors")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all push mirrors of a repository.
+func (s *PushMirrorStore) List(ctx context.Context, repoID int64) ([]types.PushMirror, error) {
+	sqlQuery := pushMirrorSelectBase + `
+	WHERE push_mirror_repo_id = $1
+	ORDER BY push_mirror_identifier`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pushMirror{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list push mirrors")
+	}
+
+	mirrors := make([]types.PushMirror, len(dst))
+	for i, m := range dst {
+		mirror, err := mapToPushMirror(m)
+		if err != nil {
+			return nil, err
+		}
+		mirrors[i] = *mirror
+	}
+
+	return mirrors, nil
+}
+
+func mapToInternalPushMirror(in *types.PushMirror) (*pushMirror, error) {
+	refs, err := json.Marshal(in.Refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push mirror refs: %w", err)
+	}
+
+	return &pushMirror{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		CreatedBy:   in.CreatedBy,
+		RemoteURL:   in.RemoteURL,
+		Credentials: in.Credentials,
+		Refs:        refs,
+		LastSuccess: null.NewInt(in.LastSuccess, in.LastSuccess != 0),
+		LastFailure: null.NewInt(in.LastFailure, in.LastFailure != 0),
+		LastError:   in.LastError,
+	}, nil
+}
+
+func mapToPushMirror(in *pushMirror) (*types.PushMirror, error) {
+	var refs []string
+	if err := json.Unmarshal(in.Refs, &refs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push mirror refs: %w", err)
+	}
+
+	return &types.PushMirror{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		CreatedBy:   in.CreatedBy,
+		RemoteURL:   in.RemoteURL,
+		Credentials: in.Credentials,
+		Refs:        refs,
+		LastSuccess: in.LastSuccess.Int64,
+		LastFailure: in.LastFailure.Int64,
+		LastError:   in.LastError,
+	}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SigningKeyStore = (*SigningKeyStore)(nil)
+
+// NewSigningKeyStore returns a new SigningKeyStore.
+func NewSigningKeyStore(db *sqlx.DB) *SigningKeyStore {
+	return &SigningKeyStore{
+		db: db,
+	}
+}
+
+// SigningKeyStore implements a store.SigningKeyStore backed by a relational database.
+type SigningKeyStore struct {
+	db *sqlx.DB
+}
+
+type signingKey struct {
+	ID          int64                 `db:"signing_key_id"`
+	Type        enum.GitSignatureType `db:"signing_key_type"`
+	Created     int64                 `db:"signing_key_created"`
+	Fingerprint string                `db:"signing_key_fingerprint"`
+	PublicKey   string                `db:"signing_key_public_key"`
+	PrivateKey  []byte                `db:"signing_key_private_key"`
+}
+
+const (
+	signingKeyColumns = `
+		 signing_key_id
+		,signing_key_type
+		,signing_key_created
+		,signing_key_fingerprint
+		,signing_key_public_key
+		,signing_key_private_key`
+
+	signingKeySelectBase = `
+	SELECT` + signingKeyColumns + `
+	FROM signing_keys`
+)
+
+// FindByType finds the signing key of the provided type.
+func (s *SigningKeyStore) FindByType(ctx context.Context, keyType enum.GitSignatureType) (*types.SigningKey, error) {
+	sqlQuery := signingKeySelectBase + `
+	WHERE signing_key_type = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &signingKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, keyType); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find signing key by type")
+	}
+
+	return mapToSigningKey(dst), nil
+}
+
+// Create saves a new signing key.
+func (s *SigningKeyStore) Create(ctx context.Context, key *types.SigningKey) error {
+	const sqlQuery = `
+		INSERT INTO signing_keys (
+			 signing_key_type
+			,signing_key_created
+			,signing_key_fingerprint
+			,signing_key_public_key
+			,signing_key_private_key
+		) values (
+			 :signing_key_type
+			,:signing_key_created
+			,:signing_key_fingerprint
+			,:signing_key_public_key
+			,:signing_key_private_key
+		) RETURNING signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind signing key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert signing key query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalSigningKey(in *types.SigningKey) *signingKey {
+	return &signingKey{
+		ID:          in.ID,
+		Type:        in.Type,
+		Created:     in.Created,
+		Fingerprint: in.Fingerprint,
+		PublicKey:   in.PublicKey,
+		PrivateKey:  in.PrivateKey,
+	}
+}
+
+func mapToSigningKey(in *signingKey) *types.SigningKey {
+	return &types.SigningKey{
+		ID:          in.ID,
+		Type:        in.Type,
+		Created:     in.Created,
+		Fingerprint: in.Fingerprint,
+		PublicKey:   in.PublicKey,
+		PrivateKey:  in.PrivateKey,
+	}
+}
@@ -31,6 +31,15 @@ var WireSet = wire.NewSet(
 	ProvideDatabase,
 	ProvidePrincipalStore,
 	ProvidePrincipalInfoView,
+	ProvidePublicKeyStore,
+	ProvideGPGKeyStore,
+	ProvideSigningKeyStore,
+	ProvideDeployKeyStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvidePullMirrorStore,
+	ProvidePushMirrorStore,
+	ProvideMergeQueueStore,
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
 	ProvideRepoStore,
@@ -49,6 +58,11 @@ var WireSet = wire.NewSet(
 	ProvideCodeCommentView,
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
+	ProvidePullReqDependencyStore,
+	ProvidePullReqLabelStore,
+	ProvidePullReqReactionStore,
+	ProvidePullReqTextVersionStore,
+	ProvideLabelStore,
 	ProvidePullReqFileViewStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
@@ -85,6 +99,51 @@ func ProvidePrincipalInfoView(db *sqlx.DB) store.PrincipalInfoView {
 	return NewPrincipalInfoView(db)
 }
 
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideGPGKeyStore provides a gpg key store.
+func ProvideGPGKeyStore(db *sqlx.DB) store.GPGKeyStore {
+	return NewGPGKeyStore(db)
+}
+
+// ProvideSigningKeyStore provides a signing key store.
+func ProvideSigningKeyStore(db *sqlx.DB) store.SigningKeyStore {
+	return NewSigningKeyStore(db)
+}
+
+// ProvideDeployKeyStore provides a deploy key store.
+func ProvideDeployKeyStore(db *sqlx.DB) store.DeployKeyStore {
+	return NewDeployKeyStore(db)
+}
+
+// ProvideLFSObjectStore provides a git lfs object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides a git lfs lock store.
+func ProvideLFSLockStore(db *sqlx.DB) store.LFSLockStore {
+	return NewLFSLockStore(db)
+}
+
+// ProvidePullMirrorStore provides a pull mirror store.
+func ProvidePullMirrorStore(db *sqlx.DB) store.PullMirrorStore {
+	return NewPullMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
 // ProvideSpacePathStore provides a space path store.
 func ProvideSpacePathStore(
 	db *sqlx.DB,
@@ -220,6 +279,34 @@ func ProvidePullReqReviewerStore(db *sqlx.DB,
 	return NewPullReqReviewerStore(db, principalInfoCache)
 }
 
+// ProvidePullReqDependencyStore provides a pull request dependency store.
+func ProvidePullReqDependencyStore(db *sqlx.DB) store.PullReqDependencyStore {
+	return NewPullReqDependencyStore(db)
+}
+
+// ProvidePullReqLabelStore provides a pull request label store.
+func ProvidePullReqLabelStore(db *sqlx.DB) store.PullReqLabelStore {
+	return NewPullReqLabelStore(db)
+}
+
+// ProvidePullReqReactionStore provides a pull request reaction store.
+func ProvidePullReqReactionStore(db *sqlx.DB) store.PullReqReactionStore {
+	return NewPullReqReactionStore(db)
+}
+
+// ProvidePullReqTextVersionStore provides a pull request text version store.
+func ProvidePullReqTextVersionStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.PullReqTextVersionStore {
+	return NewPullReqTextVersionStore(db, principalInfoCache)
+}
+
+// ProvideLabelStore provides a label store.
+func ProvideLabelStore(db *sqlx.DB) store.LabelStore {
+	return NewLabelStore(db)
+}
+
 // ProvidePullReqFileViewStore provides a pull request file view store.
 func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
@@ -48,6 +48,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
 
+	// GenerateGITCloneSSHURL generates the public git clone URL via ssh for the provided repo path.
+	// NOTE: returns an empty string in case git over ssh isn't available.
+	GenerateGITCloneSSHURL(repoPath string) string
+
 	// GenerateUIRepoURL returns the url for the UI screen of a repository.
 	GenerateUIRepoURL(repoPath string) string
 
@@ -87,6 +91,9 @@ type provider struct {
 	// NOTE: we store it as url.URL so we can derive clone URLS without errors.
 	gitURL *url.URL
 
+	// gitSSHURL stores the URL the git ssh server is available at (nil if ssh isn't available).
+	gitSSHURL *url.URL
+
 	// uiURL stores the raw URL to the ui endpoints.
 	uiURL *url.URL
 }
@@ -96,6 +103,7 @@ func NewProvider(
 	containerURLRaw string,
 	apiURLRaw string,
 	gitURLRaw,
+	gitSSHURLRaw,
 	uiURLRaw string,
 ) (Provider, error) {
 	// remove trailing '/' to make usage easier
@@ -103,6 +111,7 @@ func NewProvider(
 	containerURLRaw = strings.TrimRight(containerURLRaw, "/")
 	apiURLRaw = strings.TrimRight(apiURLRaw, "/")
 	gitURLRaw = strings.TrimRight(gitURLRaw, "/")
+	gitSSHURLRaw = strings.TrimRight(gitSSHURLRaw, "/")
 	uiURLRaw = strings.TrimRight(uiURLRaw, "/")
 
 	internalURL, err := url.Parse(internalURLRaw)
@@ -125,6 +134,14 @@ func NewProvider(
 		return nil, fmt.Errorf("provided gitURLRaw '%s' is invalid: %w", gitURLRaw, err)
 	}
 
+	var gitSSHURL *url.URL
+	if gitSSHURLRaw != "" {
+		gitSSHURL, err = url.Parse(gitSSHURLRaw)
+		if err != nil {
+			return nil, fmt.Errorf("provided gitSSHURLRaw '%s' is invalid: %w", gitSSHURLRaw, err)
+		}
+	}
+
 	uiURL, err := url.Parse(uiURLRaw)
 	if err != nil {
 		return nil, fmt.Errorf("provided uiURLRaw '%s' is invalid: %w", uiURLRaw, err)
@@ -135,6 +152,7 @@ func NewProvider(
 		containerURL: containerURL,
 		apiURL:       apiURL,
 		gitURL:       gitURL,
+		gitSSHURL:    gitSSHURL,
 		uiURL:        uiURL,
 	}, nil
 }
@@ -161,6 +179,19 @@ func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	return p.gitURL.JoinPath(repoPath).String()
 }
 
+func (p *provider) GenerateGITCloneSSHURL(repoPath string) string {
+	if p.gitSSHURL == nil {
+		return ""
+	}
+
+	repoPath = path.Clean(repoPath)
+	if !strings.HasSuffix(repoPath, GITSuffix) {
+		repoPath += GITSuffix
+	}
+
+	return p.gitSSHURL.JoinPath(repoPath).String()
+}
+
 func (p *provider) GenerateUIBuildURL(repoPath, pipelineIdentifier string, seqNumber int64) string {
 	return p.uiURL.JoinPath(repoPath, "pipelines",
 		pipelineIdentifier, "execution", strconv.Itoa(int(seqNumber))).String()
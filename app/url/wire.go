@@ -29,6 +29,7 @@ func ProvideURLProvider(config *types.Config) (Provider, error) {
 		config.URL.Container,
 		config.URL.API,
 		config.URL.Git,
+		config.URL.GitSSH,
 		config.URL.UI,
 	)
 }
@@ -80,6 +80,19 @@ func (c FileSystemStore) GetSignedURL(_ context.Context, _ string) (string, erro
 	return "", ErrNotSupported
 }
 
+func (c *FileSystemStore) Exists(_ context.Context, filePath string) (bool, error) {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	_, err := os.Stat(fileDiskPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return true, nil
+}
+
 func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
 	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
 
@@ -92,3 +105,35 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Move(_ context.Context, srcPath string, dstPath string) error {
+	srcDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, srcPath)
+	dstDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, dstPath)
+
+	dir, _ := path.Split(dstDiskPath)
+	if err := os.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create parent directory for the file: %w", err)
+	}
+
+	err := os.Rename(srcDiskPath, dstDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move file: %w", err)
+	}
+	return nil
+}
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	err := os.Remove(fileDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileSystemStore(t *testing.T) Store {
+	t.Helper()
+
+	store, err := NewFileSystemStore(Config{Bucket: t.TempDir()})
+	require.NoError(t, err)
+
+	return store
+}
+
+func TestFileSystemStoreMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		upload   string
+		src      string
+		dst      string
+		wantErr  error
+		wantData string
+	}{
+		{
+			name:     "move",
+			upload:   "tmp/a",
+			src:      "tmp/a",
+			dst:      "objects/a",
+			wantData: "data",
+		},
+		{
+			name:     "move into new directories",
+			upload:   "tmp/a",
+			src:      "tmp/a",
+			dst:      "objects/x/y/a",
+			wantData: "data",
+		},
+		{
+			name:    "missing source",
+			upload:  "tmp/a",
+			src:     "tmp/b",
+			dst:     "objects/b",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestFileSystemStore(t)
+
+			require.NoError(t, store.Upload(ctx, strings.NewReader("data"), test.upload))
+
+			err := store.Move(ctx, test.src, test.dst)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = store.Download(ctx, test.src)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			rc, err := store.Download(ctx, test.dst)
+			require.NoError(t, err)
+			defer rc.Close()
+
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.Equal(t, test.wantData, string(data))
+		})
+	}
+}
+
+func TestFileSystemStoreDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		upload  string
+		delete  string
+		wantErr error
+	}{
+		{
+			name:   "delete",
+			upload: "tmp/a",
+			delete: "tmp/a",
+		},
+		{
+			name:    "missing file",
+			upload:  "tmp/a",
+			delete:  "tmp/b",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestFileSystemStore(t)
+
+			require.NoError(t, store.Upload(ctx, strings.NewReader("data"), test.upload))
+
+			err := store.Delete(ctx, test.delete)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = store.Download(ctx, test.delete)
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestFileSystemStoreExists(t *testing.T) {
+	tests := []struct {
+		name   string
+		upload string
+		path   string
+		want   bool
+	}{
+		{
+			name:   "exists",
+			upload: "archives/main.zip",
+			path:   "archives/main.zip",
+			want:   true,
+		},
+		{
+			name:   "missing file",
+			upload: "archives/main.zip",
+			path:   "archives/main.tar.gz",
+			want:   false,
+		},
+		{
+			name:   "missing directory",
+			upload: "archives/main.zip",
+			path:   "other/main.zip",
+			want:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestFileSystemStore(t)
+
+			require.NoError(t, store.Upload(ctx, strings.NewReader("data"), test.upload))
+
+			exists, err := store.Exists(ctx, test.path)
+			require.NoError(t, err)
+			require.Equal(t, test.want, exists)
+		})
+	}
+}
@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -108,8 +109,78 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	return signedURL, nil
 }
 
-func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error) {
-	return nil, fmt.Errorf("not implemented")
+func (c *GCSStore) Exists(ctx context.Context, filePath string) (bool, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	_, err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get attributes of file '%s' in bucket '%s': %w",
+			filePath, c.config.Bucket, err)
+	}
+	return true, nil
+}
+
+func (c *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+	rc, err := bkt.Object(filePath).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reader for file '%s' in bucket '%s': %w",
+			filePath, c.config.Bucket, err)
+	}
+	return rc, nil
+}
+
+func (c *GCSStore) Move(ctx context.Context, srcPath string, dstPath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+	src := bkt.Object(srcPath)
+	_, err = bkt.Object(dstPath).CopierFrom(src).Run(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy file '%s' to '%s' in bucket '%s': %w",
+			srcPath, dstPath, c.config.Bucket, err)
+	}
+
+	if err = src.Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file '%s' from bucket '%s': %w", srcPath, c.config.Bucket, err)
+	}
+	return nil
+}
+
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete file '%s' from bucket '%s': %w", filePath, c.config.Bucket, err)
+	}
+	return nil
 }
 
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
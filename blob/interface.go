@@ -32,6 +32,15 @@ type Store interface {
 	// GetSignedURL returns the URL for a file in the blob store.
 	GetSignedURL(ctx context.Context, filePath string) (string, error)
 
+	// Exists returns whether a file exists in the blob store.
+	Exists(ctx context.Context, filePath string) (bool, error)
+
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Move moves a file to a new path in the blob store, replacing any existing file at the destination.
+	Move(ctx context.Context, srcPath string, dstPath string) error
+
+	// Delete deletes a file from the blob store.
+	Delete(ctx context.Context, filePath string) error
 }
@@ -46,6 +46,8 @@ import (
 const (
 	schemeHTTP     = "http"
 	schemeHTTPS    = "https"
+	schemeSSH      = "ssh"
+	defaultSSHPort = 22
 	gitnessHomeDir = ".gitness"
 	blobDir        = "blob"
 )
@@ -165,6 +167,21 @@ func backfillURLs(config *types.Config) error {
 		config.URL.UI = baseURL.String()
 	}
 
+	// backfill git ssh url only in case the ssh server is enabled
+	if config.URL.GitSSH == "" && config.SSH.Enable {
+		sshHost := host
+		if config.SSH.DefaultUser != "" {
+			sshHost = config.SSH.DefaultUser + "@" + sshHost
+		}
+
+		sshPort := ""
+		if config.SSH.Port != defaultSSHPort {
+			sshPort = fmt.Sprint(config.SSH.Port)
+		}
+
+		config.URL.GitSSH = combineToRawURL(schemeSSH, sshHost, sshPort, "")
+	}
+
 	return nil
 }
 
@@ -216,3 +216,42 @@ func TestBackfillURLsCustom(t *testing.T) {
 	require.Equal(t, "https://Git:443/Git/p", config.URL.Git)
 	require.Equal(t, "http://UI:80/UI/p", config.URL.UI)
 }
+
+func TestBackfillURLsSSHDisabled(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+	config.SSH.Port = 3022
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "", config.URL.GitSSH)
+}
+
+func TestBackfillURLsSSH(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+	config.URL.Base = "https://xyz:4321/test"
+	config.SSH.Enable = true
+	config.SSH.Port = 3022
+	config.SSH.DefaultUser = "git"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "ssh://git@xyz:3022", config.URL.GitSSH)
+}
+
+func TestBackfillURLsSSHDefaultPort(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+	config.URL.Base = "https://xyz:4321/test"
+	config.SSH.Enable = true
+	config.SSH.Port = 22
+	config.SSH.DefaultUser = "git"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "ssh://git@xyz", config.URL.GitSSH)
+}
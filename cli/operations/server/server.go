@@ -111,6 +111,17 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
+
+	var shutdownSSH func(context.Context) error
+	if config.SSH.Enable {
+		var gSSH *errgroup.Group
+		gSSH, shutdownSSH = system.sshServer.ListenAndServe()
+		g.Go(gSSH.Wait)
+
+		log.Info().
+			Int("port", config.SSH.Port).
+			Msg("ssh server started")
+	}
 	if c.enableCI {
 		// start populating plugins
 		g.Go(func() error {
@@ -152,6 +163,12 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
+	if shutdownSSH != nil {
+		if sErr := shutdownSSH(shutdownCtx); sErr != nil {
+			log.Err(sErr).Msg("failed to shutdown ssh server gracefully")
+		}
+	}
+
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
 	log.Info().Msg("wait for subroutines to complete")
@@ -27,17 +27,19 @@ import (
 type System struct {
 	bootstrap       bootstrap.Bootstrap
 	server          *server.Server
+	sshServer       *server.SSHServer
 	resolverManager *resolver.Manager
 	poller          *poller.Poller
 	services        services.Services
 }
 
 // NewSystem returns a new system structure.
-func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, poller *poller.Poller,
-	resolverManager *resolver.Manager, services services.Services) *System {
+func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, sshServer *server.SSHServer,
+	poller *poller.Poller, resolverManager *resolver.Manager, services services.Services) *System {
 	return &System{
 		bootstrap:       bootstrap,
 		server:          server,
+		sshServer:       sshServer,
 		poller:          poller,
 		resolverManager: resolverManager,
 		services:        services,
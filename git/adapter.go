@@ -55,6 +55,8 @@ type Adapter interface {
 		opts *types.WalkReferencesOptions) error
 	GetCommit(ctx context.Context, repoPath string, ref string) (*types.Commit, error)
 	GetCommits(ctx context.Context, repoPath string, refs []string) ([]types.Commit, error)
+	ListCommitSignedData(ctx context.Context, repoPath string, alternateObjectDirs []string,
+		rev string, exclude []string, excludeAllRefs bool) ([]types.CommitSignedData, error)
 	ListCommits(
 		ctx context.Context,
 		repoPath string,
@@ -83,6 +85,9 @@ type Adapter interface {
 	IsAncestor(ctx context.Context, repoPath, ancestorCommitSHA, descendantCommitSHA string) (bool, error)
 	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
 	Sync(ctx context.Context, repoPath string, source string, refSpecs []string) error
+	FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs []string) error
+	RepackAll(ctx context.Context, repoPath string) error
+	Archive(ctx context.Context, repoPath string, params types.ArchiveParams, w io.Writer) error
 
 	//
 	// Diff operations
@@ -143,6 +148,7 @@ type Adapter interface {
 		ctx context.Context,
 		repoPath string,
 		service string,
+		statelessRPC bool,
 		stdin io.Reader,
 		stdout io.Writer,
 		env ...string,
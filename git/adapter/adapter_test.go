@@ -99,3 +99,40 @@ func testParseTagDataFromCatFileFor(t *testing.T, object string, typ types.GitOb
 	require.Equal(t, tagger.Identity.Email, res.Tagger.Identity.Email, data)
 	require.Equal(t, tagger.When, res.Tagger.When, data)
 }
+
+func TestParseTagDataFromCatFileSigned(t *testing.T) {
+	signature := "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n"
+	signedContent := "object sha012\ntype commit\ntag v1.0\n" +
+		"tagger max <max@mail.com> 1666401234 -0700\n\nsome title\n\nsome message\n"
+
+	res, err := parseTagDataFromCatFile([]byte(signedContent + signature))
+	require.NoError(t, err)
+
+	require.Equal(t, "some title", res.Title)
+	require.Equal(t, "some title\n\nsome message", res.Message)
+	require.NotNil(t, res.SignedData)
+	require.Equal(t, signature, string(res.SignedData.Signature))
+	require.Equal(t, signedContent, string(res.SignedData.SignedContent))
+
+	// a signature that isn't at the end of the message is just part of the message
+	res, err = parseTagDataFromCatFile([]byte(signedContent + signature + "more text\n"))
+	require.NoError(t, err)
+	require.Nil(t, res.SignedData)
+}
+
+func TestParseSignedDataFromCommit(t *testing.T) {
+	headers := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author max <max@mail.com> 1666401234 -0700\n" +
+		"committer max <max@mail.com> 1666401234 -0700\n"
+	message := "\nsome title\n\nsome message\n"
+
+	signedData := parseSignedDataFromCommit([]byte(headers +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n \n wsBcBAABCAAQ\n -----END PGP SIGNATURE-----\n" +
+		message))
+	require.NotNil(t, signedData)
+	require.Equal(t, "-----BEGIN PGP SIGNATURE-----\n\nwsBcBAABCAAQ\n-----END PGP SIGNATURE-----\n",
+		string(signedData.Signature))
+	require.Equal(t, headers+message, string(signedData.SignedContent))
+
+	require.Nil(t, parseSignedDataFromCommit([]byte(headers+message)))
+}
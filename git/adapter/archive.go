@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/types"
+)
+
+// Archive writes an archive of the repository at the provided reference to w.
+func (a Adapter) Archive(
+	ctx context.Context,
+	repoPath string,
+	params types.ArchiveParams,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if params.Ref == "" {
+		return errors.InvalidArgument("reference cannot be empty")
+	}
+
+	cmd := command.New("archive",
+		command.WithFlag("--format="+string(params.Format)),
+	)
+	if params.Prefix != "" {
+		cmd.Add(command.WithFlag("--prefix=" + params.Prefix))
+	}
+	cmd.Add(command.WithArg(params.Ref))
+	if len(params.Paths) > 0 {
+		cmd.Add(command.WithPostSepArg(params.Paths...))
+	}
+
+	stderr := new(bytes.Buffer)
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(w),
+		command.WithStderr(stderr),
+	)
+	if err != nil {
+		return processGiteaErrorf(err, "failed to create archive: %s", stderr)
+	}
+
+	return nil
+}
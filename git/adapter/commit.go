@@ -15,9 +15,12 @@
 package adapter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -466,7 +469,157 @@ func (a Adapter) GetCommit(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return GetCommit(ctx, repoPath, rev, "")
+	commit, err := GetCommit(ctx, repoPath, rev, "")
+	if err != nil {
+		return nil, err
+	}
+
+	commit.SignedData, err = getCommitSignedData(ctx, repoPath, commit.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit, nil
+}
+
+// getCommitSignedData returns the signature of the commit, or nil if the commit isn't signed.
+func getCommitSignedData(
+	ctx context.Context,
+	repoPath string,
+	sha string,
+) (*types.SignedData, error) {
+	cmd := command.New("cat-file",
+		command.WithArg("commit", sha),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to read commit object '%s'", sha)
+	}
+
+	return parseSignedDataFromCommit(output.Bytes()), nil
+}
+
+// ListCommitSignedData returns the signatures of all commits reachable from rev,
+// excluding the commits reachable from any of the excluded revisions (or from any reference if excludeAllRefs is set).
+// The alternate object directories allow to read commits that are still in the quarantine directory of a push.
+func (a Adapter) ListCommitSignedData(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	rev string,
+	exclude []string,
+	excludeAllRefs bool,
+) ([]types.CommitSignedData, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	var env []string
+	if len(alternateObjectDirs) > 0 {
+		env = append(env,
+			command.GitAlternateObjectDirs, strings.Join(alternateObjectDirs, string(os.PathListSeparator)))
+	}
+
+	revListArgs := []string{rev}
+	for _, ex := range exclude {
+		revListArgs = append(revListArgs, "^"+ex)
+	}
+	if excludeAllRefs {
+		revListArgs = append(revListArgs, "--not", "--all")
+	}
+
+	revList := &bytes.Buffer{}
+	cmd := command.New("rev-list", command.WithEnv(env...), command.WithArg(revListArgs...))
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(revList)); err != nil {
+		return nil, processGiteaErrorf(err, "failed to list commits of '%s'", rev)
+	}
+
+	shas := parseLinesToSlice(revList.Bytes())
+	if len(shas) == 0 {
+		return []types.CommitSignedData{}, nil
+	}
+
+	objects := &bytes.Buffer{}
+	cmd = command.New("cat-file", command.WithEnv(env...), command.WithFlag("--batch"))
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(strings.Join(shas, "\n")+"\n")),
+		command.WithStdout(objects),
+	)
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to read commit objects")
+	}
+
+	result := make([]types.CommitSignedData, 0, len(shas))
+	reader := bufio.NewReader(objects)
+	for range shas {
+		sha, objType, size, err := ReadBatchHeaderLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit object header: %w", err)
+		}
+		if objType != string(types.GitObjectTypeCommit) {
+			return nil, fmt.Errorf("object '%s' is of type %s, expected a commit", sha, objType)
+		}
+
+		// the object content is followed by a new line.
+		data := make([]byte, size+1)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, fmt.Errorf("failed to read commit object '%s': %w", sha, err)
+		}
+
+		result = append(result, types.CommitSignedData{
+			SHA:        string(sha),
+			SignedData: parseSignedDataFromCommit(data[:size]),
+		})
+	}
+
+	return result, nil
+}
+
+// parseSignedDataFromCommit extracts the signature from the raw data of a commit object.
+// The signature is stored in the gpgsig header, the signed content is the commit object without that header.
+func parseSignedDataFromCommit(data []byte) *types.SignedData {
+	const signatureHeader = "gpgsig "
+
+	var signature []byte
+	signedContent := make([]byte, 0, len(data))
+	inSignature := false
+
+	for len(data) > 0 {
+		lineLen := bytes.IndexByte(data, '\n') + 1
+		if lineLen == 0 {
+			lineLen = len(data)
+		}
+		line := data[:lineLen]
+		data = data[lineLen:]
+
+		switch {
+		case line[0] == '\n':
+			// end of headers, the remainder is the commit message.
+			signedContent = append(signedContent, line...)
+			signedContent = append(signedContent, data...)
+			data = nil
+		case signature == nil && bytes.HasPrefix(line, []byte(signatureHeader)):
+			inSignature = true
+			signature = append(signature, line[len(signatureHeader):]...)
+		case inSignature && line[0] == ' ':
+			// continuation lines of multi-line headers are indented by a single space.
+			signature = append(signature, line[1:]...)
+		default:
+			inSignature = false
+			signedContent = append(signedContent, line...)
+		}
+	}
+
+	if signature == nil {
+		return nil
+	}
+
+	return &types.SignedData{
+		Signature:     signature,
+		SignedContent: signedContent,
+	}
 }
 
 func (a Adapter) GetFullCommitID(
@@ -62,6 +62,7 @@ func (a Adapter) ServicePack(
 	ctx context.Context,
 	repoPath string,
 	service string,
+	statelessRPC bool,
 	stdin io.Reader,
 	stdout io.Writer,
 	env ...string,
@@ -72,8 +73,12 @@ func (a Adapter) ServicePack(
 	var (
 		stderr bytes.Buffer
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
-	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
+	args := []string{service}
+	if statelessRPC {
+		args = append(args, "--stateless-rpc")
+	}
+	cmd := git.NewCommand(ctx, append(args, repoPath)...)
+	cmd.SetDescription(fmt.Sprintf("%s %s [repo_path: %s]", git.GitExecutable, strings.Join(args, " "), repoPath))
 	err := cmd.Run(&git.RunOpts{
 		Dir:               repoPath,
 		Env:               env,
@@ -76,14 +76,22 @@ func mapGiteaCommit(giteaCommit *gitea.Commit) (*types.Commit, error) {
 	for i := range giteaCommit.Parents {
 		parentShas[i] = giteaCommit.Parents[i].String()
 	}
+	var signedData *types.SignedData
+	if giteaCommit.Signature != nil {
+		signedData = &types.SignedData{
+			Signature:     []byte(giteaCommit.Signature.Signature),
+			SignedContent: []byte(giteaCommit.Signature.Payload),
+		}
+	}
 	return &types.Commit{
 		SHA:        giteaCommit.ID.String(),
 		ParentSHAs: parentShas,
 		Title:      giteaCommit.Summary(),
 		// remove potential tailing newlines from message
-		Message:   strings.TrimRight(giteaCommit.Message(), "\n"),
-		Author:    author,
-		Committer: committer,
+		Message:    strings.TrimRight(giteaCommit.Message(), "\n"),
+		Author:     author,
+		Committer:  committer,
+		SignedData: signedData,
 	}, nil
 }
 
@@ -179,6 +179,27 @@ func (a Adapter) Clone(
 	return nil
 }
 
+// RepackAll packs all objects of the repository into a single pack, including the objects
+// that are only available via alternates, and removes the redundant packs and loose objects.
+func (a Adapter) RepackAll(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a"),
+		command.WithFlag("-d"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return fmt.Errorf("failed to repack repository: %w", err)
+	}
+
+	return nil
+}
+
 // Sync synchronizes the repository to match the provided source.
 // NOTE: This is a read operation and doesn't trigger any server side hooks.
 func (a Adapter) Sync(
@@ -219,6 +240,45 @@ func (a Adapter) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects (and everything reachable from them)
+// from the source repository without updating any references.
+func (a Adapter) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	// protocol v2 allows fetching any object the source has, not only advertised ones.
+	args := []string{
+		"-c", "protocol.version=2",
+		"-c", "credential.helper=",
+		"fetch",
+		"--quiet",
+		"--no-tags",
+		"--no-write-fetch-head",
+		source,
+	}
+	args = append(args, objectSHAs...)
+
+	cmd := gitea.NewCommand(ctx, args...)
+	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+		Dir:               repoPath,
+		UseContextTimeout: true,
+	})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
 func (a Adapter) AddFiles(
 	repoPath string,
 	all bool,
@@ -291,12 +351,19 @@ func (a Adapter) Push(
 	if opts.Mirror {
 		cmd.AddArguments("--mirror")
 	}
+	if opts.Prune {
+		cmd.AddArguments("--prune")
+	}
 	cmd.AddArguments("--", opts.Remote)
 
 	if len(opts.Branch) > 0 {
 		cmd.AddArguments(opts.Branch)
 	}
 
+	for _, refSpec := range opts.RefSpecs {
+		cmd.AddArguments(refSpec)
+	}
+
 	// remove credentials if there are any
 	if strings.Contains(opts.Remote, "://") && strings.Contains(opts.Remote, "@") {
 		opts.Remote = util.SanitizeCredentialURLs(opts.Remote)
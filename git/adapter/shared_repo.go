@@ -482,7 +482,7 @@ func (r *SharedRepo) CommitTreeWithDate(
 		args = []string{"commit-tree", treeHash}
 	}
 
-	// temporary no signing
+	// never sign using the git configuration - signing is done afterwards, see signing.SignCommit.
 	args = append(args, "--no-gpg-sign")
 
 	if signoff {
@@ -31,6 +31,8 @@ import (
 const (
 	pgpSignatureBeginToken = "\n-----BEGIN PGP SIGNATURE-----\n" //#nosec G101
 	pgpSignatureEndToken   = "\n-----END PGP SIGNATURE-----"     //#nosec G101
+	sshSignatureBeginToken = "\n-----BEGIN SSH SIGNATURE-----\n" //#nosec G101
+	sshSignatureEndToken   = "\n-----END SSH SIGNATURE-----"     //#nosec G101
 )
 
 // GetAnnotatedTag returns the tag for a specific tag sha.
@@ -188,8 +190,18 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 		return tag, err
 	}
 
+	// signatures of annotated tags are appended to the message, the signed content is everything before.
+	remainder := data[p:]
+	if signatureStart := findTagSignatureStart(remainder); signatureStart >= 0 {
+		tag.SignedData = &types.SignedData{
+			Signature:     remainder[signatureStart:],
+			SignedContent: data[:p+signatureStart],
+		}
+		remainder = remainder[:signatureStart]
+	}
+
 	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	message := string(bytes.Trim(remainder, "\n"))
 
 	// handle gpg signature
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
@@ -212,6 +224,35 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 	return tag, nil
 }
 
+// findTagSignatureStart returns the index at which the signature appended to the tag message starts,
+// or -1 in case the tag isn't signed.
+func findTagSignatureStart(remainder []byte) int {
+	tokens := [][2]string{
+		{pgpSignatureBeginToken, pgpSignatureEndToken},
+		{sshSignatureBeginToken, sshSignatureEndToken},
+	}
+
+	// prepend a new line to also find signatures of tags without message.
+	remainder = append([]byte{'\n'}, remainder...)
+
+	for _, token := range tokens {
+		begin := bytes.LastIndex(remainder, []byte(token[0]))
+		if begin < 0 {
+			continue
+		}
+
+		// the signature has to be at the very end of the tag.
+		if !bytes.HasSuffix(bytes.TrimRight(remainder[begin:], "\n"), []byte(token[1])) {
+			continue
+		}
+
+		// skip the leading new line of the token (which cancels out with the prepended new line).
+		return begin
+	}
+
+	return -1
+}
+
 func parseCatFileLine(data []byte, start int, header string) (string, int, error) {
 	// for simplicity only look at data from start onwards
 	data = data[start:]
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+type ArchiveParams struct {
+	ReadParams
+	Format enum.ArchiveFormat
+	// Ref is the git reference (branch / tag / commit SHA) to archive.
+	Ref string
+	// Prefix is prepended to every path in the archive (optional), e.g. "repo-main/".
+	Prefix string
+	// Paths restricts the archive to the provided paths (optional).
+	Paths []string
+}
+
+func (p *ArchiveParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if _, ok := p.Format.Sanitize(); !ok {
+		return errors.InvalidArgument("unsupported archive format %q", p.Format)
+	}
+
+	if p.Ref == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	return nil
+}
+
+// Archive streams an archive of the repository at the provided git ref to w.
+func (s *Service) Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	return s.adapter.Archive(ctx, repoPath, types.ArchiveParams{
+		Format: params.Format,
+		Ref:    params.Ref,
+		Prefix: params.Prefix,
+		Paths:  params.Paths,
+	}, w)
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/types"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// CommitSHA is the commit that is cherry-picked.
+	CommitSHA string
+	// FromSHA optionally specifies the start (exclusive) of a range of commits.
+	// If provided, all non-merge commits in the range FromSHA..CommitSHA are cherry-picked.
+	FromSHA string
+
+	// BranchName is the branch the commits are applied to.
+	BranchName string
+	// NewBranchName optionally specifies the name of a new branch that is created for the result.
+	// If provided, BranchName is left unchanged.
+	NewBranchName string
+
+	// Committer overwrites the git committer used for the new commits
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for the new commits
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA == "" {
+		return errors.InvalidArgument("commit sha is mandatory")
+	}
+
+	if p.BranchName == "" {
+		return errors.InvalidArgument("branch name is mandatory")
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// BaseSHA is the sha of the latest commit on the branch the commits were applied to.
+	BaseSHA string
+	// CommitSHA is the sha of the latest commit after the commits were applied.
+	// It's empty if the commits couldn't be applied because of conflicts.
+	CommitSHA string
+
+	ConflictFiles []string
+}
+
+// CherryPick applies the changes introduced by a commit (or a range of commits) to a branch.
+// The author and the message of the cherry-picked commits are preserved.
+// If the commits can't be applied because of conflicts, the conflicting files are returned and no branch is updated.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.CommitSHA)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to resolve commit %q: %w", params.CommitSHA, err)
+	}
+
+	var fromSHA string
+	if params.FromSHA != "" {
+		fromSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, params.FromSHA)
+		if err != nil {
+			return CherryPickOutput{}, fmt.Errorf("failed to resolve commit %q: %w", params.FromSHA, err)
+		}
+	}
+
+	baseSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.BranchName)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to resolve branch %q: %w", params.BranchName, err)
+	}
+
+	committer := types.Signature{Identity: types.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = types.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	newSHA, conflicts, err := merge.CherryPick(ctx, repoPath, s.tmpDir, s.signer, &committer,
+		baseSHA, fromSHA, commitSHA)
+	if err != nil {
+		return CherryPickOutput{}, errors.Internal(err, "failed to cherry-pick %q to %q", commitSHA, params.BranchName)
+	}
+	if len(conflicts) > 0 {
+		return CherryPickOutput{
+			BaseSHA:       baseSHA,
+			ConflictFiles: conflicts,
+		}, nil
+	}
+
+	if newSHA == baseSHA {
+		return CherryPickOutput{}, errors.InvalidArgument(
+			"the cherry-picked commits don't introduce any changes to the branch %q", params.BranchName)
+	}
+
+	err = s.updateBranchWithCommit(ctx, params.EnvVars, repoPath,
+		params.BranchName, params.NewBranchName, baseSHA, newSHA)
+	if err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	return CherryPickOutput{
+		BaseSHA:   baseSHA,
+		CommitSHA: newSHA,
+	}, nil
+}
+
+// updateBranchWithCommit points the branch to the new commit, assuming the branch is still on the base commit.
+// If a new branch name is provided, a new branch is created instead and the branch is left untouched.
+func (s *Service) updateBranchWithCommit(
+	ctx context.Context,
+	envVars map[string]string,
+	repoPath string,
+	branchName, newBranchName string,
+	baseSHA, newSHA string,
+) error {
+	refName := branchName
+	oldValue := baseSHA
+	if newBranchName != "" {
+		refName = newBranchName
+		oldValue = types.NilSHA // the new branch must not exist yet
+	}
+
+	refPath, err := GetRefPath(refName, enum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to generate full reference for branch %q: %w", refName, err)
+	}
+
+	err = s.adapter.UpdateRef(ctx, envVars, repoPath, refPath, oldValue, newSHA)
+	if err != nil {
+		return fmt.Errorf("failed to update branch %q: %w", refName, err)
+	}
+
+	return nil
+}
@@ -38,6 +38,14 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	SignedData *SignedData       `json:"-"`
+}
+
+// SignedData contains the raw signature of a signed git object (commit or annotated tag)
+// together with the content the signature was created for.
+type SignedData struct {
+	Signature     []byte
+	SignedContent []byte
 }
 
 type GetCommitOutput struct {
@@ -193,6 +201,74 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListCommitSignaturesParams struct {
+	ReadParams
+	// GitREF is the git reference (branch / tag / commit SHA) of the commits that should be returned.
+	GitREF string
+	// Exclude contains git references whose commits are excluded from the result.
+	Exclude []string
+	// ExcludeAllRefs excludes all commits that are reachable from any existing reference of the repository.
+	ExcludeAllRefs bool
+}
+
+func (p *ListCommitSignaturesParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git reference cannot be empty")
+	}
+
+	return nil
+}
+
+type ListCommitSignaturesOutput struct {
+	Commits []CommitSignedData
+}
+
+// CommitSignedData contains the signature of a commit. SignedData is nil if the commit isn't signed.
+type CommitSignedData struct {
+	SHA        string
+	SignedData *SignedData
+}
+
+// ListCommitSignatures returns the raw signatures of all commits reachable from the provided reference.
+func (s *Service) ListCommitSignatures(
+	ctx context.Context,
+	params ListCommitSignaturesParams,
+) (ListCommitSignaturesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ListCommitSignaturesOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	result, err := s.adapter.ListCommitSignedData(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		params.Exclude,
+		params.ExcludeAllRefs,
+	)
+	if err != nil {
+		return ListCommitSignaturesOutput{}, err
+	}
+
+	commits := make([]CommitSignedData, len(result))
+	for i := range result {
+		commits[i] = CommitSignedData{
+			SHA:        result[i].SHA,
+			SignedData: mapSignedData(result[i].SignedData),
+		}
+	}
+
+	return ListCommitSignaturesOutput{
+		Commits: commits,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -21,6 +21,10 @@ import (
 // ReadParams contains the base parameters for read operations.
 type ReadParams struct {
 	RepoUID string
+
+	// AlternateObjectDirs contains object directories that are used in addition to the repository's own.
+	// This is used to read objects that are still in the quarantine directory of an ongoing push.
+	AlternateObjectDirs []string
 }
 
 func (p ReadParams) Validate() error {
@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxAlternatesDepth is the maximum nesting depth of alternates git is following.
+// A fork shares the objects of its upstream repository which itself could be a fork,
+// so this limits the length of fork chains.
+const maxAlternatesDepth = 5
+
+type ForkRepositoryParams struct {
+	// Fork operation creates a new repository, so the UID doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is being forked.
+	UpstreamRepoUID string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository id cannot be empty")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID           string
+	DefaultBranch string
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+
+	// SourceRepoUID is the UID of the repository the objects are fetched from.
+	SourceRepoUID string
+	ObjectSHAs    []string
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository id cannot be empty")
+	}
+
+	if len(p.ObjectSHAs) == 0 {
+		return errors.InvalidArgument("at least one object sha is required")
+	}
+
+	return nil
+}
+
+// ForkRepository creates a new repository with all branches and tags of the upstream repository.
+// The objects aren't copied - the fork references the object directory of the upstream repository
+// using git alternates and only new objects are stored in the object directory of the fork.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	upstreamPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamPath); os.IsNotExist(err) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the upstream repository: %w", err)
+	}
+
+	depth, err := alternatesDepth(upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alternates of the upstream repository: %w", err)
+	}
+	if depth >= maxAlternatesDepth {
+		return nil, errors.InvalidArgument("the upstream repository is a fork nested too deeply to be forked")
+	}
+
+	repoUID, err := NewRepositoryUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new uid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, repoUID)
+
+	log.Ctx(ctx).Info().Msgf("Fork git repository '%s' to '%s'", params.UpstreamRepoUID, repoUID)
+
+	err = s.adapter.InitRepository(ctx, repoPath, true)
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			cleanuperr := s.DeleteRepositoryBestEffort(ctx, repoUID)
+			if cleanuperr != nil {
+				log.Ctx(ctx).Warn().Err(cleanuperr).Msg("failed to cleanup repo dir")
+			}
+		}
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to initialize the repository: %w", err)
+	}
+
+	alternates := filepath.Join(repoPath, "objects", "info", "alternates")
+	err = os.WriteFile(alternates, []byte(filepath.Join(upstreamPath, "objects")+"\n"), 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to write alternates file: %w", err)
+	}
+
+	// objects of the upstream repository must never be pruned because they could be used by a fork.
+	err = s.adapter.Config(ctx, upstreamPath, "gc.pruneExpire", "never")
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to disable pruning in the upstream repository: %w", err)
+	}
+
+	// the objects are available through the alternates, so the fetch only creates the references.
+	err = s.adapter.Sync(ctx, repoPath, upstreamPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to fetch references from the upstream repository: %w", err)
+	}
+
+	defaultBranch, err := s.adapter.GetDefaultBranch(ctx, upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to get default branch of the upstream repository: %w", err)
+	}
+
+	err = s.adapter.SetDefaultBranch(ctx, repoPath, defaultBranch, true)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to set default branch: %w", err)
+	}
+
+	if err = s.setupServerHooks(repoPath); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().Msgf("repository forked. Path: %s", repoPath)
+
+	return &ForkRepositoryOutput{
+		UID:           repoUID,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+// DissociateRepository copies all objects the repository borrows from other repositories via alternates
+// into its own object directory and removes the alternates.
+// It has to be called for all forks of a repository before the repository can be deleted.
+func (s *Service) DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	alternates := filepath.Join(repoPath, "objects", "info", "alternates")
+
+	if _, err := os.Stat(alternates); os.IsNotExist(err) {
+		// repository doesn't use alternates, nothing to do.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check the status of the alternates file: %w", err)
+	}
+
+	if err := s.adapter.RepackAll(ctx, repoPath); err != nil {
+		return fmt.Errorf("DissociateRepository: %w", err)
+	}
+
+	if err := os.Remove(alternates); err != nil {
+		return fmt.Errorf("DissociateRepository: failed to remove alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// FetchObjects copies the provided objects, with everything reachable from them, from the source repository
+// into the repository. No references are updated. It's used to make commits of a fork available in its upstream.
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.SourceRepoUID == params.RepoUID {
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	if err := s.adapter.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs); err != nil {
+		return fmt.Errorf("FetchObjects: %w", err)
+	}
+
+	return nil
+}
+
+// alternatesDepth returns the nesting depth of the alternates of the repository.
+func alternatesDepth(repoPath string) (int, error) {
+	depth := 0
+	objectsPath := filepath.Join(repoPath, "objects")
+
+	for {
+		f, err := os.Open(filepath.Join(objectsPath, "info", "alternates"))
+		if os.IsNotExist(err) {
+			return depth, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		var next string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				next = line
+				break
+			}
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return 0, err
+		}
+
+		if next == "" || depth >= maxAlternatesDepth {
+			return depth, nil
+		}
+
+		depth++
+		objectsPath = next
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAlternatesDepth(t *testing.T) {
+	root := t.TempDir()
+
+	// newRepo creates the objects directory of a repository, optionally with the provided alternates file content.
+	newRepo := func(t *testing.T, name string, alternates string) string {
+		t.Helper()
+
+		repoPath := filepath.Join(root, name)
+		infoPath := filepath.Join(repoPath, "objects", "info")
+		if err := os.MkdirAll(infoPath, 0o755); err != nil {
+			t.Fatalf("failed to create objects directory: %v", err)
+		}
+
+		if alternates != "" {
+			err := os.WriteFile(filepath.Join(infoPath, "alternates"), []byte(alternates), 0o600)
+			if err != nil {
+				t.Fatalf("failed to write alternates file: %v", err)
+			}
+		}
+
+		return repoPath
+	}
+
+	objects := func(repoPath string) string {
+		return filepath.Join(repoPath, "objects") + "\n"
+	}
+
+	upstream := newRepo(t, "upstream", "")
+	fork := newRepo(t, "fork", objects(upstream))
+	forkOfFork := newRepo(t, "fork-of-fork", objects(fork))
+	commented := newRepo(t, "commented", "# comment\n\n"+objects(upstream))
+	onlyComments := newRepo(t, "only-comments", "# comment\n")
+
+	tests := []struct {
+		name     string
+		repoPath string
+		want     int
+	}{
+		{
+			name:     "no alternates",
+			repoPath: upstream,
+			want:     0,
+		},
+		{
+			name:     "fork",
+			repoPath: fork,
+			want:     1,
+		},
+		{
+			name:     "fork of a fork",
+			repoPath: forkOfFork,
+			want:     2,
+		},
+		{
+			name:     "comments are ignored",
+			repoPath: commented,
+			want:     1,
+		},
+		{
+			name:     "no alternates, only comments",
+			repoPath: onlyComments,
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := alternatesDepth(tt.repoPath)
+			if err != nil {
+				t.Errorf("alternatesDepth() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("alternatesDepth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchObjectsParamsValidate(t *testing.T) {
+	writeParams := WriteParams{
+		RepoUID: "target",
+		Actor:   Identity{Name: "gitness", Email: "system@gitness.io"},
+	}
+
+	tests := []struct {
+		name    string
+		params  FetchObjectsParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: FetchObjectsParams{
+				WriteParams:   writeParams,
+				SourceRepoUID: "source",
+				ObjectSHAs:    []string{"1111111111111111111111111111111111111111"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing target repository",
+			params: FetchObjectsParams{
+				SourceRepoUID: "source",
+				ObjectSHAs:    []string{"1111111111111111111111111111111111111111"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing source repository",
+			params: FetchObjectsParams{
+				WriteParams: writeParams,
+				ObjectSHAs:  []string{"1111111111111111111111111111111111111111"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing objects",
+			params: FetchObjectsParams{
+				WriteParams:   writeParams,
+				SourceRepoUID: "source",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -48,7 +49,8 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		Environment: getEnvironment(),
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -110,6 +112,33 @@ func handleServerHookOutput(out Output, err error) error {
 	return nil
 }
 
+// getEnvironment returns the git environment the hook is executed in.
+// During a push git stores the received objects in a quarantine directory and only exposes them
+// to the hooks via environment variables, which is why the object directories have to be forwarded.
+// For more details see https://git-scm.com/docs/git-receive-pack#_quarantine_environment
+func getEnvironment() Environment {
+	var dirs []string
+
+	if dir := os.Getenv(envNameGitObjectDirectory); dir != "" {
+		dirs = append(dirs, dir)
+	}
+
+	if alternates := os.Getenv(envNameGitAlternateObjectDirectories); alternates != "" {
+		dirs = append(dirs, filepath.SplitList(alternates)...)
+	}
+
+	// the hook is executed in the repository directory, ensure the server can resolve relative paths.
+	for i := range dirs {
+		if abs, err := filepath.Abs(dirs[i]); err == nil {
+			dirs[i] = abs
+		}
+	}
+
+	return Environment{
+		AlternateObjectDirs: dirs,
+	}
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
@@ -26,6 +26,13 @@ import (
 const (
 	// envNamePayload defines the environment variable name used to send the payload to githook binary.
 	envNamePayload = "GIT_HOOK_PAYLOAD"
+
+	// envNameGitObjectDirectory defines the environment variable name git uses to provide the object directory.
+	envNameGitObjectDirectory = "GIT_OBJECT_DIRECTORY"
+
+	// envNameGitAlternateObjectDirectories defines the environment variable name git uses to provide
+	// the alternate object directories.
+	envNameGitAlternateObjectDirectories = "GIT_ALTERNATE_OBJECT_DIRECTORIES"
 )
 
 var (
@@ -39,10 +39,20 @@ type PostReceiveInput struct {
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
 }
 
+// Environment contains information about the git environment the hook is executed in.
+type Environment struct {
+	// AlternateObjectDirs contains the object directories (e.g. the quarantine directory of a push)
+	// that have to be added as alternates to be able to read objects that haven't been migrated yet.
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
+}
+
 // PreReceiveInput represents the input of the pre-receive git hook.
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// Environment contains the git environment of the hook execution.
+	Environment Environment `json:"environment"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -57,6 +57,9 @@ type ServicePackParams struct {
 	GitProtocol string
 	Data        io.Reader
 	Options     []string // (key, value) pair
+	// StatelessRPC has to be set for git's smart http protocol,
+	// but not for long-lived connections like ssh.
+	StatelessRPC bool
 }
 
 func (p *ServicePackParams) Validate() error {
@@ -96,7 +99,7 @@ func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServiceP
 		env = append(env, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
-	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.Data, w, env...)
+	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.StatelessRPC, params.Data, w, env...)
 	if err != nil {
 		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
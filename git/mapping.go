@@ -64,9 +64,21 @@ func mapCommit(c *types.Commit) (*Commit, error) {
 		Author:     *author,
 		Committer:  *comitter,
 		FileStats:  mapFileStats(c.FileStats),
+		SignedData: mapSignedData(c.SignedData),
 	}, nil
 }
 
+func mapSignedData(d *types.SignedData) *SignedData {
+	if d == nil {
+		return nil
+	}
+
+	return &SignedData{
+		Signature:     d.Signature,
+		SignedContent: d.SignedContent,
+	}
+}
+
 func mapFileStats(typeStats []types.CommitFileStats) []CommitFileStats {
 	var stats = make([]CommitFileStats, len(typeStats))
 
@@ -134,6 +146,7 @@ func mapAnnotatedTag(tag *types.Tag) *CommitTag {
 		Tagger:      tagger,
 		IsAnnotated: true,
 		Commit:      nil,
+		SignedData:  mapSignedData(tag.SignedData),
 	}
 }
 
@@ -33,7 +33,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If empty, the head branch is expected to be in the repository specified by RepoUID.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -59,6 +59,11 @@ type MergeParams struct {
 	// than the HeadBranch latest sha then merge will fail.
 	HeadExpectedSHA string
 
+	// MergeBaseSHA overrides the merge base of the base and the head branch (optional).
+	// It must be an ancestor of the head commit. It allows, for example,
+	// to rebase only the head commits after the provided commit (like "git rebase --onto").
+	MergeBaseSHA string
+
 	Force            bool
 	DeleteHeadBranch bool
 
@@ -99,6 +104,10 @@ type MergeOutput struct {
 	CommitCount      int
 	ChangedFileCount int
 	ConflictFiles    []string
+
+	// FastForwardable is true if the base branch can be fast-forwarded to the head commit,
+	// i.e. if BaseSHA is an ancestor of HeadSHA. Only such merges can use the fast-forward merge method.
+	FastForwardable bool
 }
 
 // Merge method executes git merge operation. Refs can be sha, branch or tag.
@@ -140,6 +149,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		mergeFunc = merge.Squash
 	case enum.MergeMethodRebase:
 		mergeFunc = merge.Rebase
+	case enum.MergeMethodFastForward:
+		mergeFunc = merge.FastForward
 	default:
 		// should not happen, the call to Sanitize above should handle this case.
 		panic("unsupported merge method")
@@ -183,11 +194,24 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
+	// commits of the head branch in another repository (a fork) have to be available in the base repository.
+	if headRepoPath != repoPath {
+		err = s.adapter.FetchObjects(ctx, repoPath, headRepoPath, []string{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head branch commits from head repository: %w", err)
+		}
+	}
+
 	if params.HeadExpectedSHA != "" && params.HeadExpectedSHA != headCommitSHA {
 		return MergeOutput{}, errors.PreconditionFailed(
 			"head branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
@@ -201,10 +225,30 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base: %w", err)
 	}
 
+	if params.MergeBaseSHA != "" {
+		isAncestor, err := s.adapter.IsAncestor(ctx, repoPath, params.MergeBaseSHA, headCommitSHA)
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to check if the provided merge base is an ancestor: %w", err)
+		}
+		if !isAncestor {
+			return MergeOutput{}, errors.InvalidArgument(
+				"provided merge base '%s' is not an ancestor of the head commit.", params.MergeBaseSHA)
+		}
+
+		mergeBaseCommitSHA = params.MergeBaseSHA
+	}
+
 	if headCommitSHA == mergeBaseCommitSHA {
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	// check if the base branch is an ancestor of the head commit
+
+	fastForwardable, err := s.adapter.IsAncestor(ctx, repoPath, baseCommitSHA, headCommitSHA)
+	if err != nil {
+		return MergeOutput{}, fmt.Errorf("failed to check if head commit is fast-forwardable: %w", err)
+	}
+
 	// find short stat and number of commits
 
 	shortStat, err := s.adapter.DiffShortStat(ctx, repoPath, baseCommitSHA, headCommitSHA, true)
@@ -239,6 +283,21 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			CommitCount:      commitCount,
 			ChangedFileCount: changedFileCount,
 			ConflictFiles:    conflicts,
+			FastForwardable:  fastForwardable,
+		}, nil
+	}
+
+	if mergeMethod == enum.MergeMethodFastForward && !fastForwardable {
+		log.Debug().Msg("merge not possible - head commit is not fast-forwardable")
+
+		return MergeOutput{
+			BaseSHA:          baseCommitSHA,
+			HeadSHA:          headCommitSHA,
+			MergeBaseSHA:     mergeBaseCommitSHA,
+			MergeSHA:         "",
+			CommitCount:      commitCount,
+			ChangedFileCount: changedFileCount,
+			FastForwardable:  false,
 		}, nil
 	}
 
@@ -276,6 +335,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	mergeCommitSHA, conflicts, err := mergeFunc(
 		ctx,
 		repoPath, s.tmpDir,
+		s.signer,
 		&author, &committer,
 		mergeMsg,
 		mergeBaseCommitSHA, baseCommitSHA, headCommitSHA)
@@ -292,6 +352,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			CommitCount:      commitCount,
 			ChangedFileCount: changedFileCount,
 			ConflictFiles:    conflicts,
+			FastForwardable:  fastForwardable,
 		}, nil
 	}
 
@@ -322,6 +383,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		CommitCount:      commitCount,
 		ChangedFileCount: changedFileCount,
 		ConflictFiles:    nil,
+		FastForwardable:  fastForwardable,
 	}, nil
 }
 
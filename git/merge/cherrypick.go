@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/sharedrepo"
+	"github.com/harness/gitness/git/signing"
+	"github.com/harness/gitness/git/types"
+)
+
+// CherryPick applies the changes introduced by the commits on top of the targetSHA.
+// If fromSHA is provided, all non-merge commits in the range fromSHA..commitSHA are applied,
+// otherwise only the commit commitSHA is applied.
+func CherryPick(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	signer signing.Signer,
+	committer *types.Signature,
+	targetSHA, fromSHA, commitSHA string,
+) (newSHA string, conflicts []string, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
+		var err error
+
+		commitSHAs := []string{commitSHA}
+
+		if fromSHA != "" {
+			commitSHAs, err = s.CommitSHAsInRange(ctx, fromSHA, commitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to find commit list: %w", err)
+			}
+		}
+
+		newSHA, conflicts, err = applyCommits(ctx, s, signer, committer, targetSHA, commitSHAs)
+		if err != nil {
+			return fmt.Errorf("failed to apply commits: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("cherry-pick: %w", err)
+	}
+
+	return newSHA, conflicts, nil
+}
+
+// Revert creates a commit on top of the targetSHA that reverses the changes introduced by the commit commitSHA.
+// The changes are reverted relative to the parentSHA, which allows reverting a whole range of commits.
+// If parentSHA is empty the first parent of the commit is used (which is the mainline for merge commits).
+func Revert(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	signer signing.Signer,
+	author, committer *types.Signature,
+	message string,
+	targetSHA, commitSHA, parentSHA string,
+) (revertSHA string, conflicts []string, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
+		if parentSHA == "" {
+			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+			if err != nil {
+				return fmt.Errorf("failed to get commit data: %w", err)
+			}
+
+			if len(commitInfo.ParentSHAs) == 0 {
+				return fmt.Errorf("commit %s has no parents", commitSHA)
+			}
+
+			parentSHA = commitInfo.ParentSHAs[0]
+		}
+
+		targetTreeSHA, err := s.GetTreeSHA(ctx, targetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for target: %w", err)
+		}
+
+		// merging the parent using the reverted commit as merge base applies the inverse of its changes.
+		var treeSHA string
+
+		treeSHA, conflicts, err = s.MergeTree(ctx, commitSHA, targetSHA, parentSHA)
+		if err != nil {
+			return fmt.Errorf("failed to merge tree: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return nil
+		}
+
+		// the changes are already reverted on the target.
+		if treeSHA == targetTreeSHA {
+			revertSHA = targetSHA
+			return nil
+		}
+
+		revertSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, targetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to commit tree: %w", err)
+		}
+
+		revertSHA, err = signing.SignCommit(ctx, s.Directory(), revertSHA, signer)
+		if err != nil {
+			return fmt.Errorf("failed to sign revert commit: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("revert: %w", err)
+	}
+
+	return revertSHA, conflicts, nil
+}
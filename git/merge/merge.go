@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/sharedrepo"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/types"
 
 	"github.com/rs/zerolog/log"
@@ -29,6 +30,7 @@ import (
 type Func func(
 	ctx context.Context,
 	repoPath, tmpDir string,
+	signer signing.Signer,
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
@@ -38,12 +40,14 @@ type Func func(
 func Merge(
 	ctx context.Context,
 	repoPath, tmpDir string,
+	signer signing.Signer,
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
 ) (mergeSHA string, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		repoPath, tmpDir,
+		signer,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
@@ -54,12 +58,14 @@ func Merge(
 func Squash(
 	ctx context.Context,
 	repoPath, tmpDir string,
+	signer signing.Signer,
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
 ) (mergeSHA string, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		repoPath, tmpDir,
+		signer,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
@@ -70,6 +76,7 @@ func Squash(
 func mergeInternal(
 	ctx context.Context,
 	repoPath, tmpDir string,
+	signer signing.Signer,
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
@@ -100,6 +107,11 @@ func mergeInternal(
 			return fmt.Errorf("commit tree failed: %w", err)
 		}
 
+		mergeSHA, err = signing.SignCommit(ctx, s.Directory(), mergeSHA, signer)
+		if err != nil {
+			return fmt.Errorf("failed to sign merge commit: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -110,11 +122,10 @@ func mergeInternal(
 }
 
 // Rebase merges two the commits (targetSHA and sourceSHA) using the Rebase method.
-//
-//nolint:gocognit // refactor if needed.
 func Rebase(
 	ctx context.Context,
 	repoPath, tmpDir string,
+	signer signing.Signer,
 	_, committer *types.Signature, // commit author isn't used here - it's copied from every commit
 	_ string, // commit message isn't used here
 	mergeBaseSHA, targetSHA, sourceSHA string,
@@ -125,66 +136,11 @@ func Rebase(
 			return fmt.Errorf("failed to find commit list in rebase merge: %w", err)
 		}
 
-		lastCommitSHA := targetSHA
-		lastTreeSHA, err := s.GetTreeSHA(ctx, targetSHA)
+		mergeSHA, conflicts, err = applyCommits(ctx, s, signer, committer, targetSHA, sourceSHAs)
 		if err != nil {
-			return fmt.Errorf("failed to get tree sha for target: %w", err)
-		}
-
-		for _, commitSHA := range sourceSHAs {
-			var treeSHA string
-
-			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
-			if err != nil {
-				return fmt.Errorf("failed to get commit data in rebase merge: %w", err)
-			}
-
-			// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
-			author := &commitInfo.Author
-			message := commitInfo.Title
-			if commitInfo.Message != "" {
-				message += "\n\n" + commitInfo.Message
-			}
-
-			mergeTreeMergeBaseSHA := ""
-			if len(commitInfo.ParentSHAs) > 0 {
-				// use parent of commit as merge base to only apply changes introduced by commit.
-				// See example usage of when --merge-base was introduced:
-				// https://github.com/git/git/commit/66265a693e8deb3ab86577eb7f69940410044081
-				//
-				// NOTE: CommitSHAsForRebase only returns non-merge commits.
-				mergeTreeMergeBaseSHA = commitInfo.ParentSHAs[0]
-			}
-
-			treeSHA, conflicts, err = s.MergeTree(ctx, mergeTreeMergeBaseSHA, lastCommitSHA, commitSHA)
-			if err != nil {
-				return fmt.Errorf("failed to merge tree in rebase merge: %w", err)
-			}
-			if len(conflicts) > 0 {
-				return nil
-			}
-
-			// Drop any commit which after being rebased would be empty.
-			// There's two cases in which that can happen:
-			// 1. Empty commit.
-			//    Github is dropping empty commits, so we'll do the same.
-			// 2. The changes of the commit already exist on the target branch.
-			//    Git's `git rebase` is dropping such commits on default (and so does Github)
-			//    https://git-scm.com/docs/git-rebase#Documentation/git-rebase.txt---emptydropkeepask
-			if treeSHA == lastTreeSHA {
-				log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after rebase", commitSHA)
-				continue
-			}
-
-			lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
-			if err != nil {
-				return fmt.Errorf("failed to commit tree in rebase merge: %w", err)
-			}
-			lastTreeSHA = treeSHA
+			return fmt.Errorf("failed to apply commits in rebase merge: %w", err)
 		}
 
-		mergeSHA = lastCommitSHA
-
 		return nil
 	})
 	if err != nil {
@@ -194,6 +150,102 @@ func Rebase(
 	return mergeSHA, conflicts, nil
 }
 
+// FastForward merges two the commits (targetSHA and sourceSHA) using the FastForward method.
+// No new commit is created, the source commit is the result of the merge.
+// It's only possible if the target commit is an ancestor of the source commit.
+func FastForward(
+	_ context.Context,
+	_, _ string,
+	_ signing.Signer,
+	_, _ *types.Signature,
+	_ string,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+) (mergeSHA string, conflicts []string, err error) {
+	if mergeBaseSHA != targetSHA {
+		return "", nil, fmt.Errorf("merge method=fast-forward: target %s is not an ancestor of source %s",
+			targetSHA, sourceSHA)
+	}
+
+	return sourceSHA, nil, nil
+}
+
+// applyCommits applies the changes introduced by each of the commits on top of the targetSHA, one by one.
+// It preserves the commit author (and date) and the commit message, but changes the committer.
+// Commits that would be empty after being applied are dropped.
+// If applying any of the commits results in conflicts, the conflicting files are returned.
+func applyCommits(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	signer signing.Signer,
+	committer *types.Signature,
+	targetSHA string,
+	commitSHAs []string,
+) (lastCommitSHA string, conflicts []string, err error) {
+	lastCommitSHA = targetSHA
+	lastTreeSHA, err := s.GetTreeSHA(ctx, targetSHA)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tree sha for target: %w", err)
+	}
+
+	for _, commitSHA := range commitSHAs {
+		var treeSHA string
+
+		commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get commit data: %w", err)
+		}
+
+		author := &commitInfo.Author
+		message := commitInfo.Title
+		if commitInfo.Message != "" {
+			message += "\n\n" + commitInfo.Message
+		}
+
+		mergeTreeMergeBaseSHA := ""
+		if len(commitInfo.ParentSHAs) > 0 {
+			// use parent of commit as merge base to only apply changes introduced by commit.
+			// See example usage of when --merge-base was introduced:
+			// https://github.com/git/git/commit/66265a693e8deb3ab86577eb7f69940410044081
+			//
+			// NOTE: Callers only provide non-merge commits.
+			mergeTreeMergeBaseSHA = commitInfo.ParentSHAs[0]
+		}
+
+		treeSHA, conflicts, err = s.MergeTree(ctx, mergeTreeMergeBaseSHA, lastCommitSHA, commitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to merge tree: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return "", conflicts, nil
+		}
+
+		// Drop any commit which after being applied would be empty.
+		// There's two cases in which that can happen:
+		// 1. Empty commit.
+		//    Github is dropping empty commits, so we'll do the same.
+		// 2. The changes of the commit already exist on the target branch.
+		//    Git's `git rebase` is dropping such commits on default (and so does Github)
+		//    https://git-scm.com/docs/git-rebase#Documentation/git-rebase.txt---emptydropkeepask
+		if treeSHA == lastTreeSHA {
+			log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after being applied", commitSHA)
+			continue
+		}
+
+		lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to commit tree: %w", err)
+		}
+
+		lastCommitSHA, err = signing.SignCommit(ctx, s.Directory(), lastCommitSHA, signer)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to sign commit: %w", err)
+		}
+		lastTreeSHA = treeSHA
+	}
+
+	return lastCommitSHA, nil, nil
+}
+
 // runInSharedRepo is helper function used to run the provided function inside a shared repository.
 func runInSharedRepo(
 	ctx context.Context,
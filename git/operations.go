@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/types"
 
 	"code.gitea.io/gitea/modules/git"
@@ -219,6 +220,11 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 			return "", fmt.Errorf("failed to commit the tree: %w", err)
 		}
 
+		commitSHA, err = signing.SignCommit(ctx, shared.Path(), commitSHA, s.signer)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign the commit: %w", err)
+		}
+
 		err = shared.MoveObjects(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to move git objects: %w", err)
@@ -25,6 +25,11 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs [OPTIONAL] allows to restrict the references that are pushed to the remote repository.
+	// The matching references that don't exist locally are deleted from the remote repository.
+	// By default all references are mirrored to the remote repository.
+	RefSpecs []string
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -60,10 +65,12 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 
 	err = s.adapter.Push(ctx, repoPath, types.PushOptions{
-		Remote: params.RemoteURL,
-		Force:  false,
-		Env:    nil,
-		Mirror: true,
+		Remote:   params.RemoteURL,
+		Force:    false,
+		Env:      nil,
+		Mirror:   len(params.RefSpecs) == 0,
+		Prune:    len(params.RefSpecs) > 0,
+		RefSpecs: params.RefSpecs,
 	})
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
@@ -22,11 +22,13 @@ import (
 	"path"
 	"regexp"
 	"runtime/debug"
+	"sort"
 	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
@@ -118,6 +120,9 @@ type SyncRepositoryParams struct {
 
 type SyncRepositoryOutput struct {
 	DefaultBranch string
+
+	// RefUpdates contains all references that got created, updated or deleted by the sync.
+	RefUpdates []hook.ReferenceUpdate
 }
 
 type HashRepositoryParams struct {
@@ -280,17 +285,30 @@ func (s *Service) SyncRepository(
 		}
 	}
 
+	refsBefore, err := s.listReferences(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("SyncRepository: failed to list references before sync: %w", err)
+	}
+
 	// sync repo content
 	err = s.adapter.Sync(ctx, repoPath, params.Source, params.RefSpecs)
 	if err != nil {
 		return nil, fmt.Errorf("SyncRepository: failed to sync git repo: %w", err)
 	}
 
+	refsAfter, err := s.listReferences(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("SyncRepository: failed to list references after sync: %w", err)
+	}
+
+	refUpdates := diffReferences(refsBefore, refsAfter)
+
 	// get remote default branch
 	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, params.Source)
 	if errors.Is(err, types.ErrNoDefaultBranch) {
 		return &SyncRepositoryOutput{
 			DefaultBranch: "",
+			RefUpdates:    refUpdates,
 		}, nil
 	}
 	if err != nil {
@@ -305,9 +323,53 @@ func (s *Service) SyncRepository(
 
 	return &SyncRepositoryOutput{
 		DefaultBranch: defaultBranch,
+		RefUpdates:    refUpdates,
 	}, nil
 }
 
+// listReferences returns the object SHAs of all references of the repository, keyed by the full reference name.
+func (s *Service) listReferences(ctx context.Context, repoPath string) (map[string]string, error) {
+	refs := map[string]string{}
+	handler := func(e types.WalkReferencesEntry) error {
+		refs[e[types.GitReferenceFieldRefName]] = e[types.GitReferenceFieldObjectName]
+		return nil
+	}
+
+	if err := s.adapter.WalkReferences(ctx, repoPath, handler, &types.WalkReferencesOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to walk references: %w", err)
+	}
+
+	return refs, nil
+}
+
+// diffReferences returns the reference updates required to get from the "before" to the "after" references.
+func diffReferences(before, after map[string]string) []hook.ReferenceUpdate {
+	var refUpdates []hook.ReferenceUpdate
+
+	for ref, newSHA := range after {
+		oldSHA, ok := before[ref]
+		if !ok {
+			oldSHA = types.NilSHA
+		}
+		if oldSHA == newSHA {
+			continue
+		}
+		refUpdates = append(refUpdates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: newSHA})
+	}
+
+	for ref, oldSHA := range before {
+		if _, ok := after[ref]; !ok {
+			refUpdates = append(refUpdates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: types.NilSHA})
+		}
+	}
+
+	sort.Slice(refUpdates, func(i, j int) bool {
+		return refUpdates[i].Ref < refUpdates[j].Ref
+	})
+
+	return refUpdates
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
@@ -491,16 +553,25 @@ func (s *Service) createRepositoryInternal(
 
 	// setup server hook symlinks pointing to configured server hook binary
 	// IMPORTANT: Setup hooks after repo creation to avoid issues with externally dependent services.
+	if err = s.setupServerHooks(repoPath); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("repository created. Path: %s", repoPath)
+	return nil
+}
+
+// setupServerHooks creates the server hook symlinks pointing to the configured server hook binary.
+func (s *Service) setupServerHooks(repoPath string) error {
 	for _, hook := range gitServerHookNames {
 		hookPath := path.Join(repoPath, gitHooksDir, hook)
-		err = os.Symlink(s.gitHookPath, hookPath)
+		err := os.Symlink(s.gitHookPath, hookPath)
 		if err != nil {
 			return errors.Internal(err, "failed to setup symlink for hook '%s' ('%s' -> '%s')",
 				hook, hookPath, s.gitHookPath)
 		}
 	}
 
-	log.Info().Msgf("repository created. Path: %s", repoPath)
 	return nil
 }
 
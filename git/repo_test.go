@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestDiffReferences(t *testing.T) {
+	const (
+		sha1 = "1111111111111111111111111111111111111111"
+		sha2 = "2222222222222222222222222222222222222222"
+	)
+
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		want   []hook.ReferenceUpdate
+	}{
+		{
+			name:   "no changes",
+			before: map[string]string{"refs/heads/main": sha1},
+			after:  map[string]string{"refs/heads/main": sha1},
+			want:   nil,
+		},
+		{
+			name:   "created",
+			before: map[string]string{},
+			after:  map[string]string{"refs/heads/main": sha1},
+			want: []hook.ReferenceUpdate{
+				{Ref: "refs/heads/main", Old: types.NilSHA, New: sha1},
+			},
+		},
+		{
+			name:   "updated",
+			before: map[string]string{"refs/heads/main": sha1},
+			after:  map[string]string{"refs/heads/main": sha2},
+			want: []hook.ReferenceUpdate{
+				{Ref: "refs/heads/main", Old: sha1, New: sha2},
+			},
+		},
+		{
+			name:   "deleted",
+			before: map[string]string{"refs/tags/v1": sha1},
+			after:  map[string]string{},
+			want: []hook.ReferenceUpdate{
+				{Ref: "refs/tags/v1", Old: sha1, New: types.NilSHA},
+			},
+		},
+		{
+			name: "mixed changes are sorted by ref",
+			before: map[string]string{
+				"refs/heads/main":    sha1,
+				"refs/heads/feature": sha1,
+				"refs/tags/v1":       sha1,
+			},
+			after: map[string]string{
+				"refs/heads/main": sha2,
+				"refs/heads/dev":  sha2,
+				"refs/tags/v1":    sha1,
+			},
+			want: []hook.ReferenceUpdate{
+				{Ref: "refs/heads/dev", Old: types.NilSHA, New: sha2},
+				{Ref: "refs/heads/feature", Old: sha1, New: types.NilSHA},
+				{Ref: "refs/heads/main", Old: sha1, New: sha2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffReferences(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
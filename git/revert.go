@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/types"
+)
+
+// RevertParams is input structure object for the revert operation.
+type RevertParams struct {
+	WriteParams
+
+	// CommitSHA is the commit whose changes are reverted.
+	CommitSHA string
+	// ParentSHA optionally specifies the commit relative to which the changes are reverted
+	// (default: first parent of CommitSHA). It allows reverting all changes of a range of commits at once.
+	ParentSHA string
+
+	// BranchName is the branch the revert commit is added to.
+	BranchName string
+	// NewBranchName optionally specifies the name of a new branch that is created for the result.
+	// If provided, BranchName is left unchanged.
+	NewBranchName string
+
+	// Message is the commit message of the revert commit
+	// (optional, default: generated from the reverted commit)
+	Message string
+
+	// Committer overwrites the git committer used for the revert commit
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for the revert commit
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for the revert commit
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for the revert commit
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *RevertParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA == "" {
+		return errors.InvalidArgument("commit sha is mandatory")
+	}
+
+	if p.BranchName == "" {
+		return errors.InvalidArgument("branch name is mandatory")
+	}
+
+	return nil
+}
+
+// RevertOutput is result object of the revert operation.
+type RevertOutput struct {
+	// BaseSHA is the sha of the latest commit on the branch the revert commit was added to.
+	BaseSHA string
+	// CommitSHA is the sha of the revert commit.
+	// It's empty if the changes couldn't be reverted because of conflicts.
+	CommitSHA string
+
+	ConflictFiles []string
+}
+
+// Revert adds a commit to a branch that reverses the changes introduced by a commit.
+// Merge commits are reverted relative to their first parent.
+// If the changes can't be reverted because of conflicts, the conflicting files are returned and no branch is updated.
+func (s *Service) Revert(ctx context.Context, params *RevertParams) (RevertOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commit, err := s.adapter.GetCommit(ctx, repoPath, params.CommitSHA)
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to get commit %q: %w", params.CommitSHA, err)
+	}
+
+	parentSHA := ""
+	if params.ParentSHA != "" {
+		parentSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, params.ParentSHA)
+		if err != nil {
+			return RevertOutput{}, fmt.Errorf("failed to resolve commit %q: %w", params.ParentSHA, err)
+		}
+	} else if len(commit.ParentSHAs) == 0 {
+		return RevertOutput{}, errors.InvalidArgument("the initial commit of a repository can't be reverted")
+	}
+
+	baseSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.BranchName)
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to resolve branch %q: %w", params.BranchName, err)
+	}
+
+	now := time.Now().UTC()
+
+	committer := types.Signature{Identity: types.Identity(params.Actor), When: now}
+	if params.Committer != nil {
+		committer.Identity = types.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+	if params.Author != nil {
+		author.Identity = types.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	message := params.Message
+	if message == "" {
+		message = fmt.Sprintf("Revert %q\n\nThis reverts commit %s.", commit.Title, commit.SHA)
+	}
+
+	revertSHA, conflicts, err := merge.Revert(ctx, repoPath, s.tmpDir, s.signer, &author, &committer,
+		message, baseSHA, commit.SHA, parentSHA)
+	if err != nil {
+		return RevertOutput{}, errors.Internal(err, "failed to revert %q on %q", commit.SHA, params.BranchName)
+	}
+	if len(conflicts) > 0 {
+		return RevertOutput{
+			BaseSHA:       baseSHA,
+			ConflictFiles: conflicts,
+		}, nil
+	}
+
+	if revertSHA == baseSHA {
+		return RevertOutput{}, errors.InvalidArgument(
+			"the changes of commit %q are already reverted on the branch %q", commit.SHA, params.BranchName)
+	}
+
+	err = s.updateBranchWithCommit(ctx, params.EnvVars, repoPath,
+		params.BranchName, params.NewBranchName, baseSHA, revertSHA)
+	if err != nil {
+		return RevertOutput{}, err
+	}
+
+	return RevertOutput{
+		BaseSHA:   baseSHA,
+		CommitSHA: revertSHA,
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/storage"
 	"github.com/harness/gitness/git/types"
 )
@@ -35,12 +36,14 @@ type Service struct {
 	store          storage.Store
 	gitHookPath    string
 	reposGraveyard string
+	signer         signing.Signer
 }
 
 func New(
 	config types.Config,
 	adapter Adapter,
 	storage storage.Store,
+	signer signing.Signer,
 ) (*Service, error) {
 	// Create repos folder
 	reposRoot := filepath.Join(config.Root, repoSubdirName)
@@ -65,5 +68,6 @@ func New(
 		adapter:        adapter,
 		store:          storage,
 		gitHookPath:    config.HookPath,
+		signer:         signer,
 	}, nil
 }
@@ -354,7 +354,7 @@ func (r *SharedRepo) CommitTree(
 		cmd.Add(command.WithFlag("-p", parentCommit))
 	}
 
-	// temporary no signing
+	// never sign using the git configuration - signing is done afterwards, see signing.SignCommit.
 	cmd.Add(command.WithFlag("--no-gpg-sign"))
 
 	messageBytes := new(bytes.Buffer)
@@ -418,6 +418,38 @@ func (r *SharedRepo) CommitSHAsForRebase(
 	return commitSHAs, nil
 }
 
+// CommitSHAsInRange returns list of SHAs of the non-merge commits reachable from the revision "to",
+// but not from the revision "from" - in the order they should be applied in.
+func (r *SharedRepo) CommitSHAsInRange(
+	ctx context.Context,
+	from, to string,
+) ([]string, error) {
+	cmd := command.New("rev-list",
+		command.WithFlag("--max-parents=1"), // exclude merge commits
+		command.WithFlag("--reverse"),
+		command.WithFlag("--topo-order"),
+		command.WithArg(from+".."+to))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(r.temporaryPath), command.WithStdout(stdout)); err != nil {
+		return nil, fmt.Errorf("failed to rev-list in shared repo: %w", err)
+	}
+
+	var commitSHAs []string
+
+	scan := bufio.NewScanner(stdout)
+	for scan.Scan() {
+		commitSHA := scan.Text()
+		commitSHAs = append(commitSHAs, commitSHA)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan rev-list output in shared repo: %w", err)
+	}
+
+	return commitSHAs, nil
+}
+
 // MergeBase returns number of commits between the two git revisions.
 func (r *SharedRepo) MergeBase(
 	ctx context.Context,
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// commitSignatureHeader is the name of the commit header containing the signature.
+const commitSignatureHeader = "gpgsig"
+
+// Signer signs the content of git objects (commits and annotated tags) created by the server.
+type Signer interface {
+	// Sign returns the armored signature (GPG or SSH) of the provided content.
+	Sign(ctx context.Context, content []byte) ([]byte, error)
+}
+
+// SignCommit signs the commit with the provided SHA and writes the signed commit into the repository.
+// It returns the SHA of the signed commit which replaces the original one.
+// If no signer is provided, the commit is left untouched.
+func SignCommit(ctx context.Context, repoPath string, sha string, signer Signer) (string, error) {
+	if signer == nil {
+		return sha, nil
+	}
+
+	content, err := readObject(ctx, repoPath, "commit", sha)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.Sign(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign commit %s: %w", sha, err)
+	}
+
+	return writeObject(ctx, repoPath, "commit", AddCommitSignature(content, signature))
+}
+
+// SignTag signs the annotated tag object with the provided SHA and writes the signed tag into the repository.
+// It returns the SHA of the signed tag object which replaces the original one.
+// If no signer is provided, the tag is left untouched.
+func SignTag(ctx context.Context, repoPath string, sha string, signer Signer) (string, error) {
+	if signer == nil {
+		return sha, nil
+	}
+
+	content, err := readObject(ctx, repoPath, "tag", sha)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.Sign(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign tag %s: %w", sha, err)
+	}
+
+	// the signature of a tag is appended to the tag message.
+	signed := make([]byte, 0, len(content)+len(signature))
+	signed = append(signed, content...)
+	signed = append(signed, signature...)
+
+	return writeObject(ctx, repoPath, "tag", signed)
+}
+
+// AddCommitSignature adds the signature as the last header of the raw commit object.
+// Continuation lines of the multi-line header are indented by a single space.
+func AddCommitSignature(commit []byte, signature []byte) []byte {
+	headersEnd := bytes.Index(commit, []byte("\n\n"))
+	if headersEnd < 0 {
+		headersEnd = len(commit)
+	}
+
+	header := commitSignatureHeader + " " +
+		strings.ReplaceAll(strings.TrimSuffix(string(signature), "\n"), "\n", "\n ")
+
+	signed := make([]byte, 0, len(commit)+len(header)+1)
+	signed = append(signed, commit[:headersEnd]...)
+	signed = append(signed, '\n')
+	signed = append(signed, header...)
+	signed = append(signed, commit[headersEnd:]...)
+
+	return signed
+}
+
+func readObject(ctx context.Context, repoPath string, objectType string, sha string) ([]byte, error) {
+	cmd := command.New("cat-file",
+		command.WithArg(objectType, sha),
+	)
+
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, fmt.Errorf("failed to read %s object %s: %w", objectType, sha, err)
+	}
+
+	return output.Bytes(), nil
+}
+
+func writeObject(ctx context.Context, repoPath string, objectType string, content []byte) (string, error) {
+	cmd := command.New("hash-object",
+		command.WithFlag("-t", objectType),
+		command.WithFlag("-w"),
+		command.WithFlag("--stdin"),
+	)
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(bytes.NewReader(content)),
+		command.WithStdout(output),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to write signed %s object: %w", objectType, err)
+	}
+
+	return strings.TrimSpace(output.String()), nil
+}
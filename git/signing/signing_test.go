@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"testing"
+)
+
+func TestAddCommitSignature(t *testing.T) {
+	commit := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author max <max@mail.com> 1666401234 -0700\n" +
+		"committer max <max@mail.com> 1666401234 -0700\n" +
+		"\n" +
+		"some title\n"
+
+	signature := "-----BEGIN PGP SIGNATURE-----\n" +
+		"\n" +
+		"iHUEABYKAB0WIQ\n" +
+		"-----END PGP SIGNATURE-----\n"
+
+	want := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author max <max@mail.com> 1666401234 -0700\n" +
+		"committer max <max@mail.com> 1666401234 -0700\n" +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n" +
+		" \n" +
+		" iHUEABYKAB0WIQ\n" +
+		" -----END PGP SIGNATURE-----\n" +
+		"\n" +
+		"some title\n"
+
+	if got := string(AddCommitSignature([]byte(commit), []byte(signature))); got != want {
+		t.Errorf("signed commit mismatch:\nwant=%q\n got=%q", want, got)
+	}
+}
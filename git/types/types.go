@@ -158,6 +158,20 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	SignedData *SignedData       `json:"-"`
+}
+
+// SignedData contains the raw signature of a signed git object (commit or annotated tag)
+// together with the content the signature was created for.
+type SignedData struct {
+	Signature     []byte
+	SignedContent []byte
+}
+
+// CommitSignedData contains the signature of a commit, SignedData is nil if the commit isn't signed.
+type CommitSignedData struct {
+	SHA        string
+	SignedData *SignedData
 }
 
 type Branch struct {
@@ -183,6 +197,7 @@ type Tag struct {
 	Title      string
 	Message    string
 	Tagger     Signature
+	SignedData *SignedData
 }
 
 type CreateTagOptions struct {
@@ -236,6 +251,8 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	RefSpecs       []string
 }
 
 type TreeNodeWithCommit struct {
@@ -403,3 +420,13 @@ type FileDiffRequest struct {
 }
 
 type FileDiffRequests []FileDiffRequest
+
+type ArchiveParams struct {
+	Format enum.ArchiveFormat
+	// Ref is the git reference (branch / tag / commit SHA) to archive.
+	Ref string
+	// Prefix is prepended to every path in the archive (optional), e.g. "repo-main/".
+	Prefix string
+	// Paths restricts the archive to the provided paths (optional).
+	Paths []string
+}
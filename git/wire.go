@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/signing"
 	"github.com/harness/gitness/git/storage"
 	"github.com/harness/gitness/git/types"
 
@@ -42,10 +43,12 @@ func ProvideService(
 	config types.Config,
 	adapter Adapter,
 	storage storage.Store,
+	signer signing.Signer,
 ) (Interface, error) {
 	return New(
 		config,
 		adapter,
 		storage,
+		signer,
 	)
 }
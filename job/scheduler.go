@@ -682,6 +682,19 @@ func (s *Scheduler) AddRecurring(
 	jobType,
 	cronDef string,
 	maxDur time.Duration,
+) error {
+	return s.AddRecurringWithData(ctx, jobUID, jobType, cronDef, "", maxDur)
+}
+
+// AddRecurringWithData adds a recurring job, or updates its definition if it already exists.
+// The provided data is passed to the job handler on every execution.
+func (s *Scheduler) AddRecurringWithData(
+	ctx context.Context,
+	jobUID,
+	jobType,
+	cronDef string,
+	data string,
+	maxDur time.Duration,
 ) error {
 	cronExp, err := cronexpr.Parse(cronDef)
 	if err != nil {
@@ -699,7 +712,7 @@ func (s *Scheduler) AddRecurring(
 		Updated:             nowMilli,
 		Type:                jobType,
 		Priority:            JobPriorityElevated,
-		Data:                "",
+		Data:                data,
 		Result:              "",
 		MaxDurationSeconds:  int(maxDur / time.Second),
 		MaxRetries:          0,
@@ -0,0 +1,330 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultServerVersion defines the default version string sent to clients.
+	DefaultServerVersion = "SSH-2.0-Gitness"
+
+	requestTypeEnv        = "env"
+	requestTypeExec       = "exec"
+	requestTypeShell      = "shell"
+	requestTypeExitStatus = "exit-status"
+	requestTypeKeepAlive  = "keepalive@openssh.com"
+
+	channelTypeSession = "session"
+)
+
+// ErrServerClosed is returned by the server's error group after the server was shut down.
+var ErrServerClosed = errors.New("ssh: Server closed")
+
+// Config defines the config of an ssh server.
+type Config struct {
+	Host              string
+	Port              int
+	HostKeys          []gossh.Signer
+	KeepAliveInterval time.Duration
+	ServerVersion     string
+}
+
+// PublicKeyHandler authenticates a connection using the public key offered by the client.
+// The returned permissions are made available to the command handler via the session.
+type PublicKeyHandler func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error)
+
+// CommandHandler executes the command requested by the client.
+// An empty command indicates that the client requested an interactive shell.
+type CommandHandler func(ctx context.Context, session *Session) error
+
+// Session contains the information of a single command execution requested by a client.
+type Session struct {
+	Permissions *gossh.Permissions
+	User        string
+	RemoteAddr  net.Addr
+	Command     string
+	Env         map[string]string
+	Stdin       io.Reader
+	Stdout      io.Writer
+	Stderr      io.Writer
+}
+
+// Server is an ssh server that executes commands using the provided command handler.
+type Server struct {
+	config           Config
+	publicKeyHandler PublicKeyHandler
+	commandHandler   CommandHandler
+
+	mx    sync.Mutex
+	conns map[*gossh.ServerConn]struct{}
+	wg    sync.WaitGroup
+}
+
+// ShutdownFunction defines a function that is called to shutdown the server.
+type ShutdownFunction func(context.Context) error
+
+func NewServer(config Config, publicKeyHandler PublicKeyHandler, commandHandler CommandHandler) *Server {
+	if config.ServerVersion == "" {
+		config.ServerVersion = DefaultServerVersion
+	}
+
+	return &Server{
+		config:           config,
+		publicKeyHandler: publicKeyHandler,
+		commandHandler:   commandHandler,
+		conns:            map[*gossh.ServerConn]struct{}{},
+	}
+}
+
+// ListenAndServe initializes a server to respond to SSH network requests.
+// On shutdown the server stops accepting new connections and waits for open connections to complete.
+// Connections still open once the context of the shutdown is done are forcefully closed.
+func (s *Server) ListenAndServe() (*errgroup.Group, ShutdownFunction) {
+	var g errgroup.Group
+
+	serverConfig := &gossh.ServerConfig{
+		PublicKeyCallback: s.publicKeyHandler,
+		ServerVersion:     s.config.ServerVersion,
+	}
+	for _, hostKey := range s.config.HostKeys {
+		serverConfig.AddHostKey(hostKey)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.config.Host, fmt.Sprint(s.config.Port)))
+	if err != nil {
+		g.Go(func() error {
+			return fmt.Errorf("failed to listen for ssh connections: %w", err)
+		})
+		return &g, func(context.Context) error { return nil }
+	}
+
+	var closed bool
+	g.Go(func() error {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				s.mx.Lock()
+				isClosed := closed
+				s.mx.Unlock()
+				if isClosed {
+					return ErrServerClosed
+				}
+				return fmt.Errorf("failed to accept ssh connection: %w", err)
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.handleConn(conn, serverConfig)
+			}()
+		}
+	})
+
+	return &g, func(ctx context.Context) error {
+		s.mx.Lock()
+		closed = true
+		s.mx.Unlock()
+
+		err := listener.Close()
+
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return err
+		case <-ctx.Done():
+			s.mx.Lock()
+			for conn := range s.conns {
+				_ = conn.Close()
+			}
+			s.mx.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn, serverConfig *gossh.ServerConfig) {
+	sshConn, chans, reqs, err := gossh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		log.Debug().Err(err).Msgf("ssh handshake with %s failed", conn.RemoteAddr())
+		_ = conn.Close()
+		return
+	}
+
+	s.mx.Lock()
+	s.conns[sshConn] = struct{}{}
+	s.mx.Unlock()
+
+	// the context is canceled once the connection is closed, which stops any running command.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		_ = sshConn.Close()
+
+		s.mx.Lock()
+		delete(s.conns, sshConn)
+		s.mx.Unlock()
+	}()
+
+	go gossh.DiscardRequests(reqs)
+
+	if s.config.KeepAliveInterval > 0 {
+		go s.keepAlive(ctx, sshConn)
+	}
+
+	var wg sync.WaitGroup
+	for newChannel := range chans {
+		if newChannel.ChannelType() != channelTypeSession {
+			_ = newChannel.Reject(gossh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			log.Debug().Err(err).Msg("failed to accept ssh channel")
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleSession(ctx, sshConn, channel, channelRequests)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (s *Server) keepAlive(ctx context.Context, sshConn *gossh.ServerConn) {
+	ticker := time.NewTicker(s.config.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, _, err := sshConn.SendRequest(requestTypeKeepAlive, true, nil); err != nil {
+				_ = sshConn.Close()
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) handleSession(
+	ctx context.Context,
+	sshConn *gossh.ServerConn,
+	channel gossh.Channel,
+	requests <-chan *gossh.Request,
+) {
+	defer channel.Close()
+
+	env := map[string]string{}
+	started := false
+	done := make(chan struct{})
+
+	for req := range requests {
+		switch {
+		case req.Type == requestTypeEnv && !started:
+			var payload struct {
+				Name  string
+				Value string
+			}
+			if err := gossh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			env[payload.Name] = payload.Value
+			_ = req.Reply(true, nil)
+
+		case (req.Type == requestTypeExec || req.Type == requestTypeShell) && !started:
+			var payload struct {
+				Command string
+			}
+			if req.Type == requestTypeExec {
+				if err := gossh.Unmarshal(req.Payload, &payload); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+			}
+			_ = req.Reply(true, nil)
+
+			started = true
+			session := &Session{
+				Permissions: sshConn.Permissions,
+				User:        sshConn.User(),
+				RemoteAddr:  sshConn.RemoteAddr(),
+				Command:     payload.Command,
+				Env:         env,
+				Stdin:       channel,
+				Stdout:      channel,
+				Stderr:      channel.Stderr(),
+			}
+
+			// run the command asynchronously to keep processing (and discarding) channel requests.
+			go func() {
+				defer close(done)
+				s.runCommand(ctx, channel, session)
+			}()
+
+		default:
+			if req.WantReply {
+				_ = req.Reply(false, nil)
+			}
+		}
+	}
+
+	if started {
+		<-done
+	}
+}
+
+func (s *Server) runCommand(ctx context.Context, channel gossh.Channel, session *Session) {
+	var status uint32
+	if err := s.commandHandler(ctx, session); err != nil {
+		log.Debug().Err(err).Msgf("ssh command %q failed", session.Command)
+		status = 1
+	}
+
+	_ = channel.CloseWrite()
+
+	payload := struct {
+		Status uint32
+	}{
+		Status: status,
+	}
+	if _, err := channel.SendRequest(requestTypeExitStatus, false, gossh.Marshal(&payload)); err != nil {
+		log.Debug().Err(err).Msg("failed to send ssh exit status")
+	}
+
+	_ = channel.Close()
+}
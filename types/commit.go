@@ -20,3 +20,16 @@ type CommitFilesResponse struct {
 	CommitID       string           `json:"commit_id"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
+
+// CommitOperationResponse holds the result of an operation that adds commits to a branch (cherry-pick, revert).
+type CommitOperationResponse struct {
+	DryRunRules bool `json:"dry_run_rules,omitempty"`
+	// Branch is the branch the commits were added to.
+	Branch string `json:"branch,omitempty"`
+	// BaseSHA is the commit the new commits were added on top of.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// SHA is the latest commit of the branch after the operation.
+	SHA            string           `json:"sha,omitempty"`
+	PullReq        *PullReq         `json:"pull_req,omitempty"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+}
@@ -22,6 +22,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types/enum"
 )
 
 // Config stores the system configuration.
@@ -63,6 +64,12 @@ type Config struct {
 		// Value is derived from Base unless explicitly specified (e.g. http://localhost:3000/git).
 		Git string `envconfig:"GITNESS_URL_GIT"`
 
+		// GitSSH defines the external URL via which the GIT SSH server is reachable.
+		// Value is derived from Base and the SSH configuration unless explicitly specified
+		// (e.g. ssh://git@localhost:3022).
+		// NOTE: Value is only backfilled in case the SSH server is enabled.
+		GitSSH string `envconfig:"GITNESS_URL_GIT_SSH"`
+
 		// API defines the external URL via which the rest API is reachable.
 		// NOTE: for routing to work properly, the request path reaching gitness has to end with `/api`
 		// (this could be after proxy path rewrite).
@@ -107,6 +114,18 @@ type Config struct {
 			// Duration defines cache duration of last commit.
 			Duration time.Duration `envconfig:"GITNESS_GIT_LAST_COMMIT_CACHE_DURATION" default:"12h"`
 		}
+
+		// Signing defines the configuration of the instance key used to sign commits and tags created by the server.
+		Signing struct {
+			// Enabled specifies whether commits and tags created by the server are signed.
+			Enabled bool `envconfig:"GITNESS_GIT_SIGNING_ENABLED" default:"false"`
+			// Type is the type of the signing key. Valid values are "ssh" (default) or "gpg".
+			Type enum.GitSignatureType `envconfig:"GITNESS_GIT_SIGNING_TYPE" default:"ssh"`
+			// Key (optional) is the private key (OpenSSH format or armored GPG key) used for signing.
+			// NOTE: The key is imported on first start, otherwise a new key is generated.
+			// In both cases the key is stored encrypted in the database.
+			Key string `envconfig:"GITNESS_GIT_SIGNING_KEY"`
+		}
 	}
 
 	// Encrypter defines the parameters for the encrypter
@@ -132,6 +151,23 @@ type Config struct {
 		}
 	}
 
+	// SSH defines the configuration of the built-in ssh server used for git operations.
+	SSH struct {
+		Enable bool `envconfig:"GITNESS_SSH_ENABLE" default:"false"`
+		// Host is the address the ssh server listens on (all interfaces if empty).
+		Host string `envconfig:"GITNESS_SSH_HOST"`
+		Port int    `envconfig:"GITNESS_SSH_PORT" default:"3022"`
+		// DefaultUser is the user name used in ssh clone urls (e.g. git@localhost:space/repo.git).
+		// NOTE: The user name is ignored during authentication - the principal is identified via its public key.
+		DefaultUser string `envconfig:"GITNESS_SSH_DEFAULT_USER" default:"git"`
+		// ServerHostKeys are the paths to the private host keys of the server.
+		// Relative paths are resolved against the git root directory.
+		// NOTE: If none of the keys exist, an ed25519 key is generated at the first path.
+		ServerHostKeys []string `envconfig:"GITNESS_SSH_SERVER_HOST_KEYS" default:"ssh/gitness.ed25519"`
+		// KeepAliveInterval defines how often the server sends keepalive requests to the client (0 disables them).
+		KeepAliveInterval time.Duration `envconfig:"GITNESS_SSH_KEEP_ALIVE_INTERVAL" default:"15s"`
+	}
+
 	// CI defines configuration related to build executions.
 	CI struct {
 		ParallelWorkers int `envconfig:"GITNESS_CI_PARALLEL_WORKERS" default:"2"`
@@ -231,6 +267,12 @@ type Config struct {
 			DisplayName string `envconfig:"GITNESS_PRINCIPAL_PIPELINE_DISPLAY_NAME" default:"Gitness Pipeline"`
 			Email       string `envconfig:"GITNESS_PRINCIPAL_PIPELINE_EMAIL"        default:"pipeline@gitness.io"`
 		}
+		// DeployKey defines the principal information used to create the deploy key service.
+		DeployKey struct {
+			UID         string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_UID"          default:"deploy-key"`
+			DisplayName string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_DISPLAY_NAME" default:"Gitness Deploy Key"`
+			Email       string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_EMAIL"        default:"deploy-key@gitness.io"`
+		}
 		// Admin defines the principal information used to create the admin user.
 		// NOTE: The admin user is only auto-created in case a password and an email is provided.
 		Admin struct {
@@ -326,6 +368,14 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	Mirror struct {
+		// DefaultCron is the schedule of the mirrors that don't specify their own.
+		DefaultCron string        `envconfig:"GITNESS_MIRROR_DEFAULT_CRON" default:"0 */4 * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_MIRROR_MAX_DURATION" default:"30m"`
+		// PushMaxRetries is the number of times a failed push to a push mirror is retried.
+		PushMaxRetries int `envconfig:"GITNESS_MIRROR_PUSH_MAX_RETRIES" default:"3"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
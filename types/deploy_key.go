@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// DeployKey represents a public key that grants access to a single repository.
+type DeployKey struct {
+	ID         int64  `json:"-"`
+	RepoID     int64  `json:"-"`
+	Identifier string `json:"identifier"`
+	Created    int64  `json:"created"`
+	CreatedBy  int64  `json:"created_by"`
+	// LastUsed is the unix time at which the key was last used for authentication (nil if never used).
+	LastUsed *int64 `json:"last_used"`
+	// ReadOnly restricts the key to read access (PermissionRepoView), otherwise it can also push.
+	ReadOnly    bool   `json:"read_only"`
+	Fingerprint string `json:"fingerprint"`
+	Content     string `json:"-"`
+	Comment     string `json:"comment"`
+	Type        string `json:"type"`
+}
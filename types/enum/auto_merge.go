@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AutoMergeAction represents a change of a pull request's auto-merge status.
+type AutoMergeAction string
+
+// AutoMergeAction enumeration.
+const (
+	// AutoMergeActionEnabled is used when a user enables auto-merge for a pull request.
+	AutoMergeActionEnabled AutoMergeAction = "enabled"
+	// AutoMergeActionDisabled is used when a user disables auto-merge for a pull request.
+	AutoMergeActionDisabled AutoMergeAction = "disabled"
+	// AutoMergeActionCancelled is used when the system cancels auto-merge for a pull request.
+	AutoMergeActionCancelled AutoMergeAction = "cancelled"
+)
+
+var autoMergeActions = sortEnum([]AutoMergeAction{
+	AutoMergeActionEnabled,
+	AutoMergeActionDisabled,
+	AutoMergeActionCancelled,
+})
+
+func (AutoMergeAction) Enum() []interface{} { return toInterfaceSlice(autoMergeActions) }
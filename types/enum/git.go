@@ -114,3 +114,43 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return GitServiceType(""), fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// GitSignatureStatus represents the verification status of the signature of a commit or tag.
+type GitSignatureStatus string
+
+// GitSignatureStatus enumeration.
+const (
+	// GitSignatureStatusVerified is used for valid signatures created with a key
+	// that's registered by the principal matching the committer (or tagger).
+	GitSignatureStatusVerified GitSignatureStatus = "verified"
+	// GitSignatureStatusUnverified is used for signatures that are invalid or don't match the committer (or tagger).
+	GitSignatureStatusUnverified GitSignatureStatus = "unverified"
+	// GitSignatureStatusUnknownKey is used for signatures created with a key that isn't registered.
+	GitSignatureStatusUnknownKey GitSignatureStatus = "unknown_key"
+)
+
+var gitSignatureStatuses = sortEnum([]GitSignatureStatus{
+	GitSignatureStatusVerified,
+	GitSignatureStatusUnverified,
+	GitSignatureStatusUnknownKey,
+})
+
+func (GitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(gitSignatureStatuses) }
+
+// GitSignatureType represents the type of the signature of a commit or tag.
+type GitSignatureType string
+
+// GitSignatureType enumeration.
+const (
+	GitSignatureTypeGPG     GitSignatureType = "gpg"
+	GitSignatureTypeSSH     GitSignatureType = "ssh"
+	GitSignatureTypeUnknown GitSignatureType = "unknown"
+)
+
+var gitSignatureTypes = sortEnum([]GitSignatureType{
+	GitSignatureTypeGPG,
+	GitSignatureTypeSSH,
+	GitSignatureTypeUnknown,
+})
+
+func (GitSignatureType) Enum() []interface{} { return toInterfaceSlice(gitSignatureTypes) }
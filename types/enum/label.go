@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// LabelColor represents the color of a label.
+type LabelColor string
+
+// LabelColor enumeration.
+const (
+	LabelColorGray   LabelColor = "gray"
+	LabelColorRed    LabelColor = "red"
+	LabelColorOrange LabelColor = "orange"
+	LabelColorYellow LabelColor = "yellow"
+	LabelColorGreen  LabelColor = "green"
+	LabelColorCyan   LabelColor = "cyan"
+	LabelColorBlue   LabelColor = "blue"
+	LabelColorPurple LabelColor = "purple"
+	LabelColorPink   LabelColor = "pink"
+)
+
+var labelColors = sortEnum([]LabelColor{
+	LabelColorGray,
+	LabelColorRed,
+	LabelColorOrange,
+	LabelColorYellow,
+	LabelColorGreen,
+	LabelColorCyan,
+	LabelColorBlue,
+	LabelColorPurple,
+	LabelColorPink,
+})
+
+func (LabelColor) Enum() []interface{} { return toInterfaceSlice(labelColors) }
+func (c LabelColor) Sanitize() (LabelColor, bool) {
+	return Sanitize(c, GetAllLabelColors)
+}
+func GetAllLabelColors() ([]LabelColor, LabelColor) {
+	return labelColors, LabelColorGray
+}
+
+// LabelAction represents a change of the labels assigned to a pull request.
+type LabelAction string
+
+// LabelAction enumeration.
+const (
+	LabelActionAssigned   LabelAction = "assigned"
+	LabelActionUnassigned LabelAction = "unassigned"
+)
+
+var labelActions = sortEnum([]LabelAction{
+	LabelActionAssigned,
+	LabelActionUnassigned,
+})
+
+func (LabelAction) Enum() []interface{} { return toInterfaceSlice(labelActions) }
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueAction represents a change of a pull request's merge queue status.
+type MergeQueueAction string
+
+// MergeQueueAction enumeration.
+const (
+	// MergeQueueActionAdded is used when a pull request is added to the merge queue.
+	MergeQueueActionAdded MergeQueueAction = "added"
+	// MergeQueueActionRemoved is used when a pull request is removed from the merge queue by a user.
+	MergeQueueActionRemoved MergeQueueAction = "removed"
+	// MergeQueueActionEjected is used when the merge queue removes a pull request that can't be merged.
+	MergeQueueActionEjected MergeQueueAction = "ejected"
+)
+
+var mergeQueueActions = sortEnum([]MergeQueueAction{
+	MergeQueueActionAdded,
+	MergeQueueActionRemoved,
+	MergeQueueActionEjected,
+})
+
+func (MergeQueueAction) Enum() []interface{} { return toInterfaceSlice(mergeQueueActions) }
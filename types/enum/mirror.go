@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MirrorSyncState represents the state of the latest synchronization of a mirror repository.
+type MirrorSyncState string
+
+// MirrorSyncState enumeration.
+const (
+	// MirrorSyncStatePending is used for mirrors that haven't been synchronized yet.
+	MirrorSyncStatePending MirrorSyncState = "pending"
+	// MirrorSyncStateSuccess is used if the latest synchronization succeeded.
+	MirrorSyncStateSuccess MirrorSyncState = "success"
+	// MirrorSyncStateFailed is used if the latest synchronization failed.
+	MirrorSyncStateFailed MirrorSyncState = "failed"
+)
+
+var mirrorSyncStates = sortEnum([]MirrorSyncState{
+	MirrorSyncStatePending,
+	MirrorSyncStateSuccess,
+	MirrorSyncStateFailed,
+})
+
+func (MirrorSyncState) Enum() []interface{} { return toInterfaceSlice(mirrorSyncStates) }
+func (s MirrorSyncState) Sanitize() (MirrorSyncState, bool) {
+	return Sanitize(s, GetAllMirrorSyncStates)
+}
+func GetAllMirrorSyncStates() ([]MirrorSyncState, MirrorSyncState) {
+	return mirrorSyncStates, MirrorSyncStatePending
+}
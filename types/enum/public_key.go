@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PublicKeyUsage represents the purpose of a public key.
+type PublicKeyUsage string
+
+// PublicKeyUsage enumeration.
+const (
+	// PublicKeyUsageAuth is used for keys that authenticate git operations over ssh.
+	PublicKeyUsageAuth PublicKeyUsage = "auth"
+	// PublicKeyUsageSign is used for keys that verify ssh signatures of commits and tags.
+	PublicKeyUsageSign PublicKeyUsage = "sign"
+)
+
+var publicKeyUsages = sortEnum([]PublicKeyUsage{
+	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
+})
+
+func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyUsages) }
+func (u PublicKeyUsage) Sanitize() (PublicKeyUsage, bool) {
+	return Sanitize(u, GetAllPublicKeyUsages)
+}
+func GetAllPublicKeyUsages() ([]PublicKeyUsage, PublicKeyUsage) {
+	return publicKeyUsages, PublicKeyUsageAuth
+}
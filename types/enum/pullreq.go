@@ -78,14 +78,20 @@ func GetAllPullReqActivityTypes() ([]PullReqActivityType, PullReqActivityType) {
 
 // PullReqActivityType enumeration.
 const (
-	PullReqActivityTypeComment      PullReqActivityType = "comment"
-	PullReqActivityTypeCodeComment  PullReqActivityType = "code-comment"
-	PullReqActivityTypeTitleChange  PullReqActivityType = "title-change"
-	PullReqActivityTypeStateChange  PullReqActivityType = "state-change"
-	PullReqActivityTypeReviewSubmit PullReqActivityType = "review-submit"
-	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
-	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
-	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeComment            PullReqActivityType = "comment"
+	PullReqActivityTypeCodeComment        PullReqActivityType = "code-comment"
+	PullReqActivityTypeTitleChange        PullReqActivityType = "title-change"
+	PullReqActivityTypeStateChange        PullReqActivityType = "state-change"
+	PullReqActivityTypeReviewSubmit       PullReqActivityType = "review-submit"
+	PullReqActivityTypeBranchUpdate       PullReqActivityType = "branch-update"
+	PullReqActivityTypeBranchDelete       PullReqActivityType = "branch-delete"
+	PullReqActivityTypeMerge              PullReqActivityType = "merge"
+	PullReqActivityTypeRevert             PullReqActivityType = "revert"
+	PullReqActivityTypeMergeQueue         PullReqActivityType = "merge-queue"
+	PullReqActivityTypeAutoMerge          PullReqActivityType = "auto-merge"
+	PullReqActivityTypeTargetBranchChange PullReqActivityType = "target-branch-change"
+	PullReqActivityTypeRebaseConflict     PullReqActivityType = "rebase-conflict"
+	PullReqActivityTypeLabelModify        PullReqActivityType = "label-modify"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -97,6 +103,12 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeRevert,
+	PullReqActivityTypeMergeQueue,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeTargetBranchChange,
+	PullReqActivityTypeRebaseConflict,
+	PullReqActivityTypeLabelModify,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -211,15 +223,17 @@ type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
 const (
-	MergeMethodMerge  = MergeMethod(gitenum.MergeMethodMerge)
-	MergeMethodSquash = MergeMethod(gitenum.MergeMethodSquash)
-	MergeMethodRebase = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodMerge       = MergeMethod(gitenum.MergeMethodMerge)
+	MergeMethodSquash      = MergeMethod(gitenum.MergeMethodSquash)
+	MergeMethodRebase      = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodFastForward = MergeMethod(gitenum.MergeMethodFastForward)
 )
 
 var MergeMethods = sortEnum([]MergeMethod{
 	MergeMethodMerge,
 	MergeMethodSquash,
 	MergeMethodRebase,
+	MergeMethodFastForward,
 })
 
 func (MergeMethod) Enum() []interface{} { return toInterfaceSlice(MergeMethods) }
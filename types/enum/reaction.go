@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ReactionType represents the kind of reaction to a pull request description or comment.
+type ReactionType string
+
+// ReactionType enumeration.
+const (
+	ReactionTypePlusOne  ReactionType = "+1"
+	ReactionTypeMinusOne ReactionType = "-1"
+	ReactionTypeLaugh    ReactionType = "laugh"
+	ReactionTypeHooray   ReactionType = "hooray"
+	ReactionTypeConfused ReactionType = "confused"
+	ReactionTypeHeart    ReactionType = "heart"
+	ReactionTypeRocket   ReactionType = "rocket"
+	ReactionTypeEyes     ReactionType = "eyes"
+)
+
+var reactionTypes = sortEnum([]ReactionType{
+	ReactionTypePlusOne,
+	ReactionTypeMinusOne,
+	ReactionTypeLaugh,
+	ReactionTypeHooray,
+	ReactionTypeConfused,
+	ReactionTypeHeart,
+	ReactionTypeRocket,
+	ReactionTypeEyes,
+})
+
+func (ReactionType) Enum() []interface{} { return toInterfaceSlice(reactionTypes) }
+func (t ReactionType) Sanitize() (ReactionType, bool) {
+	return Sanitize(t, GetAllReactionTypes)
+}
+func GetAllReactionTypes() ([]ReactionType, ReactionType) {
+	return reactionTypes, ""
+}
@@ -158,6 +158,12 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqLabelAssigned gets triggered when a label is assigned to a pull request.
+	WebhookTriggerPullReqLabelAssigned WebhookTrigger = "pullreq_label_assigned"
+	// WebhookTriggerPullReqLabelUnassigned gets triggered when a label is removed from a pull request.
+	WebhookTriggerPullReqLabelUnassigned WebhookTrigger = "pullreq_label_unassigned"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a pull request review is submitted.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +179,7 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqLabelAssigned,
+	WebhookTriggerPullReqLabelUnassigned,
+	WebhookTriggerPullReqReviewSubmitted,
 })
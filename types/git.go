@@ -84,6 +84,18 @@ type Commit struct {
 	Author     Signature   `json:"author"`
 	Committer  Signature   `json:"committer"`
 	Stats      CommitStats `json:"stats,omitempty"`
+	// Verification is the verification result of the signature of the commit (nil if the commit isn't signed).
+	Verification *SignatureVerification `json:"verification,omitempty"`
+}
+
+// SignatureVerification is the result of the verification of the signature of a commit or tag.
+type SignatureVerification struct {
+	Status enum.GitSignatureStatus `json:"status"`
+	Type   enum.GitSignatureType   `json:"type"`
+	// KeyID identifies the key the signature was created with (GPG key ID or SSH key fingerprint).
+	KeyID string `json:"key_id,omitempty"`
+	// Signer is the principal owning the key the signature was created with (nil if unknown).
+	Signer *PrincipalInfo `json:"signer,omitempty"`
 }
 
 type Signature struct {
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID            int64            `json:"-"`
+	RepoID        int64            `json:"repo_id"`
+	PullReqID     int64            `json:"pullreq_id"`
+	PullReqNumber int64            `json:"pullreq_number"`
+	Branch        string           `json:"branch"`
+	SourceSHA     string           `json:"source_sha"`
+	Method        enum.MergeMethod `json:"method"`
+	Title         string           `json:"title"`
+	Message       string           `json:"message"`
+
+	// BaseSHA is the commit the speculative merge commit has been created on top of:
+	// Either the target branch or the speculative merge commit of the preceding entry.
+	BaseSHA string `json:"base_sha"`
+	// MergeBaseSHA is the merge base of the source commit and BaseSHA.
+	MergeBaseSHA string `json:"merge_base_sha"`
+	// MergeSHA is the speculative merge commit. It's empty until the merge queue creates it.
+	MergeSHA string `json:"merge_sha"`
+
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	CreatedBy int64 `json:"created_by"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PublicKey represents a public key of a principal.
+type PublicKey struct {
+	ID          int64  `json:"-"`
+	PrincipalID int64  `json:"-"`
+	Identifier  string `json:"identifier"`
+	Created     int64  `json:"created"`
+	// LastUsed is the unix time at which the key was last used for authentication (nil if never used).
+	LastUsed    *int64 `json:"last_used"`
+	Fingerprint string `json:"fingerprint"`
+	Content     string `json:"-"`
+	Comment     string `json:"comment"`
+	Type        string `json:"type"`
+	// Usage is the purpose of the key - authentication of git operations or verification of signatures.
+	Usage enum.PublicKeyUsage `json:"usage"`
+}
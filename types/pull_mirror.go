@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullMirror represents the configuration and the synchronization state of a repository
+// that mirrors a remote git repository.
+type PullMirror struct {
+	RepoID    int64  `json:"repo_id"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	CreatedBy int64  `json:"created_by"`
+	RemoteURL string `json:"remote_url"`
+	// Credentials are the encrypted credentials used to access the remote repository.
+	Credentials []byte `json:"-"`
+	Cron        string `json:"cron"`
+
+	LastSync      int64                `json:"last_sync,omitempty"`
+	LastSyncState enum.MirrorSyncState `json:"last_sync_state"`
+	LastSyncError string               `json:"last_sync_error,omitempty"`
+}
+
+// MirrorCredentials are the credentials used to access the remote repository of a mirror.
+type MirrorCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
@@ -55,10 +55,20 @@ type PullReq struct {
 	MergeBaseSHA     string                `json:"merge_base_sha"`
 	MergeSHA         *string               `json:"merge_sha"`
 	MergeConflicts   []string              `json:"merge_conflicts,omitempty"`
+	// MergeNotFastForwardable is true if the merge check found that the target branch
+	// isn't an ancestor of the source branch, so the fast-forward merge method can't be used.
+	MergeNotFastForwardable bool `json:"merge_not_fast_forwardable,omitempty"`
+
+	// AutoMergeMethod is set if the pull request should be merged as soon as all merge requirements are satisfied.
+	AutoMergeMethod *enum.MergeMethod `json:"auto_merge_method,omitempty"`
+	AutoMergeBy     *int64            `json:"auto_merge_by,omitempty"`
 
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
+
+	// Reactions holds the reactions to the pull request description.
+	Reactions []ReactionSummary `json:"reactions,omitempty"`
 }
 
 // DiffStats shows total number of commits and modified files.
@@ -95,6 +105,7 @@ type PullReqFilter struct {
 	TargetRepoID  int64               `json:"-"`
 	TargetBranch  string              `json:"target_branch"`
 	States        []enum.PullReqState `json:"state"`
+	LabelIDs      []int64             `json:"label_id"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
 }
@@ -133,6 +144,29 @@ type PullReqReviewer struct {
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// PullReqDependency holds a dependency of a pull request on a parent pull request.
+// A pull request can't be merged until all of its parent pull requests are merged.
+type PullReqDependency struct {
+	PullReqID int64 `json:"pullreq_id"`
+	ParentID  int64 `json:"parent_id"`
+
+	Created   int64 `json:"created"`
+	CreatedBy int64 `json:"created_by"`
+}
+
+// PullReqDependencies holds the parent and child pull requests of a pull request.
+type PullReqDependencies struct {
+	Parents  []*PullReq `json:"parents"`
+	Children []*PullReq `json:"children"`
+}
+
+// PullReqTemplate is a pull request description template stored in a repository.
+type PullReqTemplate struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
 // PullReqFileView represents a file reviewed entry for a given pr and principal.
 // NOTE: keep api lightweight and don't return unnecessary extra data.
 type PullReqFileView struct {
@@ -155,6 +189,7 @@ type MergeResponse struct {
 	// values only returned on dryrun
 	DryRun                        bool               `json:"dry_run,omitempty"`
 	ConflictFiles                 []string           `json:"conflict_files,omitempty"`
+	FastForwardable               bool               `json:"fast_forwardable,omitempty"`
 	AllowedMethods                []enum.MergeMethod `json:"allowed_methods,omitempty"`
 	MinimumRequiredApprovalsCount int                `json:"minimum_required_approvals_count,omitempty"`
 	RequiresCodeOwnersApproval    bool               `json:"requires_code_owners_approval,omitempty"`
@@ -163,6 +198,9 @@ type MergeResponse struct {
 }
 
 type MergeViolations struct {
-	ConflictFiles  []string         `json:"conflict_files,omitempty"`
-	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	ConflictFiles []string `json:"conflict_files,omitempty"`
+	// NotFastForwardable is true if the fast-forward merge method was requested,
+	// but the target branch isn't an ancestor of the source branch.
+	NotFastForwardable bool             `json:"not_fast_forwardable,omitempty"`
+	RuleViolations     []RuleViolations `json:"rule_violations,omitempty"`
 }
@@ -60,10 +60,16 @@ type PullReqActivity struct {
 	ResolvedBy *int64 `json:"-"` // not returned, because the resolver info is in the Resolver field
 	Resolved   *int64 `json:"resolved,omitempty"`
 
+	// Pending is true for review comments that are visible only to their author
+	// until they get published with the review submission.
+	Pending bool `json:"pending,omitempty"`
+
 	Author   PrincipalInfo  `json:"author"`
 	Resolver *PrincipalInfo `json:"resolver,omitempty"`
 
 	CodeComment *CodeCommentFields `json:"code_comment,omitempty"`
+
+	Reactions []ReactionSummary `json:"reactions,omitempty"`
 }
 
 func (a *PullReqActivity) IsValidCodeComment() bool {
@@ -104,7 +110,8 @@ func (a *PullReqActivity) IsReply() bool {
 
 // IsBlocking returns true if the pull request activity (comment/code-comment) is blocking the pull request merge.
 func (a *PullReqActivity) IsBlocking() bool {
-	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && a.Kind != enum.PullReqActivityKindSystem
+	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && !a.Pending &&
+		a.Kind != enum.PullReqActivityKindSystem
 }
 
 // SetPayload sets the payload and verifies it's of correct type for the activity.
@@ -157,6 +164,10 @@ type PullReqActivityFilter struct {
 
 	Types []enum.PullReqActivityType `json:"type"`
 	Kinds []enum.PullReqActivityKind `json:"kind"`
+
+	// PendingAuthorID includes the pending review comments of the principal.
+	// Pending review comments of other principals are never included.
+	PendingAuthorID int64 `json:"-"`
 }
 
 // PullReqActivityPayload is an interface used to identify PR activity payload types.
@@ -190,6 +201,12 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadRevert{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetBranchChange{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadRebaseConflict{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadLabel{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -213,6 +230,23 @@ type PullRequestActivityPayloadCodeComment struct {
 	Lines        []string `json:"lines"`
 	LineStartNew bool     `json:"line_start_new"`
 	LineEndNew   bool     `json:"line_end_new"`
+
+	// Suggestion is the replacement proposed for the commented lines (optional).
+	Suggestion *CodeSuggestion `json:"suggestion,omitempty"`
+}
+
+// CodeSuggestion holds the lines that should replace the lines of the source branch file a code comment refers to.
+type CodeSuggestion struct {
+	Lines []string `json:"lines"`
+
+	// AppliedSHA is the commit on the source branch with which the suggestion got applied.
+	AppliedSHA string `json:"applied_sha,omitempty"`
+	AppliedBy  *int64 `json:"applied_by,omitempty"`
+	Applied    *int64 `json:"applied,omitempty"`
+}
+
+func (s *CodeSuggestion) IsApplied() bool {
+	return s.Applied != nil
 }
 
 func (a *PullRequestActivityPayloadCodeComment) ActivityType() enum.PullReqActivityType {
@@ -275,3 +309,75 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+// PullRequestActivityPayloadRevert links a merged pull request and the pull request reverting its changes.
+// It's written to the timelines of both pull requests.
+type PullRequestActivityPayloadRevert struct {
+	RevertedPullReqNumber int64  `json:"reverted_pullreq_number"`
+	RevertPullReqNumber   int64  `json:"revert_pullreq_number"`
+	MergeSHA              string `json:"merge_sha"`
+}
+
+func (a *PullRequestActivityPayloadRevert) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeRevert
+}
+
+// PullRequestActivityPayloadMergeQueue records a change of the pull request's merge queue status.
+// Reason is provided only when the pull request has been ejected from the merge queue.
+type PullRequestActivityPayloadMergeQueue struct {
+	Action enum.MergeQueueAction `json:"action"`
+	Reason string                `json:"reason,omitempty"`
+	SHA    string                `json:"sha,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
+// PullRequestActivityPayloadAutoMerge records a change of the pull request's auto-merge status.
+// Reason is provided only when the auto-merge has been cancelled by the system.
+type PullRequestActivityPayloadAutoMerge struct {
+	Action enum.AutoMergeAction `json:"action"`
+	Method enum.MergeMethod     `json:"method,omitempty"`
+	Reason string               `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+// PullRequestActivityPayloadTargetBranchChange records a change of the pull request's target branch.
+// ParentNumber is provided if the pull request has been retargeted after its parent pull request got merged.
+type PullRequestActivityPayloadTargetBranchChange struct {
+	Old          string `json:"old"`
+	New          string `json:"new"`
+	ParentNumber int64  `json:"parent_number,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadTargetBranchChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetBranchChange
+}
+
+// PullRequestActivityPayloadRebaseConflict records that the source branch of the pull request
+// couldn't be rebased automatically on top of its new target branch.
+type PullRequestActivityPayloadRebaseConflict struct {
+	TargetBranch  string   `json:"target_branch"`
+	ConflictFiles []string `json:"conflict_files,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadRebaseConflict) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeRebaseConflict
+}
+
+// PullRequestActivityPayloadLabel records an assignment or removal of a label.
+// The label name and color are copied so that the entry stays readable after the label changes.
+type PullRequestActivityPayloadLabel struct {
+	Action  enum.LabelAction `json:"action"`
+	LabelID int64            `json:"label_id"`
+	Name    string           `json:"name"`
+	Color   enum.LabelColor  `json:"color"`
+}
+
+func (a *PullRequestActivityPayloadLabel) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeLabelModify
+}
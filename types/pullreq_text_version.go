@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PullReqTextVersion is a version of the text of a pull request comment
+// or of the title and the description of a pull request.
+type PullReqTextVersion struct {
+	ID        int64 `json:"id"`
+	PullReqID int64 `json:"-"`
+	// ActivityID is the ID of the comment, it's nil for versions of the pull request title and description.
+	ActivityID *int64 `json:"-"`
+
+	// Title is set only for versions of the pull request title and description.
+	Title string `json:"title,omitempty"`
+	// Text is the comment text or the pull request description.
+	Text string `json:"text"`
+
+	// Created and CreatedBy are unknown (empty) for the original version
+	// if the text had been edited before the edit history was recorded.
+	Created   int64  `json:"created,omitempty"`
+	CreatedBy *int64 `json:"-"` // not returned, because the author info is in the Author field
+
+	Author *PrincipalInfo `json:"author,omitempty"`
+}
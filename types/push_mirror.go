@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PushMirror represents a remote git repository the references of a repository are pushed to after every change.
+type PushMirror struct {
+	ID         int64  `json:"-"`
+	RepoID     int64  `json:"repo_id"`
+	Identifier string `json:"identifier"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+	CreatedBy  int64  `json:"created_by"`
+	RemoteURL  string `json:"remote_url"`
+	// Credentials are the encrypted credentials used to access the remote repository.
+	Credentials []byte `json:"-"`
+	// Refs are the patterns of the references that are pushed to the remote repository (e.g. "refs/heads/*").
+	Refs []string `json:"refs"`
+
+	LastSuccess int64  `json:"last_success,omitempty"`
+	LastFailure int64  `json:"last_failure,omitempty"`
+	LastError   string `json:"last_error,omitempty"`
+}
@@ -50,9 +50,11 @@ type Repository struct {
 	NumMergedPulls int `json:"num_merged_pulls"`
 
 	Importing bool `json:"importing"`
+	IsMirror  bool `json:"is_mirror"`
 
 	// git urls
-	GitURL string `json:"git_url"`
+	GitURL    string `json:"git_url"`
+	GitSSHURL string `json:"git_ssh_url,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.